@@ -0,0 +1,22 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+type AffordabilityConfig struct {
+	MaxInstallmentRatio float64
+}
+
+func LoadAffordabilityConfig() AffordabilityConfig {
+	cfg := AffordabilityConfig{
+		MaxInstallmentRatio: 30,
+	}
+
+	if value, err := strconv.ParseFloat(os.Getenv("AFFORDABILITY_MAX_INSTALLMENT_RATIO"), 64); err == nil && value >= 0 && value <= 100 {
+		cfg.MaxInstallmentRatio = value
+	}
+
+	return cfg
+}
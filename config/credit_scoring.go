@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+type CreditScoringConfig struct {
+	MinAge              int
+	MaxAgeAtMaturity    int
+	MaxInstallmentRatio float64
+	LimitRounding       int64
+}
+
+func LoadCreditScoringConfig() CreditScoringConfig {
+	cfg := CreditScoringConfig{
+		MinAge:              21,
+		MaxAgeAtMaturity:    65,
+		MaxInstallmentRatio: 30,
+		LimitRounding:       100000,
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("CREDIT_SCORING_MIN_AGE")); err == nil && value > 0 {
+		cfg.MinAge = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("CREDIT_SCORING_MAX_AGE_AT_MATURITY")); err == nil && value > 0 {
+		cfg.MaxAgeAtMaturity = value
+	}
+
+	if value, err := strconv.ParseFloat(os.Getenv("CREDIT_SCORING_MAX_INSTALLMENT_RATIO"), 64); err == nil && value > 0 && value <= 100 {
+		cfg.MaxInstallmentRatio = value
+	}
+
+	if value, err := strconv.ParseInt(os.Getenv("CREDIT_SCORING_LIMIT_ROUNDING"), 10, 64); err == nil && value > 0 {
+		cfg.LimitRounding = value
+	}
+
+	return cfg
+}
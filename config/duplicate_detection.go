@@ -0,0 +1,39 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+type DuplicateDetectionConfig struct {
+	PhotoHashMaxDistance int
+	BlockingScore        int
+	MaxResults           int
+	AutoApproveKYC       bool
+}
+
+func LoadDuplicateDetectionConfig() DuplicateDetectionConfig {
+	cfg := DuplicateDetectionConfig{
+		PhotoHashMaxDistance: 10,
+		BlockingScore:        40,
+		MaxResults:           10,
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("DUPLICATE_DETECTION_PHOTO_HASH_MAX_DISTANCE")); err == nil && value >= 0 && value <= 64 {
+		cfg.PhotoHashMaxDistance = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("DUPLICATE_DETECTION_BLOCKING_SCORE")); err == nil && value > 0 && value <= 100 {
+		cfg.BlockingScore = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("DUPLICATE_DETECTION_MAX_RESULTS")); err == nil && value > 0 {
+		cfg.MaxResults = value
+	}
+
+	if value, err := strconv.ParseBool(os.Getenv("KYC_AUTO_APPROVE")); err == nil {
+		cfg.AutoApproveKYC = value
+	}
+
+	return cfg
+}
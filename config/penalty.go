@@ -0,0 +1,28 @@
+package config
+
+import (
+	"os"
+	"strconv"
+)
+
+type PenaltyConfig struct {
+	DailyRate float64
+	GraceDays int
+}
+
+func LoadPenaltyConfig() PenaltyConfig {
+	cfg := PenaltyConfig{
+		DailyRate: 0.1,
+		GraceDays: 0,
+	}
+
+	if value, err := strconv.ParseFloat(os.Getenv("LATE_PENALTY_DAILY_RATE"), 64); err == nil && value >= 0 {
+		cfg.DailyRate = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("LATE_PENALTY_GRACE_DAYS")); err == nil && value >= 0 {
+		cfg.GraceDays = value
+	}
+
+	return cfg
+}
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"kreditplus/internal/money"
+	"os"
+	"strconv"
+)
+
+type SettlementConfig struct {
+	TerminationFeeRate       float64
+	MinimumTerminationFee    money.Money
+	FeeWaivedWithinRemaining int
+}
+
+func LoadSettlementConfig() SettlementConfig {
+	cfg := SettlementConfig{
+		TerminationFeeRate:       2,
+		MinimumTerminationFee:    money.Money{},
+		FeeWaivedWithinRemaining: 1,
+	}
+
+	if value, err := strconv.ParseFloat(os.Getenv("EARLY_TERMINATION_FEE_RATE"), 64); err == nil && value >= 0 {
+		cfg.TerminationFeeRate = value
+	}
+
+	if value, err := money.Parse(os.Getenv("EARLY_TERMINATION_MIN_FEE")); err == nil && !value.IsNegative() {
+		cfg.MinimumTerminationFee = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("EARLY_TERMINATION_FEE_WAIVED_WITHIN")); err == nil && value >= 0 {
+		cfg.FeeWaivedWithinRemaining = value
+	}
+
+	return cfg
+}
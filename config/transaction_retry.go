@@ -0,0 +1,48 @@
+package config
+
+import (
+	"database/sql"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type TransactionRetryConfig struct {
+	MaxAttempts    int
+	BaseDelay      time.Duration
+	MaxDelay       time.Duration
+	IsolationLevel sql.IsolationLevel
+}
+
+func LoadTransactionRetryConfig() TransactionRetryConfig {
+	cfg := TransactionRetryConfig{
+		MaxAttempts:    3,
+		BaseDelay:      50 * time.Millisecond,
+		MaxDelay:       time.Second,
+		IsolationLevel: sql.LevelDefault,
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("TX_RETRY_MAX_ATTEMPTS")); err == nil && value >= 1 {
+		cfg.MaxAttempts = value
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("TX_RETRY_BASE_DELAY_MS")); err == nil && value >= 0 {
+		cfg.BaseDelay = time.Duration(value) * time.Millisecond
+	}
+
+	if value, err := strconv.Atoi(os.Getenv("TX_RETRY_MAX_DELAY_MS")); err == nil && value >= 0 {
+		cfg.MaxDelay = time.Duration(value) * time.Millisecond
+	}
+
+	switch strings.ToLower(strings.TrimSpace(os.Getenv("TX_ISOLATION_LEVEL"))) {
+	case "read_committed", "read committed":
+		cfg.IsolationLevel = sql.LevelReadCommitted
+	case "repeatable_read", "repeatable read":
+		cfg.IsolationLevel = sql.LevelRepeatableRead
+	case "serializable":
+		cfg.IsolationLevel = sql.LevelSerializable
+	}
+
+	return cfg
+}
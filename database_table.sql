@@ -2,7 +2,8 @@ CREATE TABLE users (
     user_id SERIAL PRIMARY KEY,
     user_username VARCHAR(50) UNIQUE NOT NULL,
     user_password VARCHAR(255) NOT NULL,
-    user_role VARCHAR(20) CHECK (user_role IN ('admin', 'user')) NOT NULL
+    user_role VARCHAR(20) CHECK (user_role IN ('admin', 'user')) NOT NULL,
+    user_can_override_pricing BOOLEAN NOT NULL DEFAULT FALSE
 );
 
 CREATE TABLE customers (
@@ -15,23 +16,178 @@ CREATE TABLE customers (
     customer_salary DECIMAL(15,2) NOT NULL,
     customer_ktp_photo TEXT NOT NULL,
     customer_selfie_photo TEXT NOT NULL,
+    customer_phone VARCHAR(20) NOT NULL DEFAULT '',
+    customer_email VARCHAR(255) NOT NULL DEFAULT '',
+    customer_ktp_phash VARCHAR(16) NOT NULL DEFAULT '',
+    customer_selfie_phash VARCHAR(16) NOT NULL DEFAULT '',
+    customer_risk_grade VARCHAR(1) CHECK (customer_risk_grade IN ('', 'A', 'B', 'C', 'D', 'E')) NOT NULL DEFAULT '',
+    customer_kyc_status VARCHAR(20) CHECK (customer_kyc_status IN ('pending', 'verified', 'rejected', 'needs_resubmission')) NOT NULL DEFAULT 'pending',
+    customer_kyc_reason VARCHAR(255) NOT NULL DEFAULT '',
+    customer_kyc_submitted_at TIMESTAMP,
+    customer_kyc_reviewed_by INT REFERENCES users(user_id),
+    customer_kyc_reviewed_at TIMESTAMP,
     customer_created_by INT NOT NULL REFERENCES users(user_id),
     customer_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     customer_edited_by INT REFERENCES users(user_id),
     customer_edited_at TIMESTAMP
 );
 
+CREATE TABLE customer_addresses (
+    address_id SERIAL PRIMARY KEY,
+    address_customer_id INT NOT NULL REFERENCES customers(customer_id) ON DELETE CASCADE,
+    address_type VARCHAR(10) CHECK (address_type IN ('ktp', 'domicile')) NOT NULL,
+    address_line VARCHAR(255) NOT NULL,
+    address_rt VARCHAR(3) NOT NULL DEFAULT '',
+    address_rw VARCHAR(3) NOT NULL DEFAULT '',
+    address_village VARCHAR(100) NOT NULL DEFAULT '',
+    address_district VARCHAR(100) NOT NULL DEFAULT '',
+    address_city VARCHAR(100) NOT NULL,
+    address_province VARCHAR(100) NOT NULL,
+    address_postal_code VARCHAR(5) NOT NULL DEFAULT '',
+    address_created_by INT NOT NULL REFERENCES users(user_id),
+    address_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    address_edited_by INT REFERENCES users(user_id),
+    address_edited_at TIMESTAMP,
+    CONSTRAINT idx_customer_address_type UNIQUE (address_customer_id, address_type)
+);
+
+CREATE TABLE customer_phone_numbers (
+    phone_id SERIAL PRIMARY KEY,
+    phone_customer_id INT NOT NULL REFERENCES customers(customer_id) ON DELETE CASCADE,
+    phone_number VARCHAR(20) NOT NULL,
+    phone_label VARCHAR(10) CHECK (phone_label IN ('', 'mobile', 'home', 'office')) NOT NULL DEFAULT '',
+    phone_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    phone_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    phone_verified_by INT REFERENCES users(user_id),
+    phone_verified_at TIMESTAMP,
+    phone_created_by INT NOT NULL REFERENCES users(user_id),
+    phone_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    phone_edited_by INT REFERENCES users(user_id),
+    phone_edited_at TIMESTAMP,
+    CONSTRAINT idx_customer_phone_number UNIQUE (phone_customer_id, phone_number)
+);
+
+CREATE TABLE customer_email_addresses (
+    email_id SERIAL PRIMARY KEY,
+    email_customer_id INT NOT NULL REFERENCES customers(customer_id) ON DELETE CASCADE,
+    email_address VARCHAR(255) NOT NULL,
+    email_primary BOOLEAN NOT NULL DEFAULT FALSE,
+    email_verified BOOLEAN NOT NULL DEFAULT FALSE,
+    email_verified_by INT REFERENCES users(user_id),
+    email_verified_at TIMESTAMP,
+    email_created_by INT NOT NULL REFERENCES users(user_id),
+    email_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    email_edited_by INT REFERENCES users(user_id),
+    email_edited_at TIMESTAMP,
+    CONSTRAINT idx_customer_email_address UNIQUE (email_customer_id, email_address)
+);
+
+CREATE TABLE customer_employments (
+    employment_id SERIAL PRIMARY KEY,
+    employment_customer_id INT UNIQUE NOT NULL REFERENCES customers(customer_id) ON DELETE CASCADE,
+    employment_status VARCHAR(20) CHECK (employment_status IN ('employed', 'self_employed', 'unemployed', 'retired', 'student')) NOT NULL,
+    employment_employer_name VARCHAR(150) NOT NULL DEFAULT '',
+    employment_job_title VARCHAR(100) NOT NULL DEFAULT '',
+    employment_industry VARCHAR(100) NOT NULL DEFAULT '',
+    employment_employer_phone VARCHAR(20) NOT NULL DEFAULT '',
+    employment_employer_address VARCHAR(255) NOT NULL DEFAULT '',
+    employment_start_date DATE,
+    employment_created_by INT NOT NULL REFERENCES users(user_id),
+    employment_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    employment_edited_by INT REFERENCES users(user_id),
+    employment_edited_at TIMESTAMP
+);
+
+CREATE TABLE customer_emergency_contacts (
+    contact_id SERIAL PRIMARY KEY,
+    contact_customer_id INT NOT NULL REFERENCES customers(customer_id) ON DELETE CASCADE,
+    contact_name VARCHAR(150) NOT NULL,
+    contact_relationship VARCHAR(20) CHECK (contact_relationship IN ('spouse', 'parent', 'sibling', 'child', 'relative', 'friend', 'colleague')) NOT NULL,
+    contact_phone VARCHAR(20) NOT NULL,
+    contact_address VARCHAR(255) NOT NULL DEFAULT '',
+    contact_created_by INT NOT NULL REFERENCES users(user_id),
+    contact_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    contact_edited_by INT REFERENCES users(user_id),
+    contact_edited_at TIMESTAMP
+);
+
 CREATE TABLE limits (
     limit_id SERIAL PRIMARY KEY,
     limit_nik VARCHAR(16) NOT NULL REFERENCES customers(customer_nik) ON DELETE CASCADE,
-    limit_tenor INT CHECK (limit_tenor IN (1, 2, 3, 6)) NOT NULL,
+    limit_tenor INT CHECK (limit_tenor > 0) NOT NULL,
     limit_amount DECIMAL(15,2) NOT NULL,
     limit_used_amount DECIMAL(15,2) DEFAULT 0,
     limit_remaining_amount DECIMAL(15,2) NOT NULL,
     limit_created_by INT NOT NULL REFERENCES users(user_id),
     limit_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     limit_created_by INT REFERENCES users(user_id),
-    limit_created_at TIMESTAMP
+    limit_created_at TIMESTAMP,
+    CONSTRAINT idx_limits_nik_tenor UNIQUE (limit_nik, limit_tenor)
+);
+
+CREATE TABLE customer_limits (
+    customer_limit_id SERIAL PRIMARY KEY,
+    customer_limit_nik VARCHAR(16) UNIQUE NOT NULL REFERENCES customers(customer_nik) ON DELETE CASCADE,
+    customer_limit_amount DECIMAL(15,2) NOT NULL,
+    customer_limit_created_by INT NOT NULL REFERENCES users(user_id),
+    customer_limit_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    customer_limit_edited_by INT REFERENCES users(user_id),
+    customer_limit_edited_at TIMESTAMP
+);
+
+CREATE TABLE products (
+    product_id SERIAL PRIMARY KEY,
+    product_code VARCHAR(30) UNIQUE NOT NULL,
+    product_name VARCHAR(100) NOT NULL,
+    product_min_otr DECIMAL(15,2) NOT NULL,
+    product_max_otr DECIMAL(15,2) NOT NULL,
+    product_active BOOLEAN NOT NULL DEFAULT TRUE,
+    product_created_by INT NOT NULL REFERENCES users(user_id),
+    product_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    product_edited_by INT REFERENCES users(user_id),
+    product_edited_at TIMESTAMP,
+    CHECK (product_max_otr > product_min_otr)
+);
+
+CREATE TABLE product_tenors (
+    tenor_id SERIAL PRIMARY KEY,
+    tenor_product_id INT NOT NULL REFERENCES products(product_id) ON DELETE CASCADE,
+    tenor_months INT CHECK (tenor_months > 0) NOT NULL,
+    tenor_interest_rate DECIMAL(5,2) NOT NULL,
+    tenor_admin_fee DECIMAL(15,2) NOT NULL,
+    CONSTRAINT idx_product_tenors_product_months UNIQUE (tenor_product_id, tenor_months)
+);
+
+CREATE TABLE pricing_rules (
+    pricing_rule_id SERIAL PRIMARY KEY,
+    pricing_product_id INT NOT NULL REFERENCES products(product_id) ON DELETE CASCADE,
+    pricing_tenor INT CHECK (pricing_tenor >= 0) NOT NULL DEFAULT 0,
+    pricing_min_otr DECIMAL(15,2) NOT NULL DEFAULT 0,
+    pricing_max_otr DECIMAL(15,2) NOT NULL DEFAULT 0,
+    pricing_risk_grade VARCHAR(1) CHECK (pricing_risk_grade IN ('', 'A', 'B', 'C', 'D', 'E')) NOT NULL DEFAULT '',
+    pricing_admin_fee DECIMAL(15,2) NOT NULL,
+    pricing_interest_rate DECIMAL(5,2) NOT NULL,
+    pricing_priority INT NOT NULL DEFAULT 0,
+    pricing_active BOOLEAN NOT NULL DEFAULT TRUE,
+    pricing_created_by INT NOT NULL REFERENCES users(user_id),
+    pricing_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    pricing_edited_by INT REFERENCES users(user_id),
+    pricing_edited_at TIMESTAMP
+);
+
+CREATE TABLE negative_list_entries (
+    negative_list_id SERIAL PRIMARY KEY,
+    negative_list_type VARCHAR(10) CHECK (negative_list_type IN ('nik', 'name', 'phone')) NOT NULL,
+    negative_list_value VARCHAR(255) NOT NULL,
+    negative_list_reason VARCHAR(255) NOT NULL,
+    negative_list_source VARCHAR(100) NOT NULL,
+    negative_list_severity VARCHAR(10) CHECK (negative_list_severity IN ('flag', 'block')) NOT NULL,
+    negative_list_expires_at TIMESTAMP,
+    negative_list_active BOOLEAN NOT NULL DEFAULT TRUE,
+    negative_list_created_by INT NOT NULL REFERENCES users(user_id),
+    negative_list_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    negative_list_edited_by INT REFERENCES users(user_id),
+    negative_list_edited_at TIMESTAMP
 );
 
 CREATE TABLE transactions (
@@ -39,6 +195,9 @@ CREATE TABLE transactions (
     transaction_contract_number VARCHAR(50) UNIQUE NOT NULL,
     transaction_nik VARCHAR(16) NOT NULL REFERENCES customers(customer_nik) ON DELETE CASCADE,
     transaction_limit INT NOT NULL REFERENCES limits(limit_id) ON DELETE CASCADE,
+    transaction_product_id INT REFERENCES products(product_id),
+    transaction_pricing_rule_id INT REFERENCES pricing_rules(pricing_rule_id),
+    transaction_pricing_override BOOLEAN NOT NULL DEFAULT FALSE,
     transaction_otr DECIMAL(15,2) NOT NULL,
     transaction_admin_fee DECIMAL(15,2) NOT NULL,
     transaction_installment INT NOT NULL,
@@ -48,5 +207,89 @@ CREATE TABLE transactions (
     transaction_created_by INT NOT NULL REFERENCES users(user_id),
     transaction_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
     transaction_edited_by INT REFERENCES users(user_id),
-    transaction_edited_at TIMESTAMP
+    transaction_edited_at TIMESTAMP,
+    transaction_days_past_due INT NOT NULL DEFAULT 0,
+    transaction_collectibility VARCHAR(10) CHECK (transaction_collectibility IN ('current', '1-30', '31-60', '61-90', '90+')) NOT NULL DEFAULT 'current',
+    transaction_status VARCHAR(20) CHECK (transaction_status IN ('draft', 'active', 'paid_off', 'cancelled', 'restructured', 'written_off')) NOT NULL DEFAULT 'active',
+    transaction_scheme VARCHAR(20) CHECK (transaction_scheme IN ('flat', 'annuity', 'declining_balance')) NOT NULL DEFAULT 'flat',
+    transaction_parent_id INT REFERENCES transactions(transaction_id),
+    transaction_version INT NOT NULL DEFAULT 1,
+    transaction_original_number VARCHAR(50) NOT NULL
+);
+
+CREATE TABLE transaction_status_histories (
+    history_id SERIAL PRIMARY KEY,
+    history_transaction_id INT NOT NULL REFERENCES transactions(transaction_id),
+    history_from_status VARCHAR(20),
+    history_to_status VARCHAR(20) NOT NULL,
+    history_reason VARCHAR(255),
+    history_changed_by INT NOT NULL REFERENCES users(user_id),
+    history_changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE transaction_restructures (
+    restructure_id SERIAL PRIMARY KEY,
+    restructure_from_transaction_id INT NOT NULL REFERENCES transactions(transaction_id),
+    restructure_to_transaction_id INT NOT NULL REFERENCES transactions(transaction_id),
+    restructure_type VARCHAR(20) CHECK (restructure_type IN ('extend_tenor', 'capitalise_arrears', 'payment_holiday')) NOT NULL,
+    restructure_old_tenor INT NOT NULL,
+    restructure_new_tenor INT NOT NULL,
+    restructure_principal DECIMAL(15,2) NOT NULL,
+    restructure_capitalised DECIMAL(15,2) NOT NULL DEFAULT 0,
+    restructure_holiday_months INT NOT NULL DEFAULT 0,
+    restructure_reason VARCHAR(255),
+    restructure_created_by INT NOT NULL REFERENCES users(user_id),
+    restructure_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE limit_movements (
+    movement_id SERIAL PRIMARY KEY,
+    movement_limit_id INT NOT NULL,
+    movement_transaction_id INT REFERENCES transactions(transaction_id),
+    movement_type VARCHAR(20) CHECK (movement_type IN ('consume', 'release', 'adjust', 'expire')) NOT NULL,
+    movement_limit_delta DECIMAL(15,2) NOT NULL DEFAULT 0,
+    movement_used_delta DECIMAL(15,2) NOT NULL DEFAULT 0,
+    movement_remaining_delta DECIMAL(15,2) NOT NULL DEFAULT 0,
+    movement_limit_balance DECIMAL(15,2) NOT NULL,
+    movement_used_balance DECIMAL(15,2) NOT NULL,
+    movement_remaining_balance DECIMAL(15,2) NOT NULL,
+    movement_reason VARCHAR(255),
+    movement_created_by INT NOT NULL REFERENCES users(user_id),
+    movement_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE installments (
+    installment_id SERIAL PRIMARY KEY,
+    installment_transaction_id INT NOT NULL REFERENCES transactions(transaction_id) ON DELETE CASCADE,
+    installment_number INT NOT NULL,
+    installment_due_date TIMESTAMP NOT NULL,
+    installment_principal DECIMAL(15,2) NOT NULL,
+    installment_interest DECIMAL(15,2) NOT NULL,
+    installment_fee DECIMAL(15,2) NOT NULL,
+    installment_amount DECIMAL(15,2) NOT NULL,
+    installment_penalty DECIMAL(15,2) NOT NULL DEFAULT 0,
+    installment_paid_principal DECIMAL(15,2) NOT NULL DEFAULT 0,
+    installment_paid_interest DECIMAL(15,2) NOT NULL DEFAULT 0,
+    installment_paid_fee DECIMAL(15,2) NOT NULL DEFAULT 0,
+    installment_paid_penalty DECIMAL(15,2) NOT NULL DEFAULT 0,
+    installment_status VARCHAR(20) CHECK (installment_status IN ('unpaid', 'partial', 'paid', 'settled', 'restructured', 'void')) NOT NULL,
+    installment_paid_at TIMESTAMP,
+    installment_penalty_until TIMESTAMP,
+    installment_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE payments (
+    payment_id SERIAL PRIMARY KEY,
+    payment_transaction_id INT NOT NULL REFERENCES transactions(transaction_id),
+    payment_amount DECIMAL(15,2) NOT NULL,
+    payment_channel VARCHAR(20) CHECK (payment_channel IN ('bank_transfer', 'virtual_account', 'cash', 'auto_debit')) NOT NULL,
+    payment_paid_at TIMESTAMP NOT NULL,
+    payment_penalty_amount DECIMAL(15,2) NOT NULL,
+    payment_interest_amount DECIMAL(15,2) NOT NULL,
+    payment_fee_amount DECIMAL(15,2) NOT NULL,
+    payment_principal_amount DECIMAL(15,2) NOT NULL,
+    payment_termination_fee DECIMAL(15,2) NOT NULL DEFAULT 0,
+    payment_type VARCHAR(20) CHECK (payment_type IN ('installment', 'settlement')) NOT NULL,
+    payment_created_by INT NOT NULL REFERENCES users(user_id),
+    payment_created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
\ No newline at end of file
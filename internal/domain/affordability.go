@@ -0,0 +1,20 @@
+package domain
+
+import "kreditplus/internal/money"
+
+const (
+	AffordabilityReasonRatioExceeded = "installment_ratio_exceeded"
+	AffordabilityReasonNoSalary      = "no_declared_salary"
+)
+
+type AffordabilityRejection struct {
+	Reason                   string      `json:"reason"`
+	CustomerNIK              string      `json:"customer_nik"`
+	CustomerSalary           money.Money `json:"customer_salary"`
+	ExistingInstallments     money.Money `json:"existing_installments"`
+	NewInstallment           money.Money `json:"new_installment"`
+	TotalInstallments        money.Money `json:"total_installments"`
+	MaxAffordableInstallment money.Money `json:"max_affordable_installment"`
+	InstallmentRatio         float64     `json:"installment_ratio"`
+	MaxInstallmentRatio      float64     `json:"max_installment_ratio"`
+}
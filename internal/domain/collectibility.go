@@ -0,0 +1,33 @@
+package domain
+
+import "kreditplus/internal/money"
+
+const (
+	CollectibilityCurrent = "current"
+	Collectibility1To30   = "1-30"
+	Collectibility31To60  = "31-60"
+	Collectibility61To90  = "61-90"
+	CollectibilityOver90  = "90+"
+)
+
+func CollectibilityBucket(daysPastDue int) string {
+	switch {
+	case daysPastDue <= 0:
+		return CollectibilityCurrent
+	case daysPastDue <= 30:
+		return Collectibility1To30
+	case daysPastDue <= 60:
+		return Collectibility31To60
+	case daysPastDue <= 90:
+		return Collectibility61To90
+	default:
+		return CollectibilityOver90
+	}
+}
+
+type OverdueJobResult struct {
+	ProcessedTransactions  int         `json:"processed_transactions"`
+	DelinquentTransactions int         `json:"delinquent_transactions"`
+	FailedTransactions     int         `json:"failed_transactions"`
+	PenaltyAccrued         money.Money `json:"penalty_accrued"`
+}
@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+const (
+	RiskGradeA = "A"
+	RiskGradeB = "B"
+	RiskGradeC = "C"
+	RiskGradeD = "D"
+	RiskGradeE = "E"
+)
+
+type RepaymentHistory struct {
+	TotalContracts      int64 `json:"total_contracts"`
+	PaidOffContracts    int64 `json:"paid_off_contracts"`
+	DelinquentContracts int64 `json:"delinquent_contracts"`
+	WrittenOffContracts int64 `json:"written_off_contracts"`
+	LateInstallments    int64 `json:"late_installments"`
+	MaxDaysPastDue      int   `json:"max_days_past_due"`
+}
+
+type CreditScoreFactor struct {
+	Factor    string `json:"factor"`
+	Points    int    `json:"points"`
+	MaxPoints int    `json:"max_points"`
+}
+
+type TenorLimitRecommendation struct {
+	Tenor             int         `json:"tenor"`
+	RecommendedAmount money.Money `json:"recommended_amount"`
+	Reason            string      `json:"reason,omitempty"`
+}
+
+type LimitRecommendation struct {
+	CustomerID       uint                       `json:"customer_id"`
+	CustomerNIK      string                     `json:"customer_nik"`
+	CustomerAge      int                        `json:"customer_age"`
+	CustomerSalary   money.Money                `json:"customer_salary"`
+	ExistingExposure money.Money                `json:"existing_exposure"`
+	RepaymentHistory RepaymentHistory           `json:"repayment_history"`
+	Score            int                        `json:"score"`
+	RiskGrade        string                     `json:"risk_grade"`
+	CurrentRiskGrade string                     `json:"current_risk_grade"`
+	Factors          []CreditScoreFactor        `json:"factors"`
+	Recommendations  []TenorLimitRecommendation `json:"recommendations"`
+	RecommendationAt time.Time                  `json:"recommendation_at"`
+}
+
+func (r *LimitRecommendation) AmountForTenor(tenor int) money.Money {
+	for _, recommendation := range r.Recommendations {
+		if recommendation.Tenor == tenor {
+			return recommendation.RecommendedAmount
+		}
+	}
+	return money.Money{}
+}
@@ -1,37 +1,85 @@
 package domain
 
 import (
+	"kreditplus/internal/money"
 	"time"
 )
 
+const (
+	CustomerKYCPending           = "pending"
+	CustomerKYCVerified          = "verified"
+	CustomerKYCRejected          = "rejected"
+	CustomerKYCNeedsResubmission = "needs_resubmission"
+)
+
 type Customer struct {
-	CustomerID          uint       `gorm:"primaryKey" json:"customer_id"`
-	CustomerNIK         string     `gorm:"unique;not null" json:"customer_nik"`
-	CustomerFullName    string     `gorm:"not null" json:"customer_full_name"`
-	CustomerLegalName   string     `gorm:"not null" json:"customer_legal_name"`
-	CustomerBirthPlace  string     `gorm:"not null" json:"customer_birth_place"`
-	CustomerBirthDate   time.Time  `gorm:"not null" json:"customer_birth_date"`
-	CustomerSalary      float64    `gorm:"not null" json:"customer_salary"`
-	CustomerKTPPhoto    string     `gorm:"not null" json:"customer_ktp_photo"`
-	CustomerSelfiePhoto string     `gorm:"not null" json:"customer_selfie_photo"`
-	CustomerCreatedBy   uint       `gorm:"not null" json:"customer_created_by"`
-	CreatedByUser       User       `gorm:"foreignKey:CustomerCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	CustomerCreatedAt   time.Time  `gorm:"autoCreateTime" json:"customer_created_at"`
-	CustomerEditedBy    *uint      `json:"customer_edited_by"`
-	EditedByUser        *User      `gorm:"foreignKey:CustomerEditedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	CustomerEditedAt    *time.Time `json:"customer_edited_at"`
+	CustomerID             uint        `gorm:"primaryKey" json:"customer_id"`
+	CustomerNIK            string      `gorm:"unique;not null" json:"customer_nik"`
+	CustomerFullName       string      `gorm:"not null" json:"customer_full_name"`
+	CustomerLegalName      string      `gorm:"not null" json:"customer_legal_name"`
+	CustomerBirthPlace     string      `gorm:"not null" json:"customer_birth_place"`
+	CustomerBirthDate      time.Time   `gorm:"not null" json:"customer_birth_date"`
+	CustomerSalary         money.Money `gorm:"not null" json:"customer_salary"`
+	CustomerKTPPhoto       string      `gorm:"not null" json:"customer_ktp_photo"`
+	CustomerSelfiePhoto    string      `gorm:"not null" json:"customer_selfie_photo"`
+	CustomerPhone          string      `gorm:"not null;default:'';index" json:"customer_phone"`
+	CustomerEmail          string      `gorm:"not null;default:'';index" json:"customer_email"`
+	CustomerKTPPhash       string      `gorm:"not null;default:''" json:"-"`
+	CustomerSelfiePhash    string      `gorm:"not null;default:''" json:"-"`
+	CustomerRiskGrade      string      `gorm:"not null;default:''" json:"customer_risk_grade"`
+	CustomerKYCStatus      string      `gorm:"not null;default:'pending';index" json:"customer_kyc_status"`
+	CustomerKYCReason      string      `gorm:"not null;default:''" json:"customer_kyc_reason"`
+	CustomerKYCSubmittedAt *time.Time  `json:"customer_kyc_submitted_at"`
+	CustomerKYCReviewedBy  *uint       `json:"customer_kyc_reviewed_by"`
+	KYCReviewedByUser      *User       `gorm:"foreignKey:CustomerKYCReviewedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	CustomerKYCReviewedAt  *time.Time  `json:"customer_kyc_reviewed_at"`
+	CustomerCreatedBy      uint        `gorm:"not null" json:"customer_created_by"`
+	CreatedByUser          User        `gorm:"foreignKey:CustomerCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	CustomerCreatedAt      time.Time   `gorm:"autoCreateTime" json:"customer_created_at"`
+	CustomerEditedBy       *uint       `json:"customer_edited_by"`
+	EditedByUser           *User       `gorm:"foreignKey:CustomerEditedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	CustomerEditedAt       *time.Time  `json:"customer_edited_at"`
+
+	CustomerAddresses         []CustomerAddress          `gorm:"foreignKey:AddressCustomerID;references:CustomerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"customer_addresses,omitempty"`
+	CustomerPhones            []CustomerPhoneNumber      `gorm:"foreignKey:PhoneCustomerID;references:CustomerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"customer_phones,omitempty"`
+	CustomerEmails            []CustomerEmailAddress     `gorm:"foreignKey:EmailCustomerID;references:CustomerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"customer_emails,omitempty"`
+	CustomerEmployment        *CustomerEmployment        `gorm:"foreignKey:EmploymentCustomerID;references:CustomerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"customer_employment,omitempty"`
+	CustomerEmergencyContacts []CustomerEmergencyContact `gorm:"foreignKey:ContactCustomerID;references:CustomerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"customer_emergency_contacts,omitempty"`
 }
 
 type CustomerInput struct {
-	CustomerNIK        string  `form:"customer_nik" validate:"required,len=16,numeric"`
-	CustomerFullName   string  `form:"customer_full_name" validate:"required"`
-	CustomerLegalName  string  `form:"customer_legal_name" validate:"required"`
-	CustomerBirthPlace string  `form:"customer_birth_place" validate:"required"`
-	CustomerBirthDate  string  `form:"customer_birth_date" validate:"required,datetime=2006-01-02"`
-	CustomerSalary     float64 `form:"customer_salary" validate:"required,gte=1000000,lte=100000000"`
+	CustomerNIK        string      `form:"customer_nik" validate:"required,len=16,numeric"`
+	CustomerFullName   string      `form:"customer_full_name" validate:"required"`
+	CustomerLegalName  string      `form:"customer_legal_name" validate:"required"`
+	CustomerBirthPlace string      `form:"customer_birth_place" validate:"required"`
+	CustomerBirthDate  string      `form:"customer_birth_date" validate:"required,datetime=2006-01-02"`
+	CustomerSalary     money.Money `form:"customer_salary" validate:"required,gte=1000000,lte=100000000"`
+	CustomerRiskGrade  string      `form:"customer_risk_grade" validate:"omitempty,oneof=A B C D E"`
+	CustomerPhone      string      `form:"customer_phone" validate:"omitempty,min=9,max=20"`
+	CustomerEmail      string      `form:"customer_email" validate:"omitempty,email,max=255"`
+}
+
+type CustomerKYCReviewInput struct {
+	CustomerKYCStatus string `json:"customer_kyc_status" validate:"required,oneof=verified rejected needs_resubmission"`
+	CustomerKYCReason string `json:"customer_kyc_reason" validate:"max=255"`
 }
 
 type CustomerResponse struct {
-	CustomerNIK     string `json:"customer_nik"`
+	CustomerNIK      string `json:"customer_nik"`
 	CustomerFullName string `json:"customer_full_name"`
-}
\ No newline at end of file
+}
+
+func (c *Customer) IsKYCVerified() bool {
+	return c.CustomerKYCStatus == CustomerKYCVerified
+}
+
+func (c *Customer) ResubmitKYC(at time.Time) {
+	if c.CustomerKYCStatus == CustomerKYCRejected {
+		return
+	}
+	c.CustomerKYCStatus = CustomerKYCPending
+	c.CustomerKYCReason = ""
+	c.CustomerKYCSubmittedAt = &at
+	c.CustomerKYCReviewedBy = nil
+	c.CustomerKYCReviewedAt = nil
+}
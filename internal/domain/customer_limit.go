@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+type CustomerLimit struct {
+	CustomerLimitID        uint        `gorm:"primaryKey" json:"customer_limit_id"`
+	CustomerLimitNIK       string      `gorm:"unique;not null" json:"customer_limit_nik"`
+	NIKCustomer            Customer    `gorm:"foreignKey:CustomerLimitNIK;references:CustomerNIK;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;"`
+	CustomerLimitAmount    money.Money `gorm:"not null" json:"customer_limit_amount"`
+	CustomerLimitCreatedBy uint        `gorm:"not null" json:"customer_limit_created_by"`
+	CreatedByUser          User        `gorm:"foreignKey:CustomerLimitCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	CustomerLimitCreatedAt time.Time   `gorm:"autoCreateTime" json:"customer_limit_created_at"`
+	CustomerLimitEditedBy  *uint       `json:"customer_limit_edited_by"`
+	EditedByUser           *User       `gorm:"foreignKey:CustomerLimitEditedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	CustomerLimitEditedAt  *time.Time  `json:"customer_limit_edited_at"`
+}
+
+type CustomerLimitInput struct {
+	CustomerLimitAmount money.Money `json:"customer_limit_amount" validate:"required"`
+}
+
+type CustomerSubLimit struct {
+	Limit
+	LimitAvailableAmount money.Money `json:"limit_available_amount"`
+}
+
+type CustomerLimitSummary struct {
+	CustomerNIK          string             `json:"customer_nik"`
+	CustomerLimit        *CustomerLimit     `json:"customer_limit"`
+	TotalUsedAmount      money.Money        `json:"total_used_amount"`
+	TotalRemainingAmount *money.Money       `json:"total_remaining_amount"`
+	SubLimits            []CustomerSubLimit `json:"sub_limits"`
+}
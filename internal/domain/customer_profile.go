@@ -0,0 +1,148 @@
+package domain
+
+import "time"
+
+const (
+	CustomerAddressTypeKTP      = "ktp"
+	CustomerAddressTypeDomicile = "domicile"
+)
+
+const (
+	EmploymentStatusEmployed     = "employed"
+	EmploymentStatusSelfEmployed = "self_employed"
+	EmploymentStatusUnemployed   = "unemployed"
+	EmploymentStatusRetired      = "retired"
+	EmploymentStatusStudent      = "student"
+)
+
+type CustomerAddress struct {
+	AddressID         uint       `gorm:"primaryKey" json:"address_id"`
+	AddressCustomerID uint       `gorm:"not null;uniqueIndex:idx_customer_address_type" json:"address_customer_id"`
+	AddressType       string     `gorm:"not null;uniqueIndex:idx_customer_address_type" json:"address_type"`
+	AddressLine       string     `gorm:"not null" json:"address_line"`
+	AddressRT         string     `gorm:"not null;default:''" json:"address_rt"`
+	AddressRW         string     `gorm:"not null;default:''" json:"address_rw"`
+	AddressVillage    string     `gorm:"not null;default:''" json:"address_village"`
+	AddressDistrict   string     `gorm:"not null;default:''" json:"address_district"`
+	AddressCity       string     `gorm:"not null" json:"address_city"`
+	AddressProvince   string     `gorm:"not null" json:"address_province"`
+	AddressPostalCode string     `gorm:"not null;default:''" json:"address_postal_code"`
+	AddressCreatedBy  uint       `gorm:"not null" json:"address_created_by"`
+	AddressCreatedAt  time.Time  `gorm:"autoCreateTime" json:"address_created_at"`
+	AddressEditedBy   *uint      `json:"address_edited_by"`
+	AddressEditedAt   *time.Time `json:"address_edited_at"`
+}
+
+type CustomerAddressInput struct {
+	AddressType       string `json:"address_type" validate:"required,oneof=ktp domicile"`
+	AddressLine       string `json:"address_line" validate:"required,max=255"`
+	AddressRT         string `json:"address_rt" validate:"omitempty,numeric,max=3"`
+	AddressRW         string `json:"address_rw" validate:"omitempty,numeric,max=3"`
+	AddressVillage    string `json:"address_village" validate:"max=100"`
+	AddressDistrict   string `json:"address_district" validate:"max=100"`
+	AddressCity       string `json:"address_city" validate:"required,max=100"`
+	AddressProvince   string `json:"address_province" validate:"required,max=100"`
+	AddressPostalCode string `json:"address_postal_code" validate:"omitempty,numeric,len=5"`
+}
+
+type CustomerPhoneNumber struct {
+	PhoneID         uint       `gorm:"primaryKey" json:"phone_id"`
+	PhoneCustomerID uint       `gorm:"not null;uniqueIndex:idx_customer_phone_number" json:"phone_customer_id"`
+	PhoneNumber     string     `gorm:"not null;uniqueIndex:idx_customer_phone_number" json:"phone_number"`
+	PhoneLabel      string     `gorm:"not null;default:''" json:"phone_label"`
+	PhonePrimary    bool       `gorm:"not null;default:false" json:"phone_primary"`
+	PhoneVerified   bool       `gorm:"not null;default:false" json:"phone_verified"`
+	PhoneVerifiedBy *uint      `json:"phone_verified_by"`
+	PhoneVerifiedAt *time.Time `json:"phone_verified_at"`
+	PhoneCreatedBy  uint       `gorm:"not null" json:"phone_created_by"`
+	PhoneCreatedAt  time.Time  `gorm:"autoCreateTime" json:"phone_created_at"`
+	PhoneEditedBy   *uint      `json:"phone_edited_by"`
+	PhoneEditedAt   *time.Time `json:"phone_edited_at"`
+}
+
+type CustomerPhoneInput struct {
+	PhoneNumber  string `json:"phone_number" validate:"required,min=9,max=20"`
+	PhoneLabel   string `json:"phone_label" validate:"omitempty,oneof=mobile home office"`
+	PhonePrimary bool   `json:"phone_primary"`
+}
+
+func (p *CustomerPhoneNumber) ResetVerification() {
+	p.PhoneVerified = false
+	p.PhoneVerifiedBy = nil
+	p.PhoneVerifiedAt = nil
+}
+
+type CustomerEmailAddress struct {
+	EmailID         uint       `gorm:"primaryKey" json:"email_id"`
+	EmailCustomerID uint       `gorm:"not null;uniqueIndex:idx_customer_email_address" json:"email_customer_id"`
+	EmailAddress    string     `gorm:"not null;uniqueIndex:idx_customer_email_address" json:"email_address"`
+	EmailPrimary    bool       `gorm:"not null;default:false" json:"email_primary"`
+	EmailVerified   bool       `gorm:"not null;default:false" json:"email_verified"`
+	EmailVerifiedBy *uint      `json:"email_verified_by"`
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	EmailCreatedBy  uint       `gorm:"not null" json:"email_created_by"`
+	EmailCreatedAt  time.Time  `gorm:"autoCreateTime" json:"email_created_at"`
+	EmailEditedBy   *uint      `json:"email_edited_by"`
+	EmailEditedAt   *time.Time `json:"email_edited_at"`
+}
+
+type CustomerEmailInput struct {
+	EmailAddress string `json:"email_address" validate:"required,email,max=255"`
+	EmailPrimary bool   `json:"email_primary"`
+}
+
+func (e *CustomerEmailAddress) ResetVerification() {
+	e.EmailVerified = false
+	e.EmailVerifiedBy = nil
+	e.EmailVerifiedAt = nil
+}
+
+type CustomerEmployment struct {
+	EmploymentID              uint       `gorm:"primaryKey" json:"employment_id"`
+	EmploymentCustomerID      uint       `gorm:"not null;uniqueIndex" json:"employment_customer_id"`
+	EmploymentStatus          string     `gorm:"not null" json:"employment_status"`
+	EmploymentEmployerName    string     `gorm:"not null;default:''" json:"employment_employer_name"`
+	EmploymentJobTitle        string     `gorm:"not null;default:''" json:"employment_job_title"`
+	EmploymentIndustry        string     `gorm:"not null;default:''" json:"employment_industry"`
+	EmploymentEmployerPhone   string     `gorm:"not null;default:''" json:"employment_employer_phone"`
+	EmploymentEmployerAddress string     `gorm:"not null;default:''" json:"employment_employer_address"`
+	EmploymentStartDate       *time.Time `json:"employment_start_date"`
+	EmploymentCreatedBy       uint       `gorm:"not null" json:"employment_created_by"`
+	EmploymentCreatedAt       time.Time  `gorm:"autoCreateTime" json:"employment_created_at"`
+	EmploymentEditedBy        *uint      `json:"employment_edited_by"`
+	EmploymentEditedAt        *time.Time `json:"employment_edited_at"`
+}
+
+type CustomerEmploymentInput struct {
+	EmploymentStatus          string `json:"employment_status" validate:"required,oneof=employed self_employed unemployed retired student"`
+	EmploymentEmployerName    string `json:"employment_employer_name" validate:"max=150"`
+	EmploymentJobTitle        string `json:"employment_job_title" validate:"max=100"`
+	EmploymentIndustry        string `json:"employment_industry" validate:"max=100"`
+	EmploymentEmployerPhone   string `json:"employment_employer_phone" validate:"omitempty,min=6,max=20"`
+	EmploymentEmployerAddress string `json:"employment_employer_address" validate:"max=255"`
+	EmploymentStartDate       string `json:"employment_start_date" validate:"omitempty,datetime=2006-01-02"`
+}
+
+func (e *CustomerEmployment) HasEmployer() bool {
+	return e.EmploymentStatus == EmploymentStatusEmployed || e.EmploymentStatus == EmploymentStatusSelfEmployed
+}
+
+type CustomerEmergencyContact struct {
+	ContactID           uint       `gorm:"primaryKey" json:"contact_id"`
+	ContactCustomerID   uint       `gorm:"not null;index" json:"contact_customer_id"`
+	ContactName         string     `gorm:"not null" json:"contact_name"`
+	ContactRelationship string     `gorm:"not null" json:"contact_relationship"`
+	ContactPhone        string     `gorm:"not null" json:"contact_phone"`
+	ContactAddress      string     `gorm:"not null;default:''" json:"contact_address"`
+	ContactCreatedBy    uint       `gorm:"not null" json:"contact_created_by"`
+	ContactCreatedAt    time.Time  `gorm:"autoCreateTime" json:"contact_created_at"`
+	ContactEditedBy     *uint      `json:"contact_edited_by"`
+	ContactEditedAt     *time.Time `json:"contact_edited_at"`
+}
+
+type CustomerEmergencyContactInput struct {
+	ContactName         string `json:"contact_name" validate:"required,max=150"`
+	ContactRelationship string `json:"contact_relationship" validate:"required,oneof=spouse parent sibling child relative friend colleague"`
+	ContactPhone        string `json:"contact_phone" validate:"required,min=9,max=20"`
+	ContactAddress      string `json:"contact_address" validate:"max=255"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+const (
+	DuplicateSignalNameBirthDate = "name_birth_date"
+	DuplicateSignalKTPPhoto      = "ktp_photo"
+	DuplicateSignalSelfiePhoto   = "selfie_photo"
+	DuplicateSignalPhone         = "phone"
+	DuplicateSignalEmail         = "email"
+)
+
+type DuplicateSignal struct {
+	Signal string `json:"signal"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
+}
+
+type SuspectedDuplicate struct {
+	CustomerID        uint              `json:"customer_id"`
+	CustomerNIK       string            `json:"customer_nik"`
+	CustomerFullName  string            `json:"customer_full_name"`
+	CustomerKYCStatus string            `json:"customer_kyc_status"`
+	Score             int               `json:"score"`
+	Blocking          bool              `json:"blocking"`
+	Signals           []DuplicateSignal `json:"signals"`
+}
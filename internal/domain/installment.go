@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+const (
+	InstallmentStatusUnpaid       = "unpaid"
+	InstallmentStatusPartial      = "partial"
+	InstallmentStatusPaid         = "paid"
+	InstallmentStatusSettled      = "settled"
+	InstallmentStatusRestructured = "restructured"
+	InstallmentStatusVoid         = "void"
+)
+
+type Installment struct {
+	InstallmentID            uint        `gorm:"primaryKey" json:"installment_id"`
+	InstallmentTransactionID uint        `gorm:"not null;index" json:"installment_transaction_id"`
+	InstallmentNumber        int         `gorm:"not null" json:"installment_number"`
+	InstallmentDueDate       time.Time   `gorm:"not null" json:"installment_due_date"`
+	InstallmentPrincipal     money.Money `gorm:"not null" json:"installment_principal"`
+	InstallmentInterest      money.Money `gorm:"not null" json:"installment_interest"`
+	InstallmentFee           money.Money `gorm:"not null" json:"installment_fee"`
+	InstallmentAmount        money.Money `gorm:"not null" json:"installment_amount"`
+	InstallmentPenalty       money.Money `gorm:"not null;default:0" json:"installment_penalty"`
+	InstallmentPaidPrincipal money.Money `gorm:"not null;default:0" json:"installment_paid_principal"`
+	InstallmentPaidInterest  money.Money `gorm:"not null;default:0" json:"installment_paid_interest"`
+	InstallmentPaidFee       money.Money `gorm:"not null;default:0" json:"installment_paid_fee"`
+	InstallmentPaidPenalty   money.Money `gorm:"not null;default:0" json:"installment_paid_penalty"`
+	InstallmentStatus        string      `gorm:"not null" json:"installment_status"`
+	InstallmentPaidAt        *time.Time  `json:"installment_paid_at"`
+	InstallmentPenaltyUntil  *time.Time  `json:"installment_penalty_until"`
+	InstallmentCreatedAt     time.Time   `gorm:"autoCreateTime" json:"installment_created_at"`
+}
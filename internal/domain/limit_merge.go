@@ -0,0 +1,25 @@
+package domain
+
+import "kreditplus/internal/money"
+
+type LimitKey struct {
+	LimitNIK   string `json:"limit_nik"`
+	LimitTenor int    `json:"limit_tenor"`
+}
+
+type LimitMerge struct {
+	LimitNIK             string      `json:"limit_nik"`
+	LimitTenor           int         `json:"limit_tenor"`
+	SurvivorLimitID      uint        `json:"survivor_limit_id"`
+	MergedLimitIDs       []uint      `json:"merged_limit_ids"`
+	LimitAmount          money.Money `json:"limit_amount"`
+	LimitUsedAmount      money.Money `json:"limit_used_amount"`
+	LimitRemainingAmount money.Money `json:"limit_remaining_amount"`
+}
+
+type LimitMergeResult struct {
+	DuplicateKeys int          `json:"duplicate_keys"`
+	MergedLimits  int          `json:"merged_limits"`
+	FailedKeys    int          `json:"failed_keys"`
+	Merges        []LimitMerge `json:"merges"`
+}
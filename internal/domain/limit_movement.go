@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+const (
+	LimitMovementConsume = "consume"
+	LimitMovementRelease = "release"
+	LimitMovementAdjust  = "adjust"
+	LimitMovementExpire  = "expire"
+)
+
+type LimitMovement struct {
+	MovementID               uint        `gorm:"primaryKey" json:"movement_id"`
+	MovementLimitID          uint        `gorm:"not null;index" json:"movement_limit_id"`
+	MovementTransactionID    *uint       `gorm:"index" json:"movement_transaction_id"`
+	MovementType             string      `gorm:"not null" json:"movement_type"`
+	MovementLimitDelta       money.Money `gorm:"not null;default:0" json:"movement_limit_delta"`
+	MovementUsedDelta        money.Money `gorm:"not null;default:0" json:"movement_used_delta"`
+	MovementRemainingDelta   money.Money `gorm:"not null;default:0" json:"movement_remaining_delta"`
+	MovementLimitBalance     money.Money `gorm:"not null" json:"movement_limit_balance"`
+	MovementUsedBalance      money.Money `gorm:"not null" json:"movement_used_balance"`
+	MovementRemainingBalance money.Money `gorm:"not null" json:"movement_remaining_balance"`
+	MovementReason           string      `json:"movement_reason"`
+	MovementCreatedBy        uint        `gorm:"not null" json:"movement_created_by"`
+	CreatedByUser            User        `gorm:"foreignKey:MovementCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	MovementCreatedAt        time.Time   `gorm:"autoCreateTime" json:"movement_created_at"`
+}
+
+type LimitLedger struct {
+	LedgerLimitID         uint        `json:"ledger_limit_id"`
+	LedgerMovementCount   int64       `json:"ledger_movement_count"`
+	LedgerLimitAmount     money.Money `json:"ledger_limit_amount"`
+	LedgerUsedAmount      money.Money `json:"ledger_used_amount"`
+	LedgerRemainingAmount money.Money `json:"ledger_remaining_amount"`
+	LedgerConsistent      bool        `json:"ledger_consistent"`
+}
@@ -0,0 +1,24 @@
+package domain
+
+import "kreditplus/internal/money"
+
+type LimitReconciliation struct {
+	LimitID                 uint        `json:"limit_id"`
+	LimitNIK                string      `json:"limit_nik"`
+	LimitTenor              int         `json:"limit_tenor"`
+	LimitAmount             money.Money `json:"limit_amount"`
+	StoredUsedAmount        money.Money `json:"stored_used_amount"`
+	ExpectedUsedAmount      money.Money `json:"expected_used_amount"`
+	StoredRemainingAmount   money.Money `json:"stored_remaining_amount"`
+	ExpectedRemainingAmount money.Money `json:"expected_remaining_amount"`
+	OpenTransactions        int         `json:"open_transactions"`
+	Repaired                bool        `json:"repaired"`
+}
+
+type LimitReconciliationResult struct {
+	CheckedLimits    int                   `json:"checked_limits"`
+	MismatchedLimits int                   `json:"mismatched_limits"`
+	RepairedLimits   int                   `json:"repaired_limits"`
+	FailedLimits     int                   `json:"failed_limits"`
+	Mismatches       []LimitReconciliation `json:"mismatches"`
+}
@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+const (
+	NegativeListTypeNIK   = "nik"
+	NegativeListTypeName  = "name"
+	NegativeListTypePhone = "phone"
+)
+
+const (
+	NegativeListSeverityFlag  = "flag"
+	NegativeListSeverityBlock = "block"
+)
+
+const (
+	ScreeningDecisionClear = "clear"
+	ScreeningDecisionFlag  = "flag"
+	ScreeningDecisionBlock = "block"
+)
+
+type NegativeListEntry struct {
+	NegativeListID        uint       `gorm:"primaryKey" json:"negative_list_id"`
+	NegativeListType      string     `gorm:"not null;index:idx_negative_list_lookup" json:"negative_list_type"`
+	NegativeListValue     string     `gorm:"not null;index:idx_negative_list_lookup" json:"negative_list_value"`
+	NegativeListReason    string     `gorm:"not null" json:"negative_list_reason"`
+	NegativeListSource    string     `gorm:"not null" json:"negative_list_source"`
+	NegativeListSeverity  string     `gorm:"not null" json:"negative_list_severity"`
+	NegativeListExpiresAt *time.Time `json:"negative_list_expires_at"`
+	NegativeListActive    bool       `gorm:"not null;default:true" json:"negative_list_active"`
+	NegativeListCreatedBy uint       `gorm:"not null" json:"negative_list_created_by"`
+	CreatedByUser         User       `gorm:"foreignKey:NegativeListCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	NegativeListCreatedAt time.Time  `gorm:"autoCreateTime" json:"negative_list_created_at"`
+	NegativeListEditedBy  *uint      `json:"negative_list_edited_by"`
+	EditedByUser          *User      `gorm:"foreignKey:NegativeListEditedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	NegativeListEditedAt  *time.Time `json:"negative_list_edited_at"`
+}
+
+func (e *NegativeListEntry) IsEffective(at time.Time) bool {
+	return e.NegativeListActive && (e.NegativeListExpiresAt == nil || e.NegativeListExpiresAt.After(at))
+}
+
+type NegativeListInput struct {
+	NegativeListType      string     `json:"negative_list_type" validate:"required,oneof=nik name phone"`
+	NegativeListValue     string     `json:"negative_list_value" validate:"required,max=255"`
+	NegativeListReason    string     `json:"negative_list_reason" validate:"required,max=255"`
+	NegativeListSource    string     `json:"negative_list_source" validate:"required,max=100"`
+	NegativeListSeverity  string     `json:"negative_list_severity" validate:"required,oneof=flag block"`
+	NegativeListExpiresAt *time.Time `json:"negative_list_expires_at"`
+	NegativeListActive    *bool      `json:"negative_list_active"`
+}
+
+type ScreeningHit struct {
+	NegativeListID uint       `json:"negative_list_id"`
+	MatchedField   string     `json:"matched_field"`
+	MatchedValue   string     `json:"matched_value"`
+	Severity       string     `json:"severity"`
+	Reason         string     `json:"reason"`
+	Source         string     `json:"source"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+type ScreeningResult struct {
+	CustomerNIK string         `json:"customer_nik"`
+	Decision    string         `json:"decision"`
+	Hits        []ScreeningHit `json:"hits"`
+	ScreenedAt  time.Time      `json:"screened_at"`
+}
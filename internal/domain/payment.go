@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+const (
+	PaymentTypeInstallment = "installment"
+	PaymentTypeSettlement  = "settlement"
+)
+
+type Payment struct {
+	PaymentID              uint        `gorm:"primaryKey" json:"payment_id"`
+	PaymentTransactionID   uint        `gorm:"not null;index" json:"payment_transaction_id"`
+	PaymentAmount          money.Money `gorm:"not null" json:"payment_amount"`
+	PaymentChannel         string      `gorm:"not null" json:"payment_channel"`
+	PaymentPaidAt          time.Time   `gorm:"not null" json:"payment_paid_at"`
+	PaymentPenaltyAmount   money.Money `gorm:"not null" json:"payment_penalty_amount"`
+	PaymentInterestAmount  money.Money `gorm:"not null" json:"payment_interest_amount"`
+	PaymentFeeAmount       money.Money `gorm:"not null" json:"payment_fee_amount"`
+	PaymentPrincipalAmount money.Money `gorm:"not null" json:"payment_principal_amount"`
+	PaymentTerminationFee  money.Money `gorm:"not null;default:0" json:"payment_termination_fee"`
+	PaymentType            string      `gorm:"not null" json:"payment_type"`
+	PaymentCreatedBy       uint        `gorm:"not null" json:"payment_created_by"`
+	CreatedByUser          User        `gorm:"foreignKey:PaymentCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	PaymentCreatedAt       time.Time   `gorm:"autoCreateTime" json:"payment_created_at"`
+}
+
+type PaymentInput struct {
+	PaymentAmount  money.Money `json:"payment_amount" validate:"required,gt=0"`
+	PaymentChannel string      `json:"payment_channel" validate:"required,oneof=bank_transfer virtual_account cash auto_debit"`
+	PaymentPaidAt  string      `json:"payment_paid_at" validate:"omitempty,datetime=2006-01-02"`
+}
+
+type SettlementInput struct {
+	PaymentAmount  money.Money `json:"payment_amount" validate:"required,gt=0"`
+	PaymentChannel string      `json:"payment_channel" validate:"required,oneof=bank_transfer virtual_account cash auto_debit"`
+}
+
+type PayoffQuote struct {
+	PayoffTransactionID         uint        `json:"payoff_transaction_id"`
+	PayoffContractNumber        string      `json:"payoff_contract_number"`
+	PayoffAsOf                  time.Time   `json:"payoff_as_of"`
+	PayoffRemainingInstallments int         `json:"payoff_remaining_installments"`
+	PayoffOutstandingPrincipal  money.Money `json:"payoff_outstanding_principal"`
+	PayoffAccruedInterest       money.Money `json:"payoff_accrued_interest"`
+	PayoffOutstandingFee        money.Money `json:"payoff_outstanding_fee"`
+	PayoffOutstandingPenalty    money.Money `json:"payoff_outstanding_penalty"`
+	PayoffTerminationFee        money.Money `json:"payoff_termination_fee"`
+	PayoffInterestWaived        money.Money `json:"payoff_interest_waived"`
+	PayoffTotalAmount           money.Money `json:"payoff_total_amount"`
+}
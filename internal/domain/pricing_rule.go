@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+type PricingRule struct {
+	PricingRuleID       uint        `gorm:"primaryKey" json:"pricing_rule_id"`
+	PricingProductID    uint        `gorm:"not null;index" json:"pricing_product_id"`
+	IDProduct           Product     `gorm:"foreignKey:PricingProductID;references:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	PricingTenor        int         `gorm:"not null;default:0" json:"pricing_tenor"`
+	PricingMinOTR       money.Money `gorm:"not null" json:"pricing_min_otr"`
+	PricingMaxOTR       money.Money `gorm:"not null" json:"pricing_max_otr"`
+	PricingRiskGrade    string      `gorm:"not null;default:''" json:"pricing_risk_grade"`
+	PricingAdminFee     money.Money `gorm:"not null" json:"pricing_admin_fee"`
+	PricingInterestRate float64     `gorm:"not null" json:"pricing_interest_rate"`
+	PricingPriority     int         `gorm:"not null;default:0" json:"pricing_priority"`
+	PricingActive       bool        `gorm:"not null;default:true" json:"pricing_active"`
+	PricingCreatedBy    uint        `gorm:"not null" json:"pricing_created_by"`
+	CreatedByUser       User        `gorm:"foreignKey:PricingCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	PricingCreatedAt    time.Time   `gorm:"autoCreateTime" json:"pricing_created_at"`
+	PricingEditedBy     *uint       `json:"pricing_edited_by"`
+	EditedByUser        *User       `gorm:"foreignKey:PricingEditedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	PricingEditedAt     *time.Time  `json:"pricing_edited_at"`
+}
+
+func (r *PricingRule) Matches(tenor int, otr money.Money, riskGrade string) bool {
+	if !r.PricingActive {
+		return false
+	}
+	if r.PricingTenor != 0 && r.PricingTenor != tenor {
+		return false
+	}
+	if r.PricingRiskGrade != "" && r.PricingRiskGrade != riskGrade {
+		return false
+	}
+	if otr.LessThan(r.PricingMinOTR) {
+		return false
+	}
+	return r.PricingMaxOTR.IsZero() || !otr.GreaterThan(r.PricingMaxOTR)
+}
+
+func (r *PricingRule) Specificity() int {
+	specificity := 0
+	if r.PricingRiskGrade != "" {
+		specificity += 2
+	}
+	if r.PricingTenor != 0 {
+		specificity++
+	}
+	return specificity
+}
+
+type PricingRuleInput struct {
+	PricingProductID    uint        `json:"pricing_product_id" validate:"required"`
+	PricingTenor        int         `json:"pricing_tenor" validate:"gte=0,lte=60"`
+	PricingMinOTR       money.Money `json:"pricing_min_otr" validate:"gte=0"`
+	PricingMaxOTR       money.Money `json:"pricing_max_otr" validate:"gte=0"`
+	PricingRiskGrade    string      `json:"pricing_risk_grade" validate:"omitempty,oneof=A B C D E"`
+	PricingAdminFee     money.Money `json:"pricing_admin_fee" validate:"gte=0"`
+	PricingInterestRate float64     `json:"pricing_interest_rate" validate:"gte=0,lte=100"`
+	PricingPriority     int         `json:"pricing_priority"`
+	PricingActive       *bool       `json:"pricing_active"`
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+type Product struct {
+	ProductID        uint           `gorm:"primaryKey" json:"product_id"`
+	ProductCode      string         `gorm:"unique;not null" json:"product_code"`
+	ProductName      string         `gorm:"not null" json:"product_name"`
+	ProductMinOTR    money.Money    `gorm:"not null" json:"product_min_otr"`
+	ProductMaxOTR    money.Money    `gorm:"not null" json:"product_max_otr"`
+	ProductActive    bool           `gorm:"not null;default:true" json:"product_active"`
+	ProductTenors    []ProductTenor `gorm:"foreignKey:TenorProductID;references:ProductID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"product_tenors"`
+	ProductCreatedBy uint           `gorm:"not null" json:"product_created_by"`
+	CreatedByUser    User           `gorm:"foreignKey:ProductCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	ProductCreatedAt time.Time      `gorm:"autoCreateTime" json:"product_created_at"`
+	ProductEditedBy  *uint          `json:"product_edited_by"`
+	EditedByUser     *User          `gorm:"foreignKey:ProductEditedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	ProductEditedAt  *time.Time     `json:"product_edited_at"`
+}
+
+type ProductTenor struct {
+	TenorID           uint        `gorm:"primaryKey" json:"tenor_id"`
+	TenorProductID    uint        `gorm:"not null;uniqueIndex:idx_product_tenors_product_months" json:"tenor_product_id"`
+	TenorMonths       int         `gorm:"not null;uniqueIndex:idx_product_tenors_product_months" json:"tenor_months"`
+	TenorInterestRate float64     `gorm:"not null" json:"tenor_interest_rate"`
+	TenorAdminFee     money.Money `gorm:"not null" json:"tenor_admin_fee"`
+}
+
+func (p *Product) FindTenor(tenor float64) *ProductTenor {
+	for i := range p.ProductTenors {
+		if float64(p.ProductTenors[i].TenorMonths) == tenor {
+			return &p.ProductTenors[i]
+		}
+	}
+	return nil
+}
+
+type ProductInput struct {
+	ProductCode   string              `json:"product_code" validate:"required,max=30"`
+	ProductName   string              `json:"product_name" validate:"required,max=100"`
+	ProductMinOTR money.Money         `json:"product_min_otr" validate:"gte=0"`
+	ProductMaxOTR money.Money         `json:"product_max_otr" validate:"required"`
+	ProductActive *bool               `json:"product_active"`
+	ProductTenors []ProductTenorInput `json:"product_tenors" validate:"required,min=1,unique=TenorMonths,dive"`
+}
+
+type ProductTenorInput struct {
+	TenorMonths       int         `json:"tenor_months" validate:"required,min=1,max=60"`
+	TenorInterestRate float64     `json:"tenor_interest_rate" validate:"gte=0"`
+	TenorAdminFee     money.Money `json:"tenor_admin_fee" validate:"gte=0"`
+}
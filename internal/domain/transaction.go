@@ -1,49 +1,90 @@
 package domain
 
-import "time"
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+const (
+	TransactionStatusDraft        = "draft"
+	TransactionStatusActive       = "active"
+	TransactionStatusPaidOff      = "paid_off"
+	TransactionStatusCancelled    = "cancelled"
+	TransactionStatusRestructured = "restructured"
+	TransactionStatusWrittenOff   = "written_off"
+)
 
 type Transaction struct {
-	TransactionID             uint       `gorm:"primaryKey" json:"transaction_id"`
-	TransactionContractNumber string     `gorm:"unique;not null" json:"transaction_contract_number"`
-	TransactionNIK            string     `gorm:"not null" json:"transaction_nik"`
-	NIKCustomer               Customer   `gorm:"foreignKey:TransactionNIK;references:CustomerNIK;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	TransactionLimit          uint       `gorm:"not null" json:"transaction_limit"`
-	IDLimit                   Limit      `gorm:"foreignKey:TransactionLimit;references:LimitID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	TransactionOTR            float64    `gorm:"not null" json:"transaction_otr"`
-	TransactionAdminFee       float64    `gorm:"not null" json:"transaction_admin_fee"`
-	TransactionInstallment    float64    `gorm:"not null" json:"transaction_installment"`
-	TransactionInterest       float64    `gorm:"not null" json:"transaction_interest"`
-	TransactionAssetName      string     `gorm:"not null" json:"transaction_asset_name"`
-	TransactionDate           time.Time  `gorm:"not null" json:"transaction_date"`
-	TransactionCreatedBy      uint       `gorm:"not null" json:"transaction_created_by"`
-	CreatedByUser             User       `gorm:"foreignKey:TransactionCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	TransactionCreatedAt      time.Time  `gorm:"autoCreateTime" json:"transaction_created_at"`
-	TransactionEditedBy       *uint      `json:"transaction_edited_by"`
-	EditedByUser              *User      `gorm:"foreignKey:TransactionEditedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
-	TransactionEditedAt       *time.Time `json:"transaction_edited_at"`
+	TransactionID              uint         `gorm:"primaryKey" json:"transaction_id"`
+	TransactionContractNumber  string       `gorm:"unique;not null" json:"transaction_contract_number"`
+	TransactionNIK             string       `gorm:"not null" json:"transaction_nik"`
+	NIKCustomer                Customer     `gorm:"foreignKey:TransactionNIK;references:CustomerNIK;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	TransactionLimit           uint         `gorm:"not null" json:"transaction_limit"`
+	IDLimit                    Limit        `gorm:"foreignKey:TransactionLimit;references:LimitID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	TransactionProductID       *uint        `gorm:"index" json:"transaction_product_id"`
+	IDProduct                  *Product     `gorm:"foreignKey:TransactionProductID;references:ProductID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	TransactionPricingRuleID   *uint        `gorm:"index" json:"transaction_pricing_rule_id"`
+	IDPricingRule              *PricingRule `gorm:"foreignKey:TransactionPricingRuleID;references:PricingRuleID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	TransactionPricingOverride bool         `gorm:"not null;default:false" json:"transaction_pricing_override"`
+	TransactionOTR             money.Money  `gorm:"not null" json:"transaction_otr"`
+	TransactionAdminFee        money.Money  `gorm:"not null" json:"transaction_admin_fee"`
+	TransactionInstallment     float64      `gorm:"not null" json:"transaction_installment"`
+	TransactionInterest        float64      `gorm:"not null" json:"transaction_interest"`
+	TransactionAssetName       string       `gorm:"not null" json:"transaction_asset_name"`
+	TransactionDate            time.Time    `gorm:"not null" json:"transaction_date"`
+	TransactionCreatedBy       uint         `gorm:"not null" json:"transaction_created_by"`
+	CreatedByUser              User         `gorm:"foreignKey:TransactionCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	TransactionCreatedAt       time.Time    `gorm:"autoCreateTime" json:"transaction_created_at"`
+	TransactionEditedBy        *uint        `json:"transaction_edited_by"`
+	EditedByUser               *User        `gorm:"foreignKey:TransactionEditedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	TransactionEditedAt        *time.Time   `json:"transaction_edited_at"`
+	TransactionDaysPastDue     int          `gorm:"not null" json:"transaction_days_past_due"`
+	TransactionCollectibility  string       `gorm:"not null" json:"transaction_collectibility"`
+	TransactionStatus          string       `gorm:"not null;index" json:"transaction_status"`
+	TransactionScheme          string       `gorm:"not null" json:"transaction_scheme"`
+	TransactionParentID        *uint        `gorm:"index" json:"transaction_parent_id"`
+	TransactionVersion         int          `gorm:"not null" json:"transaction_version"`
+	TransactionOriginalNumber  string       `gorm:"not null;index" json:"transaction_original_number"`
 }
 
 type TransactionInput struct {
-	TransactionNIK         string  `json:"transaction_nik" validate:"required,len=16,numeric"`
-	TransactionOTR         float64 `json:"transaction_otr" validate:"required"`
-	TransactionAdminFee    float64 `json:"transaction_admin_fee" validate:"required"`
-	TransactionInstallment float64 `json:"transaction_installment" validate:"required"`
-	TransactionInterest    float64 `json:"transaction_interest" validate:"required"`
-	TransactionAssetName   string  `json:"transaction_asset_name" validate:"required"`
+	TransactionNIK         string      `json:"transaction_nik" validate:"required,len=16,numeric"`
+	TransactionProduct     string      `json:"transaction_product" validate:"required,max=30"`
+	TransactionOTR         money.Money `json:"transaction_otr" validate:"required"`
+	TransactionAdminFee    money.Money `json:"transaction_admin_fee" validate:"omitempty,gte=0"`
+	TransactionInstallment float64     `json:"transaction_installment" validate:"required"`
+	TransactionInterest    float64     `json:"transaction_interest" validate:"omitempty,gte=0"`
+	TransactionAssetName   string      `json:"transaction_asset_name" validate:"required"`
+	TransactionStatus      string      `json:"transaction_status" validate:"omitempty,oneof=draft active"`
+	TransactionScheme      string      `json:"transaction_scheme" validate:"omitempty,oneof=flat annuity declining_balance"`
+}
+
+func (i *TransactionInput) OverridesPricing() bool {
+	return i.TransactionAdminFee.IsPositive() || i.TransactionInterest > 0
 }
 
 type TransactionResponse struct {
-	TransactionID             uint             `json:"transaction_id"`
-	TransactionContractNumber string           `json:"transaction_contract_number"`
-	TransactionNIK            string           `json:"transaction_nik"`
-	NIKCustomer               CustomerResponse `json:"NIKCustomer"`
-	TransactionLimit          uint             `json:"transaction_limit"`
-	IDLimit                   LimitResponse    `json:"IDLimit"`
-	TransactionOTR            float64          `json:"transaction_otr"`
-	TransactionAdminFee       float64          `json:"transaction_admin_fee"`
-	TransactionInstallment    float64          `json:"transaction_installment"`
-	TransactionInterest       float64          `json:"transaction_interest"`
-	TransactionAssetName      string           `json:"transaction_asset_name"`
-	TransactionCreatedBy      uint             `json:"transaction_created_by"`
-	CreatedByUser             UserResponse     `json:"CreatedByUser"`
+	TransactionID              uint             `json:"transaction_id"`
+	TransactionContractNumber  string           `json:"transaction_contract_number"`
+	TransactionNIK             string           `json:"transaction_nik"`
+	NIKCustomer                CustomerResponse `json:"NIKCustomer"`
+	TransactionLimit           uint             `json:"transaction_limit"`
+	IDLimit                    LimitResponse    `json:"IDLimit"`
+	TransactionProductID       *uint            `json:"transaction_product_id"`
+	TransactionPricingRuleID   *uint            `json:"transaction_pricing_rule_id"`
+	TransactionPricingOverride bool             `json:"transaction_pricing_override"`
+	TransactionOTR             money.Money      `json:"transaction_otr"`
+	TransactionAdminFee        money.Money      `json:"transaction_admin_fee"`
+	TransactionInstallment     float64          `json:"transaction_installment"`
+	TransactionInterest        float64          `json:"transaction_interest"`
+	TransactionAssetName       string           `json:"transaction_asset_name"`
+	TransactionDaysPastDue     int              `json:"transaction_days_past_due"`
+	TransactionCollectibility  string           `json:"transaction_collectibility"`
+	TransactionStatus          string           `json:"transaction_status"`
+	TransactionScheme          string           `json:"transaction_scheme"`
+	TransactionParentID        *uint            `json:"transaction_parent_id"`
+	TransactionVersion         int              `json:"transaction_version"`
+	TransactionOriginalNumber  string           `json:"transaction_original_number"`
+	TransactionCreatedBy       uint             `json:"transaction_created_by"`
+	CreatedByUser              UserResponse     `json:"CreatedByUser"`
 }
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"kreditplus/internal/money"
+	"time"
+)
+
+const (
+	RestructureTypeExtendTenor       = "extend_tenor"
+	RestructureTypeCapitaliseArrears = "capitalise_arrears"
+	RestructureTypePaymentHoliday    = "payment_holiday"
+)
+
+type TransactionRestructure struct {
+	RestructureID                uint        `gorm:"primaryKey" json:"restructure_id"`
+	RestructureFromTransactionID uint        `gorm:"not null;index" json:"restructure_from_transaction_id"`
+	FromTransaction              Transaction `gorm:"foreignKey:RestructureFromTransactionID;references:TransactionID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	RestructureToTransactionID   uint        `gorm:"not null;index" json:"restructure_to_transaction_id"`
+	ToTransaction                Transaction `gorm:"foreignKey:RestructureToTransactionID;references:TransactionID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+	RestructureType              string      `gorm:"not null" json:"restructure_type"`
+	RestructureOldTenor          float64     `gorm:"not null" json:"restructure_old_tenor"`
+	RestructureNewTenor          float64     `gorm:"not null" json:"restructure_new_tenor"`
+	RestructurePrincipal         money.Money `gorm:"not null" json:"restructure_principal"`
+	RestructureCapitalised       money.Money `gorm:"not null;default:0" json:"restructure_capitalised"`
+	RestructureHolidayMonths     int         `gorm:"not null;default:0" json:"restructure_holiday_months"`
+	RestructureReason            string      `json:"restructure_reason"`
+	RestructureCreatedBy         uint        `gorm:"not null" json:"restructure_created_by"`
+	CreatedByUser                User        `gorm:"foreignKey:RestructureCreatedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	RestructureCreatedAt         time.Time   `gorm:"autoCreateTime" json:"restructure_created_at"`
+}
+
+type TransactionRestructureInput struct {
+	RestructureType          string  `json:"restructure_type" validate:"required,oneof=extend_tenor capitalise_arrears payment_holiday"`
+	RestructureTenor         float64 `json:"restructure_tenor" validate:"required,gt=0"`
+	RestructureInterest      float64 `json:"restructure_interest" validate:"omitempty,gte=0"`
+	RestructureHolidayMonths int     `json:"restructure_holiday_months" validate:"required_if=RestructureType payment_holiday,omitempty,min=1,max=6"`
+	RestructureReason        string  `json:"restructure_reason" validate:"required,max=255"`
+}
+
+func (i *TransactionRestructureInput) OverridesPricing() bool {
+	return i.RestructureInterest > 0
+}
@@ -0,0 +1,9 @@
+package domain
+
+type TransactionRetryMetrics struct {
+	Transactions  int64            `json:"transactions"`
+	Retries       int64            `json:"retries"`
+	Recovered     int64            `json:"recovered"`
+	Exhausted     int64            `json:"exhausted"`
+	RetriesByCode map[string]int64 `json:"retries_by_code"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "kreditplus/internal/money"
+
+type TransactionSimulation struct {
+	SimulationTenor                int         `json:"simulation_tenor"`
+	SimulationScheme               string      `json:"simulation_scheme"`
+	SimulationRequested            bool        `json:"simulation_requested"`
+	SimulationLimitID              uint        `json:"simulation_limit_id"`
+	SimulationLimitRemainingAmount money.Money `json:"simulation_limit_remaining_amount"`
+	SimulationPrincipal            money.Money `json:"simulation_principal"`
+	SimulationTotalInterest        money.Money `json:"simulation_total_interest"`
+	SimulationTotalFee             money.Money `json:"simulation_total_fee"`
+	SimulationTotalPayable         money.Money `json:"simulation_total_payable"`
+	SimulationMonthlyInstallment   money.Money `json:"simulation_monthly_installment"`
+	SimulationRemainingAfter       money.Money `json:"simulation_remaining_limit_after"`
+	SimulationEligible             bool        `json:"simulation_eligible"`
+	SimulationReason               string      `json:"simulation_reason,omitempty"`
+}
@@ -0,0 +1,18 @@
+package domain
+
+import "time"
+
+type TransactionStatusHistory struct {
+	HistoryID            uint      `gorm:"primaryKey" json:"history_id"`
+	HistoryTransactionID uint      `gorm:"not null;index" json:"history_transaction_id"`
+	HistoryFromStatus    string    `json:"history_from_status"`
+	HistoryToStatus      string    `gorm:"not null" json:"history_to_status"`
+	HistoryReason        string    `json:"history_reason"`
+	HistoryChangedBy     uint      `gorm:"not null" json:"history_changed_by"`
+	ChangedByUser        User      `gorm:"foreignKey:HistoryChangedBy;references:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL;"`
+	HistoryChangedAt     time.Time `gorm:"not null" json:"history_changed_at"`
+}
+
+type TransactionTransitionInput struct {
+	Reason string `json:"reason" validate:"max=255"`
+}
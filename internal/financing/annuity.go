@@ -0,0 +1,29 @@
+package financing
+
+import (
+	"kreditplus/internal/money"
+	"math"
+)
+
+type annuityScheme struct{}
+
+func (annuityScheme) Name() string {
+	return SchemeAnnuity
+}
+
+func (s annuityScheme) Calculate(input Input) Result {
+	rate := monthlyRate(input)
+
+	payment := input.Principal.Div(max(input.Tenor, 1))
+	if rate > 0 {
+		payment = input.Principal.Mul(rate / (1 - math.Pow(1+rate, -float64(input.Tenor))))
+	}
+
+	return buildResult(s.Name(), input,
+		func(period int, balance money.Money) money.Money {
+			return balance.Mul(rate)
+		},
+		func(period int, balance, interest money.Money) money.Money {
+			return payment.Sub(interest)
+		})
+}
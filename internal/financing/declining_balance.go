@@ -0,0 +1,22 @@
+package financing
+
+import "kreditplus/internal/money"
+
+type decliningBalanceScheme struct{}
+
+func (decliningBalanceScheme) Name() string {
+	return SchemeDecliningBalance
+}
+
+func (s decliningBalanceScheme) Calculate(input Input) Result {
+	rate := monthlyRate(input)
+	principal := input.Principal.Div(max(input.Tenor, 1)).RoundRupiah()
+
+	return buildResult(s.Name(), input,
+		func(period int, balance money.Money) money.Money {
+			return balance.Mul(rate)
+		},
+		func(period int, balance, interest money.Money) money.Money {
+			return principal
+		})
+}
@@ -0,0 +1,131 @@
+package financing
+
+import (
+	"errors"
+	"kreditplus/internal/money"
+	"time"
+)
+
+const (
+	SchemeFlat             = "flat"
+	SchemeAnnuity          = "annuity"
+	SchemeDecliningBalance = "declining_balance"
+)
+
+var ErrUnknownScheme = errors.New("unknown financing scheme")
+
+type Input struct {
+	Principal    money.Money
+	AdminFee     money.Money
+	InterestRate float64
+	Tenor        int
+	StartDate    time.Time
+}
+
+type Period struct {
+	Number    int
+	DueDate   time.Time
+	Principal money.Money
+	Interest  money.Money
+	Fee       money.Money
+	Amount    money.Money
+	Balance   money.Money
+}
+
+type Result struct {
+	Scheme         string
+	Principal      money.Money
+	TotalInterest  money.Money
+	TotalFee       money.Money
+	TotalAmount    money.Money
+	MonthlyPayment money.Money
+	Periods        []Period
+}
+
+type FinancingScheme interface {
+	Name() string
+	Calculate(input Input) Result
+}
+
+var schemes = map[string]FinancingScheme{
+	SchemeFlat:             flatScheme{},
+	SchemeAnnuity:          annuityScheme{},
+	SchemeDecliningBalance: decliningBalanceScheme{},
+}
+
+func GetScheme(name string) (FinancingScheme, error) {
+	if name == "" {
+		name = SchemeFlat
+	}
+
+	scheme, ok := schemes[name]
+	if !ok {
+		return nil, ErrUnknownScheme
+	}
+	return scheme, nil
+}
+
+func Calculate(name string, input Input) (Result, error) {
+	scheme, err := GetScheme(name)
+	if err != nil {
+		return Result{}, err
+	}
+	return scheme.Calculate(input), nil
+}
+
+func buildResult(name string, input Input, interestFor func(period int, balance money.Money) money.Money, principalFor func(period int, balance, interest money.Money) money.Money) Result {
+	result := Result{Scheme: name, Principal: input.Principal}
+	if input.Tenor <= 0 {
+		result.TotalFee = input.AdminFee
+		result.TotalAmount = result.Principal.Add(result.TotalFee)
+		return result
+	}
+
+	fee := input.AdminFee.Div(input.Tenor).RoundRupiah()
+	balance := result.Principal
+
+	result.Periods = make([]Period, 0, input.Tenor)
+	for number := 1; number <= input.Tenor; number++ {
+		period := Period{
+			Number:   number,
+			DueDate:  AddMonths(input.StartDate, number),
+			Interest: interestFor(number, balance).RoundRupiah(),
+			Fee:      fee,
+		}
+
+		if number == input.Tenor {
+			period.Principal = balance
+			period.Fee = input.AdminFee.Sub(fee.Mul(float64(input.Tenor - 1)))
+		} else {
+			period.Principal = money.Min(balance, principalFor(number, balance, period.Interest).RoundRupiah())
+		}
+
+		balance = balance.Sub(period.Principal)
+		period.Balance = balance
+		period.Amount = period.Principal.Add(period.Interest).Add(period.Fee)
+
+		result.TotalInterest = result.TotalInterest.Add(period.Interest)
+		result.TotalFee = result.TotalFee.Add(period.Fee)
+		result.Periods = append(result.Periods, period)
+	}
+
+	result.TotalAmount = result.Principal.Add(result.TotalInterest).Add(result.TotalFee)
+	result.MonthlyPayment = result.Periods[0].Amount
+	return result
+}
+
+func AddMonths(date time.Time, months int) time.Time {
+	year, month, day := date.Date()
+	firstOfTarget := time.Date(year, month+time.Month(months), 1, 0, 0, 0, 0, date.Location())
+	lastDay := firstOfTarget.AddDate(0, 1, -1).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	hour, minute, second := date.Clock()
+	return time.Date(firstOfTarget.Year(), firstOfTarget.Month(), day, hour, minute, second, date.Nanosecond(), date.Location())
+}
+
+func monthlyRate(input Input) float64 {
+	return input.InterestRate / 100
+}
@@ -0,0 +1,26 @@
+package financing
+
+import "kreditplus/internal/money"
+
+type flatScheme struct{}
+
+func (flatScheme) Name() string {
+	return SchemeFlat
+}
+
+func (s flatScheme) Calculate(input Input) Result {
+	totalInterest := input.Principal.Mul(monthlyRate(input) * float64(input.Tenor)).RoundRupiah()
+	interest := totalInterest.Div(max(input.Tenor, 1)).RoundRupiah()
+	principal := input.Principal.Div(max(input.Tenor, 1)).RoundRupiah()
+
+	return buildResult(s.Name(), input,
+		func(period int, balance money.Money) money.Money {
+			if period == input.Tenor {
+				return totalInterest.Sub(interest.Mul(float64(input.Tenor - 1)))
+			}
+			return interest
+		},
+		func(period int, balance, interest money.Money) money.Money {
+			return principal
+		})
+}
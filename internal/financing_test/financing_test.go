@@ -0,0 +1,130 @@
+package financing_test
+
+import (
+	"kreditplus/internal/financing"
+	"kreditplus/internal/money"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var input = financing.Input{
+	Principal:    money.New(1200000),
+	AdminFee:     money.New(60000),
+	InterestRate: 1,
+	Tenor:        12,
+	StartDate:    time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+}
+
+func sumPeriods(result financing.Result) (principal, interest, fee, amount money.Money) {
+	for _, period := range result.Periods {
+		principal = principal.Add(period.Principal)
+		interest = interest.Add(period.Interest)
+		fee = fee.Add(period.Fee)
+		amount = amount.Add(period.Amount)
+	}
+	return principal, interest, fee, amount
+}
+
+func TestFlatScheme_MatchesLegacyFormula(t *testing.T) {
+	result, err := financing.Calculate(financing.SchemeFlat, input)
+
+	assert.Nil(t, err)
+	assert.Len(t, result.Periods, 12)
+	assert.Equal(t, money.New(144000), result.TotalInterest, "Flat interest is rate * OTR * tenor")
+	assert.Equal(t, money.New(1404000), result.TotalAmount)
+	assert.Equal(t, money.New(117000), result.MonthlyPayment)
+	assert.Equal(t, time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC), result.Periods[0].DueDate)
+
+	principal, interest, fee, amount := sumPeriods(result)
+	assert.Equal(t, money.New(1200000), principal)
+	assert.Equal(t, result.TotalInterest, interest)
+	assert.Equal(t, money.New(60000), fee)
+	assert.Equal(t, result.TotalAmount, amount)
+}
+
+func TestAnnuityScheme_EqualInstallments(t *testing.T) {
+	result, err := financing.Calculate(financing.SchemeAnnuity, input)
+
+	assert.Nil(t, err)
+	assert.Len(t, result.Periods, 12)
+	assert.Equal(t, money.New(111619), result.MonthlyPayment)
+
+	for _, period := range result.Periods {
+		assert.InDelta(t, result.MonthlyPayment.Float64(), period.Amount.Float64(), float64(input.Tenor)/2, "Annuity installments should be level up to whole-rupiah rounding")
+	}
+	assert.True(t, result.Periods[0].Interest.GreaterThan(result.Periods[11].Interest))
+	assert.True(t, result.Periods[11].Balance.IsZero())
+
+	principal, interest, _, amount := sumPeriods(result)
+	assert.Equal(t, money.New(1200000), principal)
+	assert.Equal(t, result.TotalInterest, interest)
+	assert.Equal(t, result.TotalAmount, amount)
+	assert.True(t, result.TotalInterest.LessThan(money.New(144000)), "Effective rate costs less than the same flat rate")
+}
+
+func TestDecliningBalanceScheme_InterestOnOutstanding(t *testing.T) {
+	result, err := financing.Calculate(financing.SchemeDecliningBalance, input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(78000), result.TotalInterest, "Interest is charged on the declining principal")
+	assert.Equal(t, money.New(12000), result.Periods[0].Interest)
+	assert.Equal(t, money.New(1000), result.Periods[11].Interest)
+	assert.Equal(t, money.New(100000), result.Periods[0].Principal)
+
+	principal, _, _, amount := sumPeriods(result)
+	assert.Equal(t, money.New(1200000), principal)
+	assert.Equal(t, result.TotalAmount, amount)
+}
+
+func TestCalculate_InstallmentsAreWholeRupiah(t *testing.T) {
+	result, err := financing.Calculate(financing.SchemeFlat, financing.Input{
+		Principal:    money.New(1000000),
+		AdminFee:     money.New(50000),
+		InterestRate: 2,
+		Tenor:        3,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(333333), result.Periods[0].Principal)
+	assert.Equal(t, money.New(333334), result.Periods[2].Principal, "The last period absorbs the rounding remainder")
+	assert.Equal(t, money.New(16666), result.Periods[2].Fee)
+	assert.Equal(t, money.New(1110000), result.TotalAmount)
+	for _, period := range result.Periods {
+		assert.Equal(t, period.Amount, period.Amount.RoundRupiah())
+	}
+}
+
+func TestGetScheme(t *testing.T) {
+	scheme, err := financing.GetScheme("")
+	assert.Nil(t, err)
+	assert.Equal(t, financing.SchemeFlat, scheme.Name(), "Contracts without a scheme are flat")
+
+	_, err = financing.GetScheme("balloon")
+	assert.ErrorIs(t, err, financing.ErrUnknownScheme)
+}
+
+func TestSchedule_ClampsDueDateToMonthEnd(t *testing.T) {
+	monthEnd := input
+	monthEnd.StartDate = time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	result, err := financing.Calculate(financing.SchemeFlat, monthEnd)
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), result.Periods[0].DueDate)
+	assert.Equal(t, time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), result.Periods[1].DueDate, "Later due dates should not drift after a short month")
+	assert.Equal(t, time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), result.Periods[2].DueDate)
+	assert.Equal(t, time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC), result.Periods[11].DueDate)
+}
+
+func TestSchedule_LeapDayStartDate(t *testing.T) {
+	leapDay := input
+	leapDay.StartDate = time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	result, err := financing.Calculate(financing.SchemeAnnuity, leapDay)
+
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2024, 3, 29, 0, 0, 0, 0, time.UTC), result.Periods[0].DueDate)
+	assert.Equal(t, time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), result.Periods[11].DueDate, "Non-leap February should clamp to the 28th")
+}
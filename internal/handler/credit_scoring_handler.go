@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type CreditScoringHandler struct {
+	usecase usecase.CreditScoringUsecase
+}
+
+func NewCreditScoringHandler(usecase usecase.CreditScoringUsecase) *CreditScoringHandler {
+	return &CreditScoringHandler{usecase: usecase}
+}
+
+func (h *CreditScoringHandler) GetLimitRecommendation(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetLimitRecommendation")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid customer ID in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"error":       err.Error(),
+		}).Warn("Customer not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		return
+	}
+
+	recommendation, err := h.usecase.RecommendLimit(customer)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"error":       err.Error(),
+		}).Error("Failed to calculate limit recommendation")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate limit recommendation"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_id": id,
+		"risk_grade":  recommendation.RiskGrade,
+	}).Info("Limit recommendation retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{"limit_recommendation": recommendation})
+}
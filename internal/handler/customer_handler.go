@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"html"
 	"kreditplus/internal/domain"
 	"kreditplus/internal/usecase"
@@ -55,6 +56,12 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 		return
 	}
 
+	if input.CustomerRiskGrade != "" && authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Non-admin attempted to assign a customer risk grade")
+		c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: only admins can assign a risk grade"})
+		return
+	}
+
 	ktpPhotoPath, err := utils.SaveUploadedFile(c, "customer_ktp_photo", "uploads/ktp")
 	if err != nil {
 		utils.Logger.Warn("Failed to upload KTP photo")
@@ -78,16 +85,29 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 		CustomerSalary:      input.CustomerSalary,
 		CustomerKTPPhoto:    ktpPhotoPath,
 		CustomerSelfiePhoto: selfiePhotoPath,
+		CustomerRiskGrade:   input.CustomerRiskGrade,
+		CustomerPhone:       input.CustomerPhone,
+		CustomerEmail:       input.CustomerEmail,
 		CustomerCreatedBy:   authUser.(domain.User).UserID,
 		CustomerCreatedAt:   time.Now(),
 	}
 
-	err = h.usecase.CreateCustomer(customer)
+	duplicates, err := h.usecase.CreateCustomer(customer)
 	if err != nil {
 		utils.Logger.WithFields(logrus.Fields{
 			"user_id": authUser.(domain.User).UserID,
 			"error":   err.Error(),
 		}).Error("Failed to create customer")
+		var nikErr *usecase.NIKValidationError
+		if errors.As(err, &nikErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "mismatches": nikErr.Mismatches})
+			return
+		}
+		var screeningErr *usecase.ScreeningError
+		if errors.As(err, &screeningErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "screening": screeningErr.Result})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create customer"})
 		return
 	}
@@ -97,7 +117,7 @@ func (h *CustomerHandler) CreateCustomer(c *gin.Context) {
 		"customer_nik": customer.CustomerNIK,
 		"created_at":   customer.CustomerCreatedAt,
 	}).Infof("Customer NIK %s created successfully by User %d", customer.CustomerNIK, authUser.(domain.User).UserID)
-	c.JSON(http.StatusOK, gin.H{"message": "Customer created successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Customer created successfully", "suspected_duplicates": duplicates})
 }
 
 func (h *CustomerHandler) GetCustomer(c *gin.Context) {
@@ -219,19 +239,24 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 		return
 	}
 
+	identityChanged := false
 	if input.CustomerNIK != "" {
+		identityChanged = identityChanged || utils.SanitizeString(input.CustomerNIK) != customer.CustomerNIK
 		customer.CustomerNIK = input.CustomerNIK
 	}
 
 	if input.CustomerFullName != "" {
+		identityChanged = identityChanged || utils.SanitizeString(input.CustomerFullName) != customer.CustomerFullName
 		customer.CustomerFullName = input.CustomerFullName
 	}
 
 	if input.CustomerLegalName != "" {
+		identityChanged = identityChanged || utils.SanitizeString(input.CustomerLegalName) != customer.CustomerLegalName
 		customer.CustomerLegalName = input.CustomerLegalName
 	}
 
 	if input.CustomerBirthPlace != "" {
+		identityChanged = identityChanged || utils.SanitizeString(input.CustomerBirthPlace) != customer.CustomerBirthPlace
 		customer.CustomerBirthPlace = input.CustomerBirthPlace
 	}
 
@@ -242,13 +267,31 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid birth date format"})
 			return
 		}
+		identityChanged = identityChanged || input.CustomerBirthDate != customer.CustomerBirthDate.Format("2006-01-02")
 		customer.CustomerBirthDate = parsedDate
 	}
 
-	if input.CustomerSalary > 0 {
+	if input.CustomerSalary.IsPositive() {
 		customer.CustomerSalary = input.CustomerSalary
 	}
 
+	if input.CustomerPhone != "" {
+		customer.CustomerPhone = input.CustomerPhone
+	}
+
+	if input.CustomerEmail != "" {
+		customer.CustomerEmail = input.CustomerEmail
+	}
+
+	if input.CustomerRiskGrade != "" {
+		if authUserModel.UserRole != "admin" {
+			utils.Logger.Warn("Non-admin attempted to change a customer risk grade")
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: only admins can assign a risk grade"})
+			return
+		}
+		customer.CustomerRiskGrade = input.CustomerRiskGrade
+	}
+
 	if _, err := c.FormFile("customer_ktp_photo"); err == nil {
 		if customer.CustomerKTPPhoto != "" {
 			if err := os.Remove(customer.CustomerKTPPhoto); err != nil {
@@ -262,6 +305,7 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 			return
 		}
 		customer.CustomerKTPPhoto = ktpPhotoPath
+		identityChanged = true
 	}
 
 	if _, err := c.FormFile("customer_selfie_photo"); err == nil {
@@ -277,6 +321,11 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 			return
 		}
 		customer.CustomerSelfiePhoto = selfiePhotoPath
+		identityChanged = true
+	}
+
+	if identityChanged {
+		customer.ResubmitKYC(timeNow)
 	}
 
 	customer.CustomerEditedBy = &authUserModel.UserID
@@ -288,6 +337,11 @@ func (h *CustomerHandler) UpdateCustomer(c *gin.Context) {
 			"user_id": authUserModel.UserID,
 			"error":   err.Error(),
 		}).Error("Failed to update customer")
+		var nikErr *usecase.NIKValidationError
+		if errors.As(err, &nikErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "mismatches": nikErr.Mismatches})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -354,3 +408,165 @@ func (h *CustomerHandler) DeleteCustomer(c *gin.Context) {
 	}).Infof("Customer NIK %s deleted successfully by User %d", customer.CustomerNIK, authUserModel.UserID)
 	c.JSON(http.StatusOK, gin.H{"message": "Customer deleted successfully"})
 }
+
+func (h *CustomerHandler) GetKYCReviewQueue(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to GetKYCReviewQueue")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		utils.Logger.Warn("Invalid limit value in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page value"})
+		return
+	}
+
+	status := c.DefaultQuery("status", domain.CustomerKYCPending)
+	if err := utils.Validate.Var(status, "oneof=pending verified rejected needs_resubmission"); err != nil {
+		utils.Logger.Warnf("Invalid KYC status filter: %s", status)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid KYC status"})
+		return
+	}
+
+	offset := (page - 1) * limit
+
+	customers, err := h.usecase.GetKYCReviewQueue(status, limit, offset)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"kyc_status": status,
+			"error":      err.Error(),
+		}).Error("Failed to retrieve KYC review queue")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve KYC review queue"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"kyc_status": status,
+		"page":       page,
+		"limit":      limit,
+	}).Info("KYC review queue retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":       page,
+		"limit":      limit,
+		"kyc_status": status,
+		"customers":  customers,
+	})
+}
+
+func (h *CustomerHandler) ReviewCustomerKYC(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to ReviewCustomerKYC")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid customer ID in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+		return
+	}
+
+	var input domain.CustomerKYCReviewInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for KYC review")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"error":       err.Error(),
+		}).Warn("Customer not found for KYC review")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		return
+	}
+
+	if err := h.usecase.ReviewCustomerKYC(authUserModel.UserID, customer, input); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"user_id":     authUserModel.UserID,
+			"error":       err.Error(),
+		}).Error("Failed to review customer KYC")
+		if errors.Is(err, usecase.ErrKYCReasonRequired) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidKYCTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to review customer KYC"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_id": id,
+		"kyc_status":  customer.CustomerKYCStatus,
+	}).Infof("Customer NIK %s KYC reviewed by User %d", customer.CustomerNIK, authUserModel.UserID)
+
+	c.JSON(http.StatusOK, gin.H{"message": "Customer KYC reviewed successfully", "customer": customer})
+}
+
+func (h *CustomerHandler) GetSuspectedDuplicates(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetSuspectedDuplicates")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid customer ID in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"error":       err.Error(),
+		}).Warn("Customer not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		return
+	}
+
+	duplicates, err := h.usecase.GetSuspectedDuplicates(customer)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"error":       err.Error(),
+		}).Error("Failed to detect suspected duplicates")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to detect suspected duplicates"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_id": id,
+		"duplicates":  len(duplicates),
+	}).Info("Suspected duplicates retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{"customer_id": id, "suspected_duplicates": duplicates})
+}
@@ -0,0 +1,605 @@
+package handler
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type CustomerProfileHandler struct {
+	usecase usecase.CustomerProfileUsecase
+}
+
+func NewCustomerProfileHandler(usecase usecase.CustomerProfileUsecase) *CustomerProfileHandler {
+	return &CustomerProfileHandler{usecase: usecase}
+}
+
+func (h *CustomerProfileHandler) GetCustomerProfile(c *gin.Context) {
+	if _, exists := c.Get("user"); !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetCustomerProfile")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	customer, ok := h.findCustomer(c)
+	if !ok {
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_id": customer.CustomerID,
+	}).Info("Customer profile retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"customer_id":                 customer.CustomerID,
+		"customer_addresses":          customer.CustomerAddresses,
+		"customer_phones":             customer.CustomerPhones,
+		"customer_emails":             customer.CustomerEmails,
+		"customer_employment":         customer.CustomerEmployment,
+		"customer_emergency_contacts": customer.CustomerEmergencyContacts,
+	})
+}
+
+func (h *CustomerProfileHandler) AddCustomerAddress(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to AddCustomerAddress")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	customer, ok := h.findCustomer(c)
+	if !ok {
+		return
+	}
+
+	var input domain.CustomerAddressInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	address, err := h.usecase.AddCustomerAddress(authUserModel.UserID, customer, input)
+	if err != nil {
+		respondProfileError(c, err, "Failed to add customer address")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d address %d added by User %d", customer.CustomerID, address.AddressID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer address added successfully", "customer_address": address})
+}
+
+func (h *CustomerProfileHandler) UpdateCustomerAddress(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to UpdateCustomerAddress")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	address, ok := h.findAddress(c)
+	if !ok {
+		return
+	}
+
+	var input domain.CustomerAddressInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	if err := h.usecase.UpdateCustomerAddress(authUserModel.UserID, address, input); err != nil {
+		respondProfileError(c, err, "Failed to update customer address")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d address %d updated by User %d", address.AddressCustomerID, address.AddressID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer address updated successfully", "customer_address": address})
+}
+
+func (h *CustomerProfileHandler) DeleteCustomerAddress(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to DeleteCustomerAddress")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	address, ok := h.findAddress(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteCustomerAddress(address); err != nil {
+		respondProfileError(c, err, "Failed to delete customer address")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d address %d deleted by User %d", address.AddressCustomerID, address.AddressID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer address deleted successfully"})
+}
+
+func (h *CustomerProfileHandler) AddCustomerPhone(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to AddCustomerPhone")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	customer, ok := h.findCustomer(c)
+	if !ok {
+		return
+	}
+
+	var input domain.CustomerPhoneInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	phone, err := h.usecase.AddCustomerPhone(authUserModel.UserID, customer, input)
+	if err != nil {
+		respondProfileError(c, err, "Failed to add customer phone")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d phone %d added by User %d", customer.CustomerID, phone.PhoneID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer phone added successfully", "customer_phone": phone})
+}
+
+func (h *CustomerProfileHandler) UpdateCustomerPhone(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to UpdateCustomerPhone")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	phone, ok := h.findPhone(c)
+	if !ok {
+		return
+	}
+
+	var input domain.CustomerPhoneInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	if err := h.usecase.UpdateCustomerPhone(authUserModel.UserID, phone, input); err != nil {
+		respondProfileError(c, err, "Failed to update customer phone")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d phone %d updated by User %d", phone.PhoneCustomerID, phone.PhoneID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer phone updated successfully", "customer_phone": phone})
+}
+
+func (h *CustomerProfileHandler) VerifyCustomerPhone(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to VerifyCustomerPhone")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	phone, ok := h.findPhone(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.VerifyCustomerPhone(authUserModel.UserID, phone); err != nil {
+		respondProfileError(c, err, "Failed to verify customer phone")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d phone %d verified by User %d", phone.PhoneCustomerID, phone.PhoneID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer phone verified successfully", "customer_phone": phone})
+}
+
+func (h *CustomerProfileHandler) DeleteCustomerPhone(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to DeleteCustomerPhone")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	phone, ok := h.findPhone(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteCustomerPhone(phone); err != nil {
+		respondProfileError(c, err, "Failed to delete customer phone")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d phone %d deleted by User %d", phone.PhoneCustomerID, phone.PhoneID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer phone deleted successfully"})
+}
+
+func (h *CustomerProfileHandler) AddCustomerEmail(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to AddCustomerEmail")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	customer, ok := h.findCustomer(c)
+	if !ok {
+		return
+	}
+
+	var input domain.CustomerEmailInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	email, err := h.usecase.AddCustomerEmail(authUserModel.UserID, customer, input)
+	if err != nil {
+		respondProfileError(c, err, "Failed to add customer email")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d email %d added by User %d", customer.CustomerID, email.EmailID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer email added successfully", "customer_email": email})
+}
+
+func (h *CustomerProfileHandler) UpdateCustomerEmail(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to UpdateCustomerEmail")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	email, ok := h.findEmail(c)
+	if !ok {
+		return
+	}
+
+	var input domain.CustomerEmailInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	if err := h.usecase.UpdateCustomerEmail(authUserModel.UserID, email, input); err != nil {
+		respondProfileError(c, err, "Failed to update customer email")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d email %d updated by User %d", email.EmailCustomerID, email.EmailID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer email updated successfully", "customer_email": email})
+}
+
+func (h *CustomerProfileHandler) VerifyCustomerEmail(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to VerifyCustomerEmail")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	email, ok := h.findEmail(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.VerifyCustomerEmail(authUserModel.UserID, email); err != nil {
+		respondProfileError(c, err, "Failed to verify customer email")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d email %d verified by User %d", email.EmailCustomerID, email.EmailID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer email verified successfully", "customer_email": email})
+}
+
+func (h *CustomerProfileHandler) DeleteCustomerEmail(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to DeleteCustomerEmail")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	email, ok := h.findEmail(c)
+	if !ok {
+		return
+	}
+
+	if err := h.usecase.DeleteCustomerEmail(email); err != nil {
+		respondProfileError(c, err, "Failed to delete customer email")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d email %d deleted by User %d", email.EmailCustomerID, email.EmailID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer email deleted successfully"})
+}
+
+func (h *CustomerProfileHandler) SaveCustomerEmployment(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to SaveCustomerEmployment")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	customer, ok := h.findCustomer(c)
+	if !ok {
+		return
+	}
+
+	var input domain.CustomerEmploymentInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	employment, err := h.usecase.SaveCustomerEmployment(authUserModel.UserID, customer, input)
+	if err != nil {
+		respondProfileError(c, err, "Failed to save customer employment")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d employment saved by User %d", customer.CustomerID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer employment saved successfully", "customer_employment": employment})
+}
+
+func (h *CustomerProfileHandler) DeleteCustomerEmployment(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to DeleteCustomerEmployment")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	customer, ok := h.findCustomer(c)
+	if !ok {
+		return
+	}
+
+	if customer.CustomerEmployment == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer employment not found"})
+		return
+	}
+
+	if err := h.usecase.DeleteCustomerEmployment(customer.CustomerEmployment); err != nil {
+		respondProfileError(c, err, "Failed to delete customer employment")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d employment deleted by User %d", customer.CustomerID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer employment deleted successfully"})
+}
+
+func (h *CustomerProfileHandler) AddCustomerEmergencyContact(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to AddCustomerEmergencyContact")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	customer, ok := h.findCustomer(c)
+	if !ok {
+		return
+	}
+
+	var input domain.CustomerEmergencyContactInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	contact, err := h.usecase.AddCustomerEmergencyContact(authUserModel.UserID, customer, input)
+	if err != nil {
+		respondProfileError(c, err, "Failed to add customer emergency contact")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d emergency contact %d added by User %d", customer.CustomerID, contact.ContactID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer emergency contact added successfully", "customer_emergency_contact": contact})
+}
+
+func (h *CustomerProfileHandler) UpdateCustomerEmergencyContact(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to UpdateCustomerEmergencyContact")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	customer, ok := h.findCustomer(c)
+	if !ok {
+		return
+	}
+
+	contactID, ok := profileItemID(c, "contactId", "emergency contact")
+	if !ok {
+		return
+	}
+
+	contact, err := h.usecase.GetCustomerEmergencyContactByID(customer.CustomerID, contactID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer emergency contact not found"})
+		return
+	}
+
+	var input domain.CustomerEmergencyContactInput
+	if !bindProfileInput(c, &input) {
+		return
+	}
+
+	if err := h.usecase.UpdateCustomerEmergencyContact(authUserModel.UserID, customer, contact, input); err != nil {
+		respondProfileError(c, err, "Failed to update customer emergency contact")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d emergency contact %d updated by User %d", customer.CustomerID, contact.ContactID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer emergency contact updated successfully", "customer_emergency_contact": contact})
+}
+
+func (h *CustomerProfileHandler) DeleteCustomerEmergencyContact(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to DeleteCustomerEmergencyContact")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	customerID, ok := profileItemID(c, "id", "customer")
+	if !ok {
+		return
+	}
+
+	contactID, ok := profileItemID(c, "contactId", "emergency contact")
+	if !ok {
+		return
+	}
+
+	contact, err := h.usecase.GetCustomerEmergencyContactByID(customerID, contactID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer emergency contact not found"})
+		return
+	}
+
+	if err := h.usecase.DeleteCustomerEmergencyContact(contact); err != nil {
+		respondProfileError(c, err, "Failed to delete customer emergency contact")
+		return
+	}
+
+	utils.Logger.Infof("Customer %d emergency contact %d deleted by User %d", customerID, contact.ContactID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Customer emergency contact deleted successfully"})
+}
+
+func (h *CustomerProfileHandler) findCustomer(c *gin.Context) (*domain.Customer, bool) {
+	id, ok := profileItemID(c, "id", "customer")
+	if !ok {
+		return nil, false
+	}
+
+	customer, err := h.usecase.GetCustomerByID(id)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"error":       err.Error(),
+		}).Warn("Customer not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		return nil, false
+	}
+	return customer, true
+}
+
+func (h *CustomerProfileHandler) findAddress(c *gin.Context) (*domain.CustomerAddress, bool) {
+	customerID, ok := profileItemID(c, "id", "customer")
+	if !ok {
+		return nil, false
+	}
+	addressID, ok := profileItemID(c, "addressId", "address")
+	if !ok {
+		return nil, false
+	}
+
+	address, err := h.usecase.GetCustomerAddressByID(customerID, addressID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer address not found"})
+		return nil, false
+	}
+	return address, true
+}
+
+func (h *CustomerProfileHandler) findPhone(c *gin.Context) (*domain.CustomerPhoneNumber, bool) {
+	customerID, ok := profileItemID(c, "id", "customer")
+	if !ok {
+		return nil, false
+	}
+	phoneID, ok := profileItemID(c, "phoneId", "phone")
+	if !ok {
+		return nil, false
+	}
+
+	phone, err := h.usecase.GetCustomerPhoneByID(customerID, phoneID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer phone not found"})
+		return nil, false
+	}
+	return phone, true
+}
+
+func (h *CustomerProfileHandler) findEmail(c *gin.Context) (*domain.CustomerEmailAddress, bool) {
+	customerID, ok := profileItemID(c, "id", "customer")
+	if !ok {
+		return nil, false
+	}
+	emailID, ok := profileItemID(c, "emailId", "email")
+	if !ok {
+		return nil, false
+	}
+
+	email, err := h.usecase.GetCustomerEmailByID(customerID, emailID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer email not found"})
+		return nil, false
+	}
+	return email, true
+}
+
+func profileItemID(c *gin.Context, param, label string) (uint, bool) {
+	id, err := strconv.Atoi(c.Param(param))
+	if err != nil || id <= 0 {
+		utils.Logger.Warnf("Invalid %s ID in request", label)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + label + " ID"})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func bindProfileInput(c *gin.Context, input interface{}) bool {
+	if err := c.ShouldBindJSON(input); err != nil {
+		utils.Logger.Warn("Invalid request format for customer profile")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return false
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
+
+func respondProfileError(c *gin.Context, err error, message string) {
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_id": c.Param("id"),
+		"error":       err.Error(),
+	}).Error(message)
+
+	switch {
+	case errors.Is(err, usecase.ErrCustomerAddressTypeExists) || errors.Is(err, usecase.ErrCustomerPhoneExists) || errors.Is(err, usecase.ErrCustomerEmailExists):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, usecase.ErrEmployerRequired) || errors.Is(err, usecase.ErrInvalidEmploymentStartDate) || errors.Is(err, usecase.ErrEmergencyContactIsCustomer):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
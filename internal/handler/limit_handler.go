@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"errors"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
 	"kreditplus/internal/usecase"
 	"kreditplus/internal/utils"
 	"net/http"
@@ -13,11 +15,12 @@ import (
 )
 
 type LimitHandler struct {
-	usecase usecase.LimitUsecase
+	usecase        usecase.LimitUsecase
+	scoringUsecase usecase.CreditScoringUsecase
 }
 
-func NewLimitHandler(usecase usecase.LimitUsecase) *LimitHandler {
-	return &LimitHandler{usecase: usecase}
+func NewLimitHandler(usecase usecase.LimitUsecase, scoringUsecase usecase.CreditScoringUsecase) *LimitHandler {
+	return &LimitHandler{usecase: usecase, scoringUsecase: scoringUsecase}
 }
 
 func (h *LimitHandler) CreateLimit(c *gin.Context) {
@@ -51,12 +54,41 @@ func (h *LimitHandler) CreateLimit(c *gin.Context) {
 		return
 	}
 
+	limitAmount := input.LimitAmount
+	if limitAmount.IsZero() {
+		recommendation, err := h.scoringUsecase.RecommendLimit(customer)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"customer_nik": customer.CustomerNIK,
+				"error":        err.Error(),
+			}).Error("Failed to calculate limit recommendation")
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate limit recommendation"})
+			return
+		}
+
+		limitAmount = recommendation.AmountForTenor(input.LimitTenor)
+		if !limitAmount.IsPositive() {
+			utils.Logger.WithFields(logrus.Fields{
+				"customer_nik": customer.CustomerNIK,
+				"limit_tenor":  input.LimitTenor,
+				"risk_grade":   recommendation.RiskGrade,
+			}).Warn("No limit recommended for prefill")
+			c.JSON(http.StatusBadRequest, gin.H{"error": usecase.ErrNoLimitRecommended.Error(), "limit_recommendation": recommendation})
+			return
+		}
+
+		if err := h.scoringUsecase.AssignRiskGrade(customer, recommendation.RiskGrade); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign risk grade"})
+			return
+		}
+	}
+
 	limit := domain.Limit{
 		LimitNIK:             customer.CustomerNIK,
 		LimitTenor:           input.LimitTenor,
-		LimitAmount:          input.LimitAmount,
-		LimitUsedAmount:      0,
-		LimitRemainingAmount: input.LimitAmount,
+		LimitAmount:          limitAmount,
+		LimitUsedAmount:      money.Money{},
+		LimitRemainingAmount: limitAmount,
 		LimitCreatedBy:       authUser.(domain.User).UserID,
 		LimitCreatedAt:       time.Now(),
 	}
@@ -67,6 +99,19 @@ func (h *LimitHandler) CreateLimit(c *gin.Context) {
 			"user_id": authUser.(domain.User).UserID,
 			"error":   err.Error(),
 		}).Error("Failed to create limit")
+		var screeningErr *usecase.ScreeningError
+		if errors.As(err, &screeningErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "screening": screeningErr.Result})
+			return
+		}
+		if errors.Is(err, usecase.ErrTenorNotOffered) || errors.Is(err, usecase.ErrCustomerNotVerified) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrLimitAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create limit"})
 		return
 	}
@@ -76,7 +121,7 @@ func (h *LimitHandler) CreateLimit(c *gin.Context) {
 		"limit_nik":  limit.LimitNIK,
 		"created_at": time.Now(),
 	}).Infof("Limit NIK %s created successfully by User %d", limit.LimitNIK, authUser.(domain.User).UserID)
-	c.JSON(http.StatusOK, gin.H{"message": "Limit created successfully"})
+	c.JSON(http.StatusOK, gin.H{"message": "Limit created successfully", "limit_amount": limit.LimitAmount})
 }
 
 func (h *LimitHandler) GetLimit(c *gin.Context) {
@@ -206,15 +251,15 @@ func (h *LimitHandler) UpdateLimit(c *gin.Context) {
 		limit.LimitTenor = input.LimitTenor
 	}
 
-	if input.LimitAmount > 0 {
+	if input.LimitAmount.IsPositive() {
 		limit.LimitAmount = input.LimitAmount
 	}
 
-	if input.LimitUsedAmount != nil && *input.LimitUsedAmount > 0 {
+	if input.LimitUsedAmount != nil && input.LimitUsedAmount.IsPositive() {
 		limit.LimitUsedAmount = *input.LimitUsedAmount
 	}
 
-	if input.LimitRemainingAmount != nil && *input.LimitRemainingAmount > 0 {
+	if input.LimitRemainingAmount != nil && input.LimitRemainingAmount.IsPositive() {
 		limit.LimitRemainingAmount = *input.LimitRemainingAmount
 	}
 
@@ -227,6 +272,10 @@ func (h *LimitHandler) UpdateLimit(c *gin.Context) {
 			"user_id": authUserModel.UserID,
 			"error":   err.Error(),
 		}).Error("Failed to update user")
+		if errors.Is(err, usecase.ErrTenorNotOffered) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -265,7 +314,7 @@ func (h *LimitHandler) DeleteLimit(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.DeleteLimit(uint(id))
+	err = h.usecase.DeleteLimit(authUserModel.UserID, uint(id))
 	if err != nil {
 		utils.Logger.WithFields(logrus.Fields{
 			"user_id":   authUserModel.UserID,
@@ -283,3 +332,214 @@ func (h *LimitHandler) DeleteLimit(c *gin.Context) {
 	}).Infof("Limit NIK %s deleted successfully by User %d", limit.LimitNIK, authUserModel.UserID)
 	c.JSON(http.StatusOK, gin.H{"message": "Limit deleted successfully"})
 }
+
+func (h *LimitHandler) GetLimitMovements(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetLimitMovements")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid limit ID in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit ID"})
+		return
+	}
+
+	pageLimit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || pageLimit <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page value"})
+		return
+	}
+
+	limit, err := h.usecase.GetLimitByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_id": id,
+			"error":    err.Error(),
+		}).Warn("Limit not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Limit not found"})
+		return
+	}
+
+	movements, ledger, err := h.usecase.GetLimitMovements(limit, pageLimit, (page-1)*pageLimit)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_id": limit.LimitID,
+			"error":    err.Error(),
+		}).Error("Failed to retrieve limit movements")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve limit movements"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":      page,
+		"limit":     pageLimit,
+		"limit_id":  limit.LimitID,
+		"ledger":    ledger,
+		"movements": movements,
+	})
+}
+
+func (h *LimitHandler) GetCustomerLimit(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetCustomerLimit")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByNIK(c.Param("nik"))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": c.Param("nik"),
+			"error":        err.Error(),
+		}).Warn("Customer not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer NIK not found"})
+		return
+	}
+
+	summary, err := h.usecase.GetCustomerLimitSummary(customer.CustomerNIK)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to retrieve customer limit")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve customer limit"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_nik": customer.CustomerNIK,
+	}).Info("Customer limit retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{"customer_limit": summary})
+}
+
+func (h *LimitHandler) SetCustomerLimit(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to SetCustomerLimit")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	var input domain.CustomerLimitInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for setting customer limit")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByNIK(c.Param("nik"))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": c.Param("nik"),
+			"error":        err.Error(),
+		}).Warn("Customer not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer NIK not found"})
+		return
+	}
+
+	customerLimit, err := h.usecase.SetCustomerLimit(authUserModel.UserID, customer.CustomerNIK, input.CustomerLimitAmount)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":      authUserModel.UserID,
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to set customer limit")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set customer limit"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":      authUserModel.UserID,
+		"customer_nik": customer.CustomerNIK,
+	}).Infof("Customer limit for NIK %s set by User %d", customer.CustomerNIK, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"customer_limit": customerLimit})
+}
+
+func (h *LimitHandler) UpsertLimit(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to UpsertLimit")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	tenor, err := strconv.Atoi(c.Param("tenor"))
+	if err != nil || tenor <= 0 {
+		utils.Logger.Warn("Invalid tenor in limit upsert request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tenor"})
+		return
+	}
+
+	var input domain.UpsertLimitInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for upserting limit")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByNIK(c.Param("nik"))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": c.Param("nik"),
+			"error":        err.Error(),
+		}).Warn("Customer not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer NIK not found"})
+		return
+	}
+
+	limit, created, err := h.usecase.UpsertLimit(authUserModel.UserID, customer.CustomerNIK, tenor, input.LimitAmount)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":     authUserModel.UserID,
+			"limit_nik":   customer.CustomerNIK,
+			"limit_tenor": tenor,
+			"error":       err.Error(),
+		}).Error("Failed to upsert limit")
+		var screeningErr *usecase.ScreeningError
+		if errors.As(err, &screeningErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "screening": screeningErr.Result})
+			return
+		}
+		if errors.Is(err, usecase.ErrTenorNotOffered) || errors.Is(err, usecase.ErrCustomerNotVerified) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to upsert limit"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":     authUserModel.UserID,
+		"limit_id":    limit.LimitID,
+		"limit_nik":   limit.LimitNIK,
+		"limit_tenor": limit.LimitTenor,
+		"created":     created,
+	}).Infof("Limit NIK %s tenor %d upserted by User %d", limit.LimitNIK, limit.LimitTenor, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"limit": limit, "created": created})
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type LimitReconciliationHandler struct {
+	usecase usecase.LimitReconciliationUsecase
+}
+
+func NewLimitReconciliationHandler(usecase usecase.LimitReconciliationUsecase) *LimitReconciliationHandler {
+	return &LimitReconciliationHandler{usecase: usecase}
+}
+
+func (h *LimitReconciliationHandler) ReconcileLimits(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to ReconcileLimits")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	repair, err := strconv.ParseBool(c.DefaultQuery("repair", "false"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid repair value"})
+		return
+	}
+
+	result, err := h.usecase.ReconcileLimits(authUserModel.UserID, repair)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id": authUserModel.UserID,
+			"repair":  repair,
+			"error":   err.Error(),
+		}).Error("Failed to reconcile limits")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to reconcile limits"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"reconciliation": result})
+}
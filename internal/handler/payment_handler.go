@@ -0,0 +1,135 @@
+package handler
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type PaymentHandler struct {
+	usecase usecase.PaymentUsecase
+}
+
+func NewPaymentHandler(usecase usecase.PaymentUsecase) *PaymentHandler {
+	return &PaymentHandler{usecase: usecase}
+}
+
+func (h *PaymentHandler) CreatePayment(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to CreatePayment")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid transaction ID provided for payment")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	transaction, err := h.usecase.GetTransactionByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": id,
+			"error":          err.Error(),
+		}).Warn("Transaction not found for payment")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	var input domain.PaymentInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for creating payment")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment, err := h.usecase.CreatePayment(authUser.(domain.User).UserID, transaction, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                     authUser.(domain.User).UserID,
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to record payment")
+		if errors.Is(err, usecase.ErrNoOutstandingInstallments) || errors.Is(err, usecase.ErrPaymentExceedsOutstanding) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrTransactionNotActive) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record payment"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":                     authUser.(domain.User).UserID,
+		"transaction_contract_number": transaction.TransactionContractNumber,
+		"payment_amount":              payment.PaymentAmount,
+	}).Infof("Payment for Contract Number %s recorded successfully by User %d", transaction.TransactionContractNumber, authUser.(domain.User).UserID)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Payment recorded successfully",
+		"payment": payment,
+	})
+}
+
+func (h *PaymentHandler) GetPayments(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetPayments")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid transaction ID in payments request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	transaction, err := h.usecase.GetTransactionByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": id,
+			"error":          err.Error(),
+		}).Warn("Transaction not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	payments, err := h.usecase.GetPaymentsByTransactionID(transaction.TransactionID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": transaction.TransactionID,
+			"error":          err.Error(),
+		}).Error("Failed to retrieve payments")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve payments"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"transaction_id": transaction.TransactionID,
+	}).Info("Payments retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction_id":              transaction.TransactionID,
+		"transaction_contract_number": transaction.TransactionContractNumber,
+		"payments":                    payments,
+	})
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type PricingHandler struct {
+	usecase usecase.PricingUsecase
+}
+
+func NewPricingHandler(usecase usecase.PricingUsecase) *PricingHandler {
+	return &PricingHandler{usecase: usecase}
+}
+
+func (h *PricingHandler) CreatePricingRule(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to CreatePricingRule")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	var input domain.PricingRuleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for creating pricing rule")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.usecase.CreatePricingRule(authUserModel.UserID, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id": authUserModel.UserID,
+			"error":   err.Error(),
+		}).Error("Failed to create pricing rule")
+		if errors.Is(err, usecase.ErrInvalidOTRRange) || errors.Is(err, usecase.ErrTenorNotOffered) || errors.Is(err, usecase.ErrPricingProductNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create pricing rule"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":         authUserModel.UserID,
+		"pricing_rule_id": rule.PricingRuleID,
+	}).Infof("Pricing rule %d created successfully by User %d", rule.PricingRuleID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Pricing rule created successfully", "pricing_rule": rule})
+}
+
+func (h *PricingHandler) GetPricingRule(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetPricingRule")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		utils.Logger.Warn("Invalid limit value in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page value"})
+		return
+	}
+
+	offset := (page - 1) * limit
+
+	rules, err := h.usecase.GetAllPricingRules(limit, offset)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit":  limit,
+			"offset": offset,
+			"error":  err.Error(),
+		}).Error("Failed to retrieve pricing rules")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve pricing rules"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"page":  page,
+		"limit": limit,
+	}).Info("Pricing rules retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":          page,
+		"limit":         limit,
+		"pricing_rules": rules,
+	})
+}
+
+func (h *PricingHandler) GetPricingRuleByID(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetPricingRuleByID")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid pricing rule ID in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pricing rule ID"})
+		return
+	}
+
+	rule, err := h.usecase.GetPricingRuleByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"pricing_rule_id": id,
+			"error":           err.Error(),
+		}).Warn("Pricing rule not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pricing rule not found"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"pricing_rule_id": rule.PricingRuleID,
+	}).Info("Pricing rule retrieved successfully")
+
+	c.JSON(http.StatusOK, rule)
+}
+
+func (h *PricingHandler) UpdatePricingRule(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to UpdatePricingRule")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid pricing rule ID provided for update")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid pricing rule ID"})
+		return
+	}
+
+	rule, err := h.usecase.GetPricingRuleByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"pricing_rule_id": id,
+			"error":           err.Error(),
+		}).Warn("Pricing rule not found for update")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Pricing rule not found"})
+		return
+	}
+
+	var input domain.PricingRuleInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for updating pricing rule")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.usecase.UpdatePricingRule(authUserModel.UserID, rule, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":         authUserModel.UserID,
+			"pricing_rule_id": rule.PricingRuleID,
+			"error":           err.Error(),
+		}).Error("Failed to update pricing rule")
+		if errors.Is(err, usecase.ErrInvalidOTRRange) || errors.Is(err, usecase.ErrTenorNotOffered) || errors.Is(err, usecase.ErrPricingProductNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update pricing rule"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":         authUserModel.UserID,
+		"pricing_rule_id": rule.PricingRuleID,
+		"updated_at":      rule.PricingEditedAt,
+	}).Infof("Pricing rule %d updated successfully by User %d", rule.PricingRuleID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Pricing rule updated successfully", "pricing_rule": rule})
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ProductHandler struct {
+	usecase usecase.ProductUsecase
+}
+
+func NewProductHandler(usecase usecase.ProductUsecase) *ProductHandler {
+	return &ProductHandler{usecase: usecase}
+}
+
+func (h *ProductHandler) CreateProduct(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to CreateProduct")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	var input domain.ProductInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for creating product")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := h.usecase.CreateProduct(authUserModel.UserID, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id": authUserModel.UserID,
+			"error":   err.Error(),
+		}).Error("Failed to create product")
+		if errors.Is(err, usecase.ErrInvalidOTRRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrProductAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create product"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":      authUserModel.UserID,
+		"product_code": product.ProductCode,
+	}).Infof("Product %s created successfully by User %d", product.ProductCode, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Product created successfully", "product": product})
+}
+
+func (h *ProductHandler) GetProduct(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetProduct")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		utils.Logger.Warn("Invalid limit value in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page value"})
+		return
+	}
+
+	offset := (page - 1) * limit
+
+	products, err := h.usecase.GetAllProducts(limit, offset)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit":  limit,
+			"offset": offset,
+			"error":  err.Error(),
+		}).Error("Failed to retrieve products")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve products"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"page":  page,
+		"limit": limit,
+	}).Info("Products retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":     page,
+		"limit":    limit,
+		"products": products,
+	})
+}
+
+func (h *ProductHandler) GetProductByID(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetProductByID")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid product ID in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	product, err := h.usecase.GetProductByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Product not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"product_id": product.ProductID,
+	}).Info("Product retrieved successfully")
+
+	c.JSON(http.StatusOK, product)
+}
+
+func (h *ProductHandler) UpdateProduct(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to UpdateProduct")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid product ID provided for update")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	product, err := h.usecase.GetProductByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"product_id": id,
+			"error":      err.Error(),
+		}).Warn("Product not found for update")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	var input domain.ProductInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for updating product")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.usecase.UpdateProduct(authUserModel.UserID, product, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":    authUserModel.UserID,
+			"product_id": product.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to update product")
+		if errors.Is(err, usecase.ErrInvalidOTRRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrProductAlreadyExists) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update product"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":      authUserModel.UserID,
+		"product_code": product.ProductCode,
+		"updated_at":   product.ProductEditedAt,
+	}).Infof("Product %s updated successfully by User %d", product.ProductCode, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Product updated successfully", "product": product})
+}
@@ -0,0 +1,248 @@
+package handler
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type ScreeningHandler struct {
+	usecase usecase.ScreeningUsecase
+}
+
+func NewScreeningHandler(usecase usecase.ScreeningUsecase) *ScreeningHandler {
+	return &ScreeningHandler{usecase: usecase}
+}
+
+func (h *ScreeningHandler) CreateNegativeListEntry(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to CreateNegativeListEntry")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	var input domain.NegativeListInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for creating negative list entry")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	entry, err := h.usecase.CreateNegativeListEntry(authUserModel.UserID, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id": authUserModel.UserID,
+			"error":   err.Error(),
+		}).Error("Failed to create negative list entry")
+		if errors.Is(err, usecase.ErrInvalidNegativeListValue) || errors.Is(err, usecase.ErrNegativeListExpired) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create negative list entry"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":          authUserModel.UserID,
+		"negative_list_id": entry.NegativeListID,
+	}).Infof("Negative list entry %d created successfully by User %d", entry.NegativeListID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Negative list entry created successfully", "negative_list_entry": entry})
+}
+
+func (h *ScreeningHandler) GetNegativeListEntry(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to GetNegativeListEntry")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		utils.Logger.Warn("Invalid limit value in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page value"})
+		return
+	}
+
+	offset := (page - 1) * limit
+
+	entries, err := h.usecase.GetAllNegativeListEntries(limit, offset)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit":  limit,
+			"offset": offset,
+			"error":  err.Error(),
+		}).Error("Failed to retrieve negative list entries")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve negative list entries"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"page":  page,
+		"limit": limit,
+	}).Info("Negative list entries retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":                  page,
+		"limit":                 limit,
+		"negative_list_entries": entries,
+	})
+}
+
+func (h *ScreeningHandler) GetNegativeListEntryByID(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to GetNegativeListEntryByID")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid negative list ID in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid negative list ID"})
+		return
+	}
+
+	entry, err := h.usecase.GetNegativeListEntryByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"negative_list_id": id,
+			"error":            err.Error(),
+		}).Warn("Negative list entry not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Negative list entry not found"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"negative_list_id": entry.NegativeListID,
+	}).Info("Negative list entry retrieved successfully")
+
+	c.JSON(http.StatusOK, entry)
+}
+
+func (h *ScreeningHandler) UpdateNegativeListEntry(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to UpdateNegativeListEntry")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	authUserModel := authUser.(domain.User)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid negative list ID provided for update")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid negative list ID"})
+		return
+	}
+
+	entry, err := h.usecase.GetNegativeListEntryByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"negative_list_id": id,
+			"error":            err.Error(),
+		}).Warn("Negative list entry not found for update")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Negative list entry not found"})
+		return
+	}
+
+	var input domain.NegativeListInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for updating negative list entry")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.usecase.UpdateNegativeListEntry(authUserModel.UserID, entry, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":          authUserModel.UserID,
+			"negative_list_id": entry.NegativeListID,
+			"error":            err.Error(),
+		}).Error("Failed to update negative list entry")
+		if errors.Is(err, usecase.ErrInvalidNegativeListValue) || errors.Is(err, usecase.ErrNegativeListExpired) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update negative list entry"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":          authUserModel.UserID,
+		"negative_list_id": entry.NegativeListID,
+		"updated_at":       entry.NegativeListEditedAt,
+	}).Infof("Negative list entry %d updated successfully by User %d", entry.NegativeListID, authUserModel.UserID)
+	c.JSON(http.StatusOK, gin.H{"message": "Negative list entry updated successfully", "negative_list_entry": entry})
+}
+
+func (h *ScreeningHandler) GetCustomerScreening(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetCustomerScreening")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid customer ID in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid customer ID"})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"error":       err.Error(),
+		}).Warn("Customer not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer not found"})
+		return
+	}
+
+	result, err := h.usecase.ScreenCustomer(customer)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": id,
+			"error":       err.Error(),
+		}).Error("Failed to screen customer")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to screen customer"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_id": id,
+		"decision":    result.Decision,
+		"hits":        len(result.Hits),
+	}).Info("Customer screening retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{"customer_id": id, "screening": result})
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type SettlementHandler struct {
+	usecase usecase.SettlementUsecase
+}
+
+func NewSettlementHandler(usecase usecase.SettlementUsecase) *SettlementHandler {
+	return &SettlementHandler{usecase: usecase}
+}
+
+func (h *SettlementHandler) GetPayoffQuote(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetPayoffQuote")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid transaction ID in payoff quote request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	asOf := time.Now()
+	if value := c.Query("as_of"); value != "" {
+		asOf, err = time.Parse("2006-01-02", value)
+		if err != nil {
+			utils.Logger.Warnf("Invalid as_of date in payoff quote request: %s", value)
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid as_of date, expected YYYY-MM-DD"})
+			return
+		}
+	}
+
+	transaction, err := h.usecase.GetTransactionByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": id,
+			"error":          err.Error(),
+		}).Warn("Transaction not found for payoff quote")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	quote, err := h.usecase.GetPayoffQuote(transaction, asOf)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to calculate payoff quote")
+		if errors.Is(err, usecase.ErrInvalidPayoffDate) || errors.Is(err, usecase.ErrNoOutstandingInstallments) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrTransactionNotActive) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to calculate payoff quote"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"transaction_contract_number": transaction.TransactionContractNumber,
+		"payoff_total_amount":         quote.PayoffTotalAmount,
+	}).Info("Payoff quote calculated successfully")
+
+	c.JSON(http.StatusOK, gin.H{"payoff_quote": quote})
+}
+
+func (h *SettlementHandler) SettleTransaction(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to SettleTransaction")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid transaction ID provided for settlement")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	transaction, err := h.usecase.GetTransactionByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": id,
+			"error":          err.Error(),
+		}).Warn("Transaction not found for settlement")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	var input domain.SettlementInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for settling transaction")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	payment, err := h.usecase.SettleTransaction(authUser.(domain.User).UserID, transaction, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                     authUser.(domain.User).UserID,
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to settle transaction")
+		if errors.Is(err, usecase.ErrNoOutstandingInstallments) || errors.Is(err, usecase.ErrSettlementAmountMismatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrTransactionNotActive) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to settle transaction"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":                     authUser.(domain.User).UserID,
+		"transaction_contract_number": transaction.TransactionContractNumber,
+		"payment_amount":              payment.PaymentAmount,
+	}).Infof("Contract Number %s settled early by User %d", transaction.TransactionContractNumber, authUser.(domain.User).UserID)
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Transaction settled successfully",
+		"payment": payment,
+	})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"kreditplus/internal/domain"
 	"kreditplus/internal/usecase"
 	"kreditplus/internal/utils"
@@ -41,6 +42,16 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
+	if input.OverridesPricing() && !authUser.(domain.User).UserCanOverridePricing {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":               authUser.(domain.User).UserID,
+			"transaction_admin_fee": input.TransactionAdminFee,
+			"transaction_interest":  input.TransactionInterest,
+		}).Warn("Pricing override attempted without permission")
+		c.JSON(http.StatusForbidden, gin.H{"error": usecase.ErrPricingOverrideNotAllowed.Error()})
+		return
+	}
+
 	customer, err := h.usecase.GetCustomerByNIK(input.TransactionNIK)
 	if err != nil {
 		utils.Logger.WithFields(logrus.Fields{
@@ -51,12 +62,37 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 		return
 	}
 
-	err = h.usecase.CreateTransactionWithLimitUpdate(authUser.(domain.User).UserID, customer, input)
+	product, err := h.usecase.GetProductByCode(input.TransactionProduct)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_product": input.TransactionProduct,
+			"error":               err.Error(),
+		}).Warn("Product not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	err = h.usecase.CreateTransactionWithLimitUpdate(authUser.(domain.User).UserID, customer, product, input)
 	if err != nil {
 		utils.Logger.WithFields(logrus.Fields{
 			"user_id": authUser.(domain.User).UserID,
 			"error":   err.Error(),
 		}).Error("Failed to create transaction")
+		var affordabilityErr *usecase.AffordabilityError
+		if errors.As(err, &affordabilityErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "rejection": affordabilityErr.Rejection})
+			return
+		}
+		var screeningErr *usecase.ScreeningError
+		if errors.As(err, &screeningErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "screening": screeningErr.Result})
+			return
+		}
+		if errors.Is(err, usecase.ErrCustomerNotVerified) || errors.Is(err, usecase.ErrInsufficientLimit) || errors.Is(err, usecase.ErrCustomerLimitExceeded) ||
+			errors.Is(err, usecase.ErrProductInactive) || errors.Is(err, usecase.ErrTenorNotOffered) || errors.Is(err, usecase.ErrOTROutOfRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create transaction"})
 		return
 	}
@@ -69,6 +105,80 @@ func (h *TransactionHandler) CreateTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Transaction created successfully"})
 }
 
+func (h *TransactionHandler) SimulateTransaction(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to SimulateTransaction")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	var input domain.TransactionInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for simulating transaction")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input.OverridesPricing() && !authUser.(domain.User).UserCanOverridePricing {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":               authUser.(domain.User).UserID,
+			"transaction_admin_fee": input.TransactionAdminFee,
+			"transaction_interest":  input.TransactionInterest,
+		}).Warn("Pricing override attempted without permission")
+		c.JSON(http.StatusForbidden, gin.H{"error": usecase.ErrPricingOverrideNotAllowed.Error()})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByNIK(input.TransactionNIK)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_nik": input.TransactionNIK,
+			"error":           err.Error(),
+		}).Warn("Customer not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Customer NIK not found"})
+		return
+	}
+
+	product, err := h.usecase.GetProductByCode(input.TransactionProduct)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_product": input.TransactionProduct,
+			"error":               err.Error(),
+		}).Warn("Product not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	simulations, err := h.usecase.SimulateTransaction(customer, product, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_nik": customer.CustomerNIK,
+			"error":           err.Error(),
+		}).Error("Failed to simulate transaction")
+		if errors.Is(err, usecase.ErrProductInactive) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to simulate transaction"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction_nik":        customer.CustomerNIK,
+		"transaction_product":    product.ProductCode,
+		"transaction_otr":        input.TransactionOTR,
+		"transaction_asset_name": input.TransactionAssetName,
+		"simulations":            simulations,
+	})
+}
+
 func (h *TransactionHandler) GetTransaction(c *gin.Context) {
 	_, exists := c.Get("user")
 	if !exists {
@@ -116,6 +226,84 @@ func (h *TransactionHandler) GetTransaction(c *gin.Context) {
 	})
 }
 
+func (h *TransactionHandler) GetDelinquentTransactions(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetDelinquentTransactions")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit <= 0 {
+		utils.Logger.Warn("Invalid limit value in request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+		return
+	}
+
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page value"})
+		return
+	}
+
+	minDaysPastDue, err := strconv.Atoi(c.DefaultQuery("min_dpd", "0"))
+	if err != nil || minDaysPastDue < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid min_dpd value"})
+		return
+	}
+
+	collectibility := c.Query("bucket")
+	if collectibility != "" && collectibility != domain.Collectibility1To30 && collectibility != domain.Collectibility31To60 &&
+		collectibility != domain.Collectibility61To90 && collectibility != domain.CollectibilityOver90 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bucket value"})
+		return
+	}
+
+	offset := (page - 1) * limit
+
+	transactions, err := h.usecase.GetDelinquentTransactions(collectibility, minDaysPastDue, limit, offset)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"bucket":  collectibility,
+			"min_dpd": minDaysPastDue,
+			"error":   err.Error(),
+		}).Error("Failed to retrieve delinquent transactions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve delinquent transactions"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"page":    page,
+		"limit":   limit,
+		"bucket":  collectibility,
+		"min_dpd": minDaysPastDue,
+	}).Info("Delinquent transactions retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"page":         page,
+		"limit":        limit,
+		"transactions": transactions,
+	})
+}
+
+func (h *TransactionHandler) GetTransactionRetryMetrics(c *gin.Context) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warn("Unauthorized access attempt to GetTransactionRetryMetrics")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	metrics := h.usecase.GetTransactionRetryMetrics()
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id": authUser.(domain.User).UserID,
+	}).Info("Transaction retry metrics retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{"retry_metrics": metrics})
+}
+
 func (h *TransactionHandler) GetTransactionByID(c *gin.Context) {
 	_, exists := c.Get("user")
 	if !exists {
@@ -154,12 +342,22 @@ func (h *TransactionHandler) GetTransactionByID(c *gin.Context) {
 			LimitID:     transaction.IDLimit.LimitID,
 			LimitAmount: transaction.IDLimit.LimitAmount,
 		},
-		TransactionOTR:         transaction.TransactionOTR,
-		TransactionAdminFee:    transaction.TransactionAdminFee,
-		TransactionInstallment: transaction.TransactionInstallment,
-		TransactionInterest:    transaction.TransactionInterest,
-		TransactionAssetName:   transaction.TransactionAssetName,
-		TransactionCreatedBy:   transaction.TransactionCreatedBy,
+		TransactionProductID:       transaction.TransactionProductID,
+		TransactionPricingRuleID:   transaction.TransactionPricingRuleID,
+		TransactionPricingOverride: transaction.TransactionPricingOverride,
+		TransactionOTR:             transaction.TransactionOTR,
+		TransactionAdminFee:        transaction.TransactionAdminFee,
+		TransactionInstallment:     transaction.TransactionInstallment,
+		TransactionInterest:        transaction.TransactionInterest,
+		TransactionAssetName:       transaction.TransactionAssetName,
+		TransactionDaysPastDue:     transaction.TransactionDaysPastDue,
+		TransactionCollectibility:  transaction.TransactionCollectibility,
+		TransactionStatus:          transaction.TransactionStatus,
+		TransactionScheme:          transaction.TransactionScheme,
+		TransactionParentID:        transaction.TransactionParentID,
+		TransactionVersion:         transaction.TransactionVersion,
+		TransactionOriginalNumber:  transaction.TransactionOriginalNumber,
+		TransactionCreatedBy:       transaction.TransactionCreatedBy,
 		CreatedByUser: domain.UserResponse{
 			UserID:       transaction.CreatedByUser.UserID,
 			UserUsername: transaction.CreatedByUser.UserUsername,
@@ -174,6 +372,52 @@ func (h *TransactionHandler) GetTransactionByID(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+func (h *TransactionHandler) GetTransactionSchedule(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetTransactionSchedule")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid transaction ID in schedule request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	transaction, err := h.usecase.GetTransactionByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": id,
+			"error":          err.Error(),
+		}).Warn("Transaction not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	installments, err := h.usecase.GetTransactionSchedule(transaction.TransactionID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": transaction.TransactionID,
+			"error":          err.Error(),
+		}).Error("Failed to retrieve installment schedule")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve installment schedule"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"transaction_id": transaction.TransactionID,
+	}).Info("Installment schedule retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction_id":              transaction.TransactionID,
+		"transaction_contract_number": transaction.TransactionContractNumber,
+		"installments":                installments,
+	})
+}
+
 func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
 	authUser, exists := c.Get("user")
 	if !exists || authUser.(domain.User).UserRole != "admin" {
@@ -212,22 +456,66 @@ func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
 		return
 	}
 
-	customer, err := h.usecase.GetCustomerByNIK(input.TransactionNIK)
-	if err != nil {
+	if input.OverridesPricing() && !authUser.(domain.User).UserCanOverridePricing {
 		utils.Logger.WithFields(logrus.Fields{
+			"user_id":               authUser.(domain.User).UserID,
+			"transaction_admin_fee": input.TransactionAdminFee,
+			"transaction_interest":  input.TransactionInterest,
+		}).Warn("Pricing override attempted without permission")
+		c.JSON(http.StatusForbidden, gin.H{"error": usecase.ErrPricingOverrideNotAllowed.Error()})
+		return
+	}
+
+	if input.TransactionNIK != transaction.TransactionNIK {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id":  transaction.TransactionID,
 			"transaction_nik": input.TransactionNIK,
+		}).Warn("Transaction amendment carries a different NIK")
+		c.JSON(http.StatusBadRequest, gin.H{"error": usecase.ErrTransactionNIKMismatch.Error()})
+		return
+	}
+
+	customer, err := h.usecase.GetCustomerByNIK(transaction.TransactionNIK)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_nik": transaction.TransactionNIK,
 			"error":           err.Error(),
 		}).Warn("Customer not found")
 		c.JSON(http.StatusNotFound, gin.H{"error": "Customer NIK not found"})
 		return
 	}
 
-	err = h.usecase.UpdateTransactionWithLimitUpdate(authUser.(domain.User).UserID, customer, transaction, input)
+	product, err := h.usecase.GetProductByCode(input.TransactionProduct)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_product": input.TransactionProduct,
+			"error":               err.Error(),
+		}).Warn("Product not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Product not found"})
+		return
+	}
+
+	err = h.usecase.UpdateTransactionWithLimitUpdate(authUser.(domain.User).UserID, customer, product, transaction, input)
 	if err != nil {
 		utils.Logger.WithFields(logrus.Fields{
 			"user_id": authUser.(domain.User).UserID,
 			"error":   err.Error(),
 		}).Error("Failed to update transaction")
+		var affordabilityErr *usecase.AffordabilityError
+		if errors.As(err, &affordabilityErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "rejection": affordabilityErr.Rejection})
+			return
+		}
+		if errors.Is(err, usecase.ErrTransactionHasPayments) || errors.Is(err, usecase.ErrTransactionNotEditable) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrInsufficientLimit) || errors.Is(err, usecase.ErrCustomerLimitExceeded) ||
+			errors.Is(err, usecase.ErrProductInactive) || errors.Is(err, usecase.ErrTenorNotOffered) || errors.Is(err, usecase.ErrOTROutOfRange) ||
+			errors.Is(err, usecase.ErrTransactionNIKMismatch) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update transaction"})
 		return
 	}
@@ -240,18 +528,212 @@ func (h *TransactionHandler) UpdateTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Transaction edited successfully"})
 }
 
-func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
+func (h *TransactionHandler) GetTransactionStatusHistory(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetTransactionStatusHistory")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid transaction ID in status history request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	transaction, err := h.usecase.GetTransactionByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": id,
+			"error":          err.Error(),
+		}).Warn("Transaction not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	histories, err := h.usecase.GetTransactionStatusHistory(transaction.TransactionID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": transaction.TransactionID,
+			"error":          err.Error(),
+		}).Error("Failed to retrieve transaction status history")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve transaction status history"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"transaction_id": transaction.TransactionID,
+	}).Info("Transaction status history retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction_id":              transaction.TransactionID,
+		"transaction_contract_number": transaction.TransactionContractNumber,
+		"transaction_status":          transaction.TransactionStatus,
+		"history":                     histories,
+	})
+}
+
+func (h *TransactionHandler) GetTransactionVersions(c *gin.Context) {
+	_, exists := c.Get("user")
+	if !exists {
+		utils.Logger.Warn("Unauthorized access attempt to GetTransactionVersions")
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid transaction ID in versions request")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	transaction, err := h.usecase.GetTransactionByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": id,
+			"error":          err.Error(),
+		}).Warn("Transaction not found")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	versions, restructures, err := h.usecase.GetTransactionVersions(transaction)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": transaction.TransactionID,
+			"error":          err.Error(),
+		}).Error("Failed to retrieve transaction versions")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve transaction versions"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"transaction_id": transaction.TransactionID,
+	}).Info("Transaction versions retrieved successfully")
+
+	c.JSON(http.StatusOK, gin.H{
+		"transaction_id":              transaction.TransactionID,
+		"transaction_original_number": transaction.TransactionOriginalNumber,
+		"versions":                    versions,
+		"restructures":                restructures,
+	})
+}
+
+func (h *TransactionHandler) RestructureTransaction(c *gin.Context) {
 	authUser, exists := c.Get("user")
 	if !exists || authUser.(domain.User).UserRole != "admin" {
-		utils.Logger.Warn("Unauthorized access attempt to DeleteTransaction")
+		utils.Logger.Warn("Unauthorized access attempt to RestructureTransaction")
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
 		return
 	}
 
 	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil || id <= 0 {
-		utils.Logger.Warn("Invalid user ID provided for delete")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transasction ID"})
+		utils.Logger.Warn("Invalid transaction ID provided for restructuring")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
+		return
+	}
+
+	transaction, err := h.usecase.GetTransactionByID(uint(id))
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id": id,
+			"error":          err.Error(),
+		}).Warn("Transaction not found for restructuring")
+		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
+		return
+	}
+
+	var input domain.TransactionRestructureInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		utils.Logger.Warn("Invalid request format for restructuring transaction")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+		return
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input.OverridesPricing() && !authUser.(domain.User).UserCanOverridePricing {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":              authUser.(domain.User).UserID,
+			"restructure_interest": input.RestructureInterest,
+		}).Warn("Pricing override attempted without permission")
+		c.JSON(http.StatusForbidden, gin.H{"error": usecase.ErrPricingOverrideNotAllowed.Error()})
+		return
+	}
+
+	restructured, err := h.usecase.RestructureTransaction(authUser.(domain.User).UserID, transaction, input)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                     authUser.(domain.User).UserID,
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to restructure transaction")
+		var affordabilityErr *usecase.AffordabilityError
+		if errors.As(err, &affordabilityErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "rejection": affordabilityErr.Rejection})
+			return
+		}
+		if errors.Is(err, usecase.ErrRestructureHasArrears) || errors.Is(err, usecase.ErrInvalidRestructureTenor) ||
+			errors.Is(err, usecase.ErrInsufficientLimit) || errors.Is(err, usecase.ErrCustomerLimitExceeded) ||
+			errors.Is(err, usecase.ErrNoOutstandingInstallments) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrTransactionNotActive) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to restructure transaction"})
+		return
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":                      authUser.(domain.User).UserID,
+		"transaction_contract_number":  transaction.TransactionContractNumber,
+		"restructured_contract_number": restructured.TransactionContractNumber,
+	}).Infof("Transaction Contract Number %s restructured into %s by User %d", transaction.TransactionContractNumber, restructured.TransactionContractNumber, authUser.(domain.User).UserID)
+	c.JSON(http.StatusOK, gin.H{
+		"message":     "Transaction restructured successfully",
+		"transaction": restructured,
+	})
+}
+
+func (h *TransactionHandler) ActivateTransaction(c *gin.Context) {
+	h.transitionTransaction(c, domain.TransactionStatusActive, "Transaction activated successfully")
+}
+
+func (h *TransactionHandler) CancelTransaction(c *gin.Context) {
+	h.transitionTransaction(c, domain.TransactionStatusCancelled, "Transaction cancelled successfully")
+}
+
+func (h *TransactionHandler) PayOffTransaction(c *gin.Context) {
+	h.transitionTransaction(c, domain.TransactionStatusPaidOff, "Transaction marked as paid off successfully")
+}
+
+func (h *TransactionHandler) WriteOffTransaction(c *gin.Context) {
+	h.transitionTransaction(c, domain.TransactionStatusWrittenOff, "Transaction written off successfully")
+}
+
+func (h *TransactionHandler) transitionTransaction(c *gin.Context, toStatus string, message string) {
+	authUser, exists := c.Get("user")
+	if !exists || authUser.(domain.User).UserRole != "admin" {
+		utils.Logger.Warnf("Unauthorized access attempt to move transaction to %s", toStatus)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		utils.Logger.Warn("Invalid transaction ID provided for status transition")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid transaction ID"})
 		return
 	}
 
@@ -260,25 +742,58 @@ func (h *TransactionHandler) DeleteTransaction(c *gin.Context) {
 		utils.Logger.WithFields(logrus.Fields{
 			"transaction_id": id,
 			"error":          err.Error(),
-		}).Warn("Transaction not found for delete")
+		}).Warn("Transaction not found for status transition")
 		c.JSON(http.StatusNotFound, gin.H{"error": "Transaction not found"})
 		return
 	}
 
-	err = h.usecase.DeleteTransactionWithLimitUpdate(authUser.(domain.User).UserID, transaction)
+	var input domain.TransactionTransitionInput
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&input); err != nil {
+			utils.Logger.Warn("Invalid request format for transaction status transition")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request format"})
+			return
+		}
+	}
+
+	if err := utils.Validate.Struct(input); err != nil {
+		utils.Logger.Warnf("Validation error: %s", err.Error())
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fromStatus := transaction.TransactionStatus
+	err = h.usecase.TransitionTransactionStatus(authUser.(domain.User).UserID, transaction, toStatus, input.Reason)
 	if err != nil {
 		utils.Logger.WithFields(logrus.Fields{
 			"user_id":                     authUser.(domain.User).UserID,
 			"transaction_contract_number": transaction.TransactionContractNumber,
+			"to_status":                   toStatus,
 			"error":                       err.Error(),
-		}).Error("Failed to delete transaction")
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete transaction"})
+		}).Error("Failed to change transaction status")
+		var affordabilityErr *usecase.AffordabilityError
+		if errors.As(err, &affordabilityErr) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error(), "rejection": affordabilityErr.Rejection})
+			return
+		}
+		if errors.Is(err, usecase.ErrInvalidStatusTransition) || errors.Is(err, usecase.ErrTransactionHasPayments) ||
+			errors.Is(err, usecase.ErrTransactionHasOutstandingBalance) {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, usecase.ErrInsufficientLimit) || errors.Is(err, usecase.ErrCustomerLimitExceeded) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to change transaction status"})
 		return
 	}
 
 	utils.Logger.WithFields(logrus.Fields{
 		"user_id":                     authUser.(domain.User).UserID,
 		"transaction_contract_number": transaction.TransactionContractNumber,
-	}).Infof("Transaction Contract Number %s deleted successfully by User %d", transaction.TransactionContractNumber, authUser.(domain.User).UserID)
-	c.JSON(http.StatusOK, gin.H{"message": "Transaction deleted successfully"})
+		"from_status":                 fromStatus,
+		"to_status":                   toStatus,
+	}).Infof("Transaction Contract Number %s moved to %s by User %d", transaction.TransactionContractNumber, toStatus, authUser.(domain.User).UserID)
+	c.JSON(http.StatusOK, gin.H{"message": message})
 }
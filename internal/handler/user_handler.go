@@ -54,6 +54,9 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		UserPassword: string(hashedPassword),
 		UserRole:     input.UserRole,
 	}
+	if input.UserCanOverridePricing != nil {
+		user.UserCanOverridePricing = *input.UserCanOverridePricing
+	}
 
 	err = h.usecase.CreateUser(user)
 	if err != nil {
@@ -231,6 +234,16 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		}
 	}
 
+	if input.UserCanOverridePricing != nil {
+		if authUser.(domain.User).UserRole == "admin" {
+			user.UserCanOverridePricing = *input.UserCanOverridePricing
+		} else {
+			utils.Logger.Warn("You cannot change your own pricing permission")
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden: You cannot change your own pricing permission"})
+			return
+		}
+	}
+
 	err = h.usecase.UpdateUser(*user)
 	if err != nil {
 		utils.Logger.WithFields(logrus.Fields{
@@ -0,0 +1,69 @@
+package handler_test
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetLimitRecommendation_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	scoringUsecase := new(mocks.CreditScoringUsecase)
+	scoringHandler := handler.NewCreditScoringHandler(scoringUsecase)
+
+	router.GET("/customers/:id/limit-recommendation", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		scoringHandler.GetLimitRecommendation(c)
+	})
+
+	customer := &domain.Customer{CustomerID: 1, CustomerNIK: "1234567890123456"}
+	scoringUsecase.On("GetCustomerByID", uint(1)).Return(customer, nil)
+	scoringUsecase.On("RecommendLimit", customer).Return(&domain.LimitRecommendation{
+		CustomerID:      1,
+		CustomerNIK:     "1234567890123456",
+		Score:           85,
+		RiskGrade:       domain.RiskGradeA,
+		Recommendations: []domain.TenorLimitRecommendation{{Tenor: 3, RecommendedAmount: money.New(9000000)}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/1/limit-recommendation", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"risk_grade":"A"`)
+	assert.Contains(t, w.Body.String(), `{"tenor":3,"recommended_amount":"9000000.00"}`)
+}
+
+func TestGetLimitRecommendation_CustomerNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	scoringUsecase := new(mocks.CreditScoringUsecase)
+	scoringHandler := handler.NewCreditScoringHandler(scoringUsecase)
+
+	router.GET("/customers/:id/limit-recommendation", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		scoringHandler.GetLimitRecommendation(c)
+	})
+
+	scoringUsecase.On("GetCustomerByID", uint(99)).Return(nil, errors.New("record not found"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/99/limit-recommendation", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+	scoringUsecase.AssertNotCalled(t, "RecommendLimit", mock.Anything)
+}
@@ -7,6 +7,9 @@ import (
 	"io"
 	"kreditplus/internal/domain"
 	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/nik"
+	"kreditplus/internal/usecase"
 	"kreditplus/internal/usecase/mocks"
 	"mime/multipart"
 	"net/http"
@@ -104,7 +107,7 @@ func TestCreateCustomer_Success(t *testing.T) {
 	req, _ := http.NewRequest("POST", "/customers", body)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	customerUsecase.On("CreateCustomer", mock.Anything).Return(nil)
+	customerUsecase.On("CreateCustomer", mock.Anything).Return(nil, nil)
 
 	router.ServeHTTP(w, req)
 
@@ -265,7 +268,7 @@ func TestCreateCustomer_DBError(t *testing.T) {
 	req, _ := http.NewRequest("POST", "/customers", body)
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 
-	customerUsecase.On("CreateCustomer", mock.Anything).Return(errors.New("database error"))
+	customerUsecase.On("CreateCustomer", mock.Anything).Return(nil, errors.New("database error"))
 
 	router.ServeHTTP(w, req)
 
@@ -381,7 +384,7 @@ func TestGetCustomerByID_Success(t *testing.T) {
 		CustomerLegalName:  "John D",
 		CustomerBirthPlace: "Jakarta",
 		CustomerBirthDate:  time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
-		CustomerSalary:     1000000,
+		CustomerSalary:     money.New(1000000),
 	}, nil)
 
 	router.ServeHTTP(w, req)
@@ -483,6 +486,100 @@ func TestUpdateCustomer_Success(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"message":"Customer updated successfully"`)
 }
 
+func TestUpdateCustomer_IdentityChangeResetsKYC(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.PUT("/customers/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		customerHandler.UpdateCustomer(c)
+	})
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("customer_nik", "1234567890123456")
+	_ = writer.WriteField("customer_full_name", "Someone Else")
+	_ = writer.WriteField("customer_legal_name", "Original Legal Name")
+	_ = writer.WriteField("customer_birth_place", "Jakarta")
+	_ = writer.WriteField("customer_birth_date", "1990-01-01")
+	_ = writer.WriteField("customer_salary", "2000000")
+	writer.Close()
+
+	req, _ := http.NewRequest("PUT", "/customers/1", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	reviewer := uint(2)
+	reviewedAt := time.Now().AddDate(0, 0, -1)
+	customerUsecase.On("GetCustomerByID", uint(1)).Return(&domain.Customer{
+		CustomerNIK:           "1234567890123456",
+		CustomerFullName:      "Original Name",
+		CustomerLegalName:     "Original Legal Name",
+		CustomerBirthPlace:    "Jakarta",
+		CustomerBirthDate:     time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+		CustomerKYCStatus:     domain.CustomerKYCVerified,
+		CustomerKYCReviewedBy: &reviewer,
+		CustomerKYCReviewedAt: &reviewedAt,
+	}, nil)
+	customerUsecase.On("UpdateCustomer", mock.MatchedBy(func(customer domain.Customer) bool {
+		return customer.CustomerKYCStatus == domain.CustomerKYCPending &&
+			customer.CustomerKYCReviewedBy == nil && customer.CustomerKYCReviewedAt == nil
+	})).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	customerUsecase.AssertExpectations(t)
+}
+
+func TestUpdateCustomer_ContactChangeKeepsKYC(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.PUT("/customers/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		customerHandler.UpdateCustomer(c)
+	})
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("customer_nik", "1234567890123456")
+	_ = writer.WriteField("customer_full_name", "Original Name")
+	_ = writer.WriteField("customer_legal_name", "Original Legal Name")
+	_ = writer.WriteField("customer_birth_place", "Jakarta")
+	_ = writer.WriteField("customer_birth_date", "1990-01-01")
+	_ = writer.WriteField("customer_salary", "3000000")
+	writer.Close()
+
+	req, _ := http.NewRequest("PUT", "/customers/1", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	customerUsecase.On("GetCustomerByID", uint(1)).Return(&domain.Customer{
+		CustomerNIK:        "1234567890123456",
+		CustomerFullName:   "Original Name",
+		CustomerLegalName:  "Original Legal Name",
+		CustomerBirthPlace: "Jakarta",
+		CustomerBirthDate:  time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC),
+		CustomerSalary:     money.New(2000000),
+		CustomerKYCStatus:  domain.CustomerKYCVerified,
+	}, nil)
+	customerUsecase.On("UpdateCustomer", mock.MatchedBy(func(customer domain.Customer) bool {
+		return customer.CustomerKYCStatus == domain.CustomerKYCVerified
+	})).Return(nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	customerUsecase.AssertExpectations(t)
+}
+
 func TestUpdateCustomer_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
@@ -590,6 +687,52 @@ func TestUpdateCustomer_DBError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"error":"database error"`)
 }
 
+func TestUpdateCustomer_NIKMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.PUT("/customers/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		customerHandler.UpdateCustomer(c)
+	})
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	_ = writer.WriteField("customer_nik", "3171014509900001")
+	_ = writer.WriteField("customer_full_name", "Updated Name")
+	_ = writer.WriteField("customer_legal_name", "Legal Name")
+	_ = writer.WriteField("customer_birth_place", "Jakarta")
+	_ = writer.WriteField("customer_birth_date", "1990-01-01")
+	_ = writer.WriteField("customer_salary", "2000000")
+
+	writer.Close()
+
+	req, _ := http.NewRequest("PUT", "/customers/1", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	customerUsecase.On("GetCustomerByID", uint(1)).Return(&domain.Customer{
+		CustomerNIK: "3171014509900001",
+	}, nil)
+
+	customerUsecase.On("UpdateCustomer", mock.Anything).Return(&usecase.NIKValidationError{Mismatches: []nik.Mismatch{{
+		Field:    "customer_birth_date",
+		Expected: "1990-09-05",
+		Actual:   "1990-01-01",
+		Message:  "birth date does not match the date encoded in the NIK",
+	}}})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), `"field":"customer_birth_date"`)
+	assert.Contains(t, w.Body.String(), `"expected":"1990-09-05"`)
+}
+
 func TestUpdateCustomer_FailedFileUpload(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
@@ -707,3 +850,182 @@ func TestDeleteCustomer_DBError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code, "Expected HTTP 500 Internal Server Error")
 	assert.Contains(t, w.Body.String(), `"error":"Failed to delete customer"`)
 }
+
+func TestReviewCustomerKYC_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.PUT("/customers/:id/kyc", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		customerHandler.ReviewCustomerKYC(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/customers/1/kyc", bytes.NewBufferString(`{"customer_kyc_status": "verified"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	customer := &domain.Customer{CustomerID: 1, CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCPending}
+	customerUsecase.On("GetCustomerByID", uint(1)).Return(customer, nil)
+	customerUsecase.On("ReviewCustomerKYC", uint(1), customer, domain.CustomerKYCReviewInput{CustomerKYCStatus: domain.CustomerKYCVerified}).
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*domain.Customer).CustomerKYCStatus = domain.CustomerKYCVerified
+		}).
+		Return(nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"customer_kyc_status":"verified"`)
+}
+
+func TestReviewCustomerKYC_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.PUT("/customers/:id/kyc", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 2, UserRole: "user"})
+		customerHandler.ReviewCustomerKYC(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/customers/1/kyc", bytes.NewBufferString(`{"customer_kyc_status": "verified"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized")
+	customerUsecase.AssertNotCalled(t, "ReviewCustomerKYC", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestReviewCustomerKYC_InvalidTransition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.PUT("/customers/:id/kyc", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		customerHandler.ReviewCustomerKYC(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/customers/1/kyc", bytes.NewBufferString(`{"customer_kyc_status": "verified"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	customer := &domain.Customer{CustomerID: 1, CustomerKYCStatus: domain.CustomerKYCRejected}
+	customerUsecase.On("GetCustomerByID", uint(1)).Return(customer, nil)
+	customerUsecase.On("ReviewCustomerKYC", uint(1), customer, mock.Anything).Return(usecase.ErrInvalidKYCTransition)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict")
+	assert.Contains(t, w.Body.String(), usecase.ErrInvalidKYCTransition.Error())
+}
+
+func TestGetKYCReviewQueue_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.GET("/customers/kyc-queue", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		customerHandler.GetKYCReviewQueue(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/kyc-queue?status=needs_resubmission&page=2&limit=5", nil)
+
+	customerUsecase.On("GetKYCReviewQueue", domain.CustomerKYCNeedsResubmission, 5, 5).Return([]domain.Customer{
+		{CustomerID: 3, CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCNeedsResubmission},
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"kyc_status":"needs_resubmission"`)
+	assert.Contains(t, w.Body.String(), `"customer_id":3`)
+}
+
+func TestGetKYCReviewQueue_InvalidStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.GET("/customers/kyc-queue", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		customerHandler.GetKYCReviewQueue(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/kyc-queue?status=approved", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+}
+
+func TestGetSuspectedDuplicates_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.GET("/customers/:id/duplicates", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		customerHandler.GetSuspectedDuplicates(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/1/duplicates", nil)
+
+	customer := &domain.Customer{CustomerID: 1, CustomerNIK: "3171014509900001"}
+	customerUsecase.On("GetCustomerByID", uint(1)).Return(customer, nil)
+	customerUsecase.On("GetSuspectedDuplicates", customer).Return([]domain.SuspectedDuplicate{{
+		CustomerID:  5,
+		CustomerNIK: "3273014509900003",
+		Score:       80,
+		Blocking:    true,
+		Signals:     []domain.DuplicateSignal{{Signal: domain.DuplicateSignalNameBirthDate, Score: 50}},
+	}}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"customer_nik":"3273014509900003"`)
+	assert.Contains(t, w.Body.String(), `"signal":"name_birth_date"`)
+}
+
+func TestGetSuspectedDuplicates_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	customerUsecase := new(mocks.CustomerUsecase)
+	customerHandler := handler.NewCustomerHandler(customerUsecase)
+
+	router.GET("/customers/:id/duplicates", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		customerHandler.GetSuspectedDuplicates(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/99/duplicates", nil)
+
+	customerUsecase.On("GetCustomerByID", uint(99)).Return(nil, errors.New("record not found"))
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+	customerUsecase.AssertNotCalled(t, "GetSuspectedDuplicates", mock.Anything)
+}
@@ -0,0 +1,174 @@
+package handler_test
+
+import (
+	"bytes"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetCustomerProfile_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	profileUsecase := new(mocks.CustomerProfileUsecase)
+	profileHandler := handler.NewCustomerProfileHandler(profileUsecase)
+
+	router.GET("/customers/:id/profile", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "user"})
+		profileHandler.GetCustomerProfile(c)
+	})
+
+	profileUsecase.On("GetCustomerByID", uint(3)).Return(&domain.Customer{
+		CustomerID: 3,
+		CustomerPhones: []domain.CustomerPhoneNumber{
+			{PhoneID: 1, PhoneCustomerID: 3, PhoneNumber: "6281234567890", PhonePrimary: true},
+		},
+		CustomerEmployment: &domain.CustomerEmployment{EmploymentID: 1, EmploymentCustomerID: 3, EmploymentStatus: domain.EmploymentStatusEmployed},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/3/profile", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"phone_number":"6281234567890"`)
+	assert.Contains(t, w.Body.String(), `"employment_status":"employed"`)
+}
+
+func TestAddCustomerAddress_TypeExists(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	profileUsecase := new(mocks.CustomerProfileUsecase)
+	profileHandler := handler.NewCustomerProfileHandler(profileUsecase)
+
+	router.POST("/customers/:id/addresses", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "user"})
+		profileHandler.AddCustomerAddress(c)
+	})
+
+	customer := &domain.Customer{CustomerID: 3}
+	profileUsecase.On("GetCustomerByID", uint(3)).Return(customer, nil)
+	profileUsecase.On("AddCustomerAddress", uint(1), customer, mock.Anything).Return(nil, usecase.ErrCustomerAddressTypeExists)
+
+	body := `{
+		"address_type": "ktp",
+		"address_line": "Jl. Merdeka No. 1",
+		"address_city": "Jakarta Pusat",
+		"address_province": "DKI Jakarta"
+	}`
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/customers/3/addresses", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict")
+	assert.Contains(t, w.Body.String(), usecase.ErrCustomerAddressTypeExists.Error())
+}
+
+func TestAddCustomerAddress_InvalidType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	profileUsecase := new(mocks.CustomerProfileUsecase)
+	profileHandler := handler.NewCustomerProfileHandler(profileUsecase)
+
+	router.POST("/customers/:id/addresses", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "user"})
+		profileHandler.AddCustomerAddress(c)
+	})
+
+	profileUsecase.On("GetCustomerByID", uint(3)).Return(&domain.Customer{CustomerID: 3}, nil)
+
+	body := `{
+		"address_type": "office",
+		"address_line": "Jl. Merdeka No. 1",
+		"address_city": "Jakarta Pusat",
+		"address_province": "DKI Jakarta"
+	}`
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/customers/3/addresses", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	profileUsecase.AssertNotCalled(t, "AddCustomerAddress", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestVerifyCustomerPhone_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	profileUsecase := new(mocks.CustomerProfileUsecase)
+	profileHandler := handler.NewCustomerProfileHandler(profileUsecase)
+
+	router.PUT("/customers/:id/phones/:phoneId/verify", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 2, UserRole: "user"})
+		profileHandler.VerifyCustomerPhone(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/customers/3/phones/1/verify", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized")
+	profileUsecase.AssertNotCalled(t, "VerifyCustomerPhone", mock.Anything, mock.Anything)
+}
+
+func TestSaveCustomerEmployment_EmployerRequired(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	profileUsecase := new(mocks.CustomerProfileUsecase)
+	profileHandler := handler.NewCustomerProfileHandler(profileUsecase)
+
+	router.PUT("/customers/:id/employment", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "user"})
+		profileHandler.SaveCustomerEmployment(c)
+	})
+
+	customer := &domain.Customer{CustomerID: 3}
+	profileUsecase.On("GetCustomerByID", uint(3)).Return(customer, nil)
+	profileUsecase.On("SaveCustomerEmployment", uint(1), customer, mock.Anything).Return(nil, usecase.ErrEmployerRequired)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/customers/3/employment", bytes.NewBufferString(`{"employment_status": "employed"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrEmployerRequired.Error())
+}
+
+func TestDeleteCustomerEmployment_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	profileUsecase := new(mocks.CustomerProfileUsecase)
+	profileHandler := handler.NewCustomerProfileHandler(profileUsecase)
+
+	router.DELETE("/customers/:id/employment", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "user"})
+		profileHandler.DeleteCustomerEmployment(c)
+	})
+
+	profileUsecase.On("GetCustomerByID", uint(3)).Return(&domain.Customer{CustomerID: 3}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("DELETE", "/customers/3/employment", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+	profileUsecase.AssertNotCalled(t, "DeleteCustomerEmployment", mock.Anything)
+}
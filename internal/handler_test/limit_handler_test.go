@@ -5,6 +5,8 @@ import (
 	"errors"
 	"kreditplus/internal/domain"
 	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/usecase"
 	"kreditplus/internal/usecase/mocks"
 	"net/http"
 	"net/http/httptest"
@@ -20,7 +22,7 @@ func TestCreateLimit_Success(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.POST("/limits", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -50,7 +52,7 @@ func TestCreateLimit_Unauthorized(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.POST("/limits", limitHandler.CreateLimit)
 
@@ -68,7 +70,7 @@ func TestCreateLimit_ValidationError(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.POST("/limits", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -91,7 +93,7 @@ func TestCreateLimit_CustomerNotFound(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.POST("/limits", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -120,7 +122,7 @@ func TestCreateLimit_DBError(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.POST("/limits", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -150,7 +152,7 @@ func TestGetLimit_Success(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.GET("/limits", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -161,8 +163,8 @@ func TestGetLimit_Success(t *testing.T) {
 	req, _ := http.NewRequest("GET", "/limits?limit=10&page=1", nil)
 
 	limitUsecase.On("GetAllLimits", 10, 0).Return([]domain.Limit{
-		{LimitID: 1, LimitNIK: "1234567890123456", LimitAmount: 50000000},
-		{LimitID: 2, LimitNIK: "9876543210987654", LimitAmount: 75000000},
+		{LimitID: 1, LimitNIK: "1234567890123456", LimitAmount: money.New(50000000)},
+		{LimitID: 2, LimitNIK: "9876543210987654", LimitAmount: money.New(75000000)},
 	}, nil)
 
 	router.ServeHTTP(w, req)
@@ -178,7 +180,7 @@ func TestGetLimit_InvalidLimitOrPage(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.GET("/limits", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -211,7 +213,7 @@ func TestGetLimit_DBError(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.GET("/limits", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -234,7 +236,7 @@ func TestGetLimitByID_Success(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.GET("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -248,7 +250,7 @@ func TestGetLimitByID_Success(t *testing.T) {
 		LimitID:     1,
 		LimitNIK:    "1234567890123456",
 		LimitTenor:  12,
-		LimitAmount: 50000000,
+		LimitAmount: money.New(50000000),
 	}, nil)
 
 	router.ServeHTTP(w, req)
@@ -262,7 +264,7 @@ func TestGetLimitByID_NotFound(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.GET("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -285,7 +287,7 @@ func TestGetLimitByID_InvalidID(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.GET("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -306,7 +308,7 @@ func TestGetLimitByID_DBError(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.GET("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -329,7 +331,7 @@ func TestUpdateLimit_Success(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.PUT("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
@@ -361,7 +363,7 @@ func TestUpdateLimit_NotFound(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.PUT("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
@@ -390,7 +392,7 @@ func TestUpdateLimit_ValidationError(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.PUT("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
@@ -428,7 +430,7 @@ func TestUpdateLimit_DBError(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.PUT("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
@@ -460,7 +462,7 @@ func TestDeleteLimit_Success(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.DELETE("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -473,7 +475,7 @@ func TestDeleteLimit_Success(t *testing.T) {
 	limitUsecase.On("GetLimitByID", uint(1)).Return(&domain.Limit{
 		LimitNIK: "1234567890123456",
 	}, nil)
-	limitUsecase.On("DeleteLimit", uint(1)).Return(nil)
+	limitUsecase.On("DeleteLimit", mock.Anything, uint(1)).Return(nil)
 
 	router.ServeHTTP(w, req)
 
@@ -486,7 +488,7 @@ func TestDeleteLimit_NotFound(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.DELETE("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -509,7 +511,7 @@ func TestDeleteLimit_DBError(t *testing.T) {
 	router := gin.Default()
 
 	limitUsecase := new(mocks.LimitUsecase)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
 
 	router.DELETE("/limits/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1})
@@ -522,7 +524,7 @@ func TestDeleteLimit_DBError(t *testing.T) {
 	limitUsecase.On("GetLimitByID", uint(1)).Return(&domain.Limit{
 		LimitNIK: "1234567890123456",
 	}, nil)
-	limitUsecase.On("DeleteLimit", uint(1)).Return(errors.New("database error"))
+	limitUsecase.On("DeleteLimit", mock.Anything, uint(1)).Return(errors.New("database error"))
 
 	router.ServeHTTP(w, req)
 
@@ -530,3 +532,271 @@ func TestDeleteLimit_DBError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"error":"Failed to delete limit"`)
 }
 
+
+func TestGetLimitMovements_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
+
+	router.GET("/limits/:id/movements", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		limitHandler.GetLimitMovements(c)
+	})
+
+	limit := &domain.Limit{LimitID: 1, LimitAmount: money.New(5000000), LimitRemainingAmount: money.New(5000000)}
+	limitUsecase.On("GetLimitByID", uint(1)).Return(limit, nil)
+	limitUsecase.On("GetLimitMovements", limit, 10, 10).Return([]domain.LimitMovement{
+		{MovementID: 11, MovementLimitID: 1, MovementType: domain.LimitMovementAdjust, MovementLimitDelta: money.New(5000000)},
+	}, &domain.LimitLedger{LedgerLimitID: 1, LedgerMovementCount: 11, LedgerConsistent: true}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/limits/1/movements?limit=10&page=2", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"movement_type":"adjust"`)
+	assert.Contains(t, w.Body.String(), `"ledger_consistent":true`)
+	limitUsecase.AssertExpectations(t)
+}
+
+func TestGetLimitMovements_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
+
+	router.GET("/limits/:id/movements", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		limitHandler.GetLimitMovements(c)
+	})
+
+	limitUsecase.On("GetLimitByID", uint(99)).Return(nil, errors.New("record not found"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/limits/99/movements", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	limitUsecase.AssertNotCalled(t, "GetLimitMovements", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetCustomerLimit_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
+
+	router.GET("/limits/by-nik/:nik", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		limitHandler.GetCustomerLimit(c)
+	})
+
+	remaining := money.New(500000)
+	limitUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	limitUsecase.On("GetCustomerLimitSummary", "1234567890123456").Return(&domain.CustomerLimitSummary{
+		CustomerNIK:          "1234567890123456",
+		CustomerLimit:        &domain.CustomerLimit{CustomerLimitID: 1, CustomerLimitAmount: money.New(5000000)},
+		TotalUsedAmount:      money.New(4500000),
+		TotalRemainingAmount: &remaining,
+		SubLimits: []domain.CustomerSubLimit{
+			{Limit: domain.Limit{LimitID: 1, LimitTenor: 1}, LimitAvailableAmount: money.New(500000)},
+		},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/limits/by-nik/1234567890123456", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"total_remaining_amount":"500000.00"`)
+	assert.Contains(t, w.Body.String(), `"limit_available_amount":"500000.00"`)
+}
+
+func TestSetCustomerLimit_NotAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
+
+	router.PUT("/limits/by-nik/:nik", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 2, UserRole: "user"})
+		limitHandler.SetCustomerLimit(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/limits/by-nik/1234567890123456", bytes.NewBufferString(`{"customer_limit_amount":5000000}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	limitUsecase.AssertNotCalled(t, "SetCustomerLimit", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpsertLimit_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
+
+	router.PUT("/limits/by-nik/:nik/tenors/:tenor", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		limitHandler.UpsertLimit(c)
+	})
+
+	limitUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	limitUsecase.On("UpsertLimit", uint(1), "1234567890123456", 6, money.New(4000000)).
+		Return(&domain.Limit{LimitID: 3, LimitNIK: "1234567890123456", LimitTenor: 6, LimitAmount: money.New(4000000)}, true, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/limits/by-nik/1234567890123456/tenors/6", bytes.NewBufferString(`{"limit_amount":4000000}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"created":true`)
+	limitUsecase.AssertExpectations(t)
+}
+
+func TestUpsertLimit_InvalidTenor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
+
+	router.PUT("/limits/by-nik/:nik/tenors/:tenor", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		limitHandler.UpsertLimit(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/limits/by-nik/1234567890123456/tenors/abc", bytes.NewBufferString(`{"limit_amount":4000000}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	limitUsecase.AssertNotCalled(t, "UpsertLimit", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateLimit_Duplicate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
+
+	router.POST("/limits", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		limitHandler.CreateLimit(c)
+	})
+
+	limitUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	limitUsecase.On("CreateLimit", mock.Anything).Return(usecase.ErrLimitAlreadyExists)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/limits", bytes.NewBufferString(`{"limit_nik":"1234567890123456","limit_tenor":3,"limit_amount":5000000}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestCreateLimit_PrefillsRecommendedAmount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	scoringUsecase := new(mocks.CreditScoringUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, scoringUsecase)
+
+	router.POST("/limits", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		limitHandler.CreateLimit(c)
+	})
+
+	customer := &domain.Customer{CustomerID: 1, CustomerNIK: "1234567890123456"}
+	limitUsecase.On("GetCustomerByNIK", "1234567890123456").Return(customer, nil)
+	scoringUsecase.On("RecommendLimit", customer).Return(&domain.LimitRecommendation{
+		RiskGrade: domain.RiskGradeB,
+		Recommendations: []domain.TenorLimitRecommendation{
+			{Tenor: 3, RecommendedAmount: money.New(7200000)},
+			{Tenor: 6, RecommendedAmount: money.New(14400000)},
+		},
+	}, nil)
+	scoringUsecase.On("AssignRiskGrade", customer, domain.RiskGradeB).Return(nil)
+	limitUsecase.On("CreateLimit", mock.MatchedBy(func(limit domain.Limit) bool {
+		return limit.LimitAmount == money.New(14400000) && limit.LimitRemainingAmount == money.New(14400000)
+	})).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/limits", bytes.NewBufferString(`{"limit_nik":"1234567890123456","limit_tenor":6}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"limit_amount":"14400000.00"`)
+	scoringUsecase.AssertExpectations(t)
+	limitUsecase.AssertExpectations(t)
+}
+
+func TestCreateLimit_NoRecommendedAmount(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	scoringUsecase := new(mocks.CreditScoringUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, scoringUsecase)
+
+	router.POST("/limits", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		limitHandler.CreateLimit(c)
+	})
+
+	customer := &domain.Customer{CustomerID: 1, CustomerNIK: "1234567890123456"}
+	limitUsecase.On("GetCustomerByNIK", "1234567890123456").Return(customer, nil)
+	scoringUsecase.On("RecommendLimit", customer).Return(&domain.LimitRecommendation{
+		RiskGrade:       domain.RiskGradeE,
+		Recommendations: []domain.TenorLimitRecommendation{{Tenor: 6, Reason: "risk grade E is not eligible for a limit"}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/limits", bytes.NewBufferString(`{"limit_nik":"1234567890123456","limit_tenor":6}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), usecase.ErrNoLimitRecommended.Error())
+	scoringUsecase.AssertNotCalled(t, "AssignRiskGrade", mock.Anything, mock.Anything)
+	limitUsecase.AssertNotCalled(t, "CreateLimit", mock.Anything)
+}
+
+func TestCreateLimit_CustomerNotVerified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	limitUsecase := new(mocks.LimitUsecase)
+	limitHandler := handler.NewLimitHandler(limitUsecase, new(mocks.CreditScoringUsecase))
+
+	router.POST("/limits", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		limitHandler.CreateLimit(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/limits", bytes.NewBufferString(`{"limit_nik": "1234567890123456", "limit_tenor": 12, "limit_amount": 50000000}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	limitUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	limitUsecase.On("CreateLimit", mock.Anything).Return(usecase.ErrCustomerNotVerified)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrCustomerNotVerified.Error())
+}
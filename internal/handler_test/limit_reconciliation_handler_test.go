@@ -0,0 +1,85 @@
+package handler_test
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReconcileLimits_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	reconciliationUsecase := new(mocks.LimitReconciliationUsecase)
+	reconciliationHandler := handler.NewLimitReconciliationHandler(reconciliationUsecase)
+
+	router.POST("/limits/reconcile", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		reconciliationHandler.ReconcileLimits(c)
+	})
+
+	reconciliationUsecase.On("ReconcileLimits", uint(1), true).Return(&domain.LimitReconciliationResult{
+		CheckedLimits:    2,
+		MismatchedLimits: 1,
+		RepairedLimits:   1,
+		Mismatches: []domain.LimitReconciliation{
+			{LimitID: 1, StoredUsedAmount: money.New(1500000), ExpectedUsedAmount: money.New(810000), Repaired: true},
+		},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/limits/reconcile?repair=true", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"expected_used_amount":"810000.00"`)
+	assert.Contains(t, w.Body.String(), `"repaired_limits":1`)
+	reconciliationUsecase.AssertExpectations(t)
+}
+
+func TestReconcileLimits_RequiresAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	reconciliationUsecase := new(mocks.LimitReconciliationUsecase)
+	reconciliationHandler := handler.NewLimitReconciliationHandler(reconciliationUsecase)
+
+	router.POST("/limits/reconcile", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 2, UserRole: "user"})
+		reconciliationHandler.ReconcileLimits(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/limits/reconcile", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	reconciliationUsecase.AssertNotCalled(t, "ReconcileLimits", mock.Anything, mock.Anything)
+}
+
+func TestReconcileLimits_InvalidRepairFlag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	reconciliationUsecase := new(mocks.LimitReconciliationUsecase)
+	reconciliationHandler := handler.NewLimitReconciliationHandler(reconciliationUsecase)
+
+	router.POST("/limits/reconcile", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		reconciliationHandler.ReconcileLimits(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/limits/reconcile?repair=maybe", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
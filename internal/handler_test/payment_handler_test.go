@@ -0,0 +1,127 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreatePayment_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	paymentUsecase := new(mocks.PaymentUsecase)
+	paymentHandler := handler.NewPaymentHandler(paymentUsecase)
+
+	router.POST("/transactions/:id/payments", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		paymentHandler.CreatePayment(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"payment_amount": 150000,
+		"payment_channel": "bank_transfer",
+		"payment_paid_at": "2025-01-10"
+	}`
+	req, _ := http.NewRequest("POST", "/transactions/1/payments", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionContractNumber: "TX12345"}
+	paymentUsecase.On("GetTransactionByID", uint(1)).Return(transaction, nil)
+	paymentUsecase.On("CreatePayment", uint(1), transaction, mock.Anything).Return(&domain.Payment{
+		PaymentID:              1,
+		PaymentAmount:          money.New(150000),
+		PaymentPrincipalAmount: money.New(100000),
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"message":"Payment recorded successfully"`)
+	assert.Contains(t, w.Body.String(), `"payment_principal_amount":"100000.00"`)
+}
+
+func TestCreatePayment_ValidationError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	paymentUsecase := new(mocks.PaymentUsecase)
+	paymentHandler := handler.NewPaymentHandler(paymentUsecase)
+
+	router.POST("/transactions/:id/payments", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		paymentHandler.CreatePayment(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"payment_amount": 150000, "payment_channel": "crypto"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/payments", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	paymentUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{TransactionID: 1}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	paymentUsecase.AssertNotCalled(t, "CreatePayment", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreatePayment_ExceedsOutstanding(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	paymentUsecase := new(mocks.PaymentUsecase)
+	paymentHandler := handler.NewPaymentHandler(paymentUsecase)
+
+	router.POST("/transactions/:id/payments", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		paymentHandler.CreatePayment(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"payment_amount": 99000000, "payment_channel": "cash"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/payments", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	paymentUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{TransactionID: 1}, nil)
+	paymentUsecase.On("CreatePayment", uint(1), mock.Anything, mock.Anything).Return(nil, usecase.ErrPaymentExceedsOutstanding)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrPaymentExceedsOutstanding.Error())
+}
+
+func TestGetPayments_TransactionNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	paymentUsecase := new(mocks.PaymentUsecase)
+	paymentHandler := handler.NewPaymentHandler(paymentUsecase)
+
+	router.GET("/transactions/:id/payments", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		paymentHandler.GetPayments(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/99/payments", nil)
+
+	paymentUsecase.On("GetTransactionByID", uint(99)).Return(nil, errors.New("transaction not found"))
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+}
@@ -0,0 +1,133 @@
+package handler_test
+
+import (
+	"bytes"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const pricingRuleRequestBody = `{
+	"pricing_product_id": 1,
+	"pricing_tenor": 12,
+	"pricing_risk_grade": "A",
+	"pricing_admin_fee": 250000,
+	"pricing_interest_rate": 1.25
+}`
+
+func TestCreatePricingRule_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	pricingUsecase := new(mocks.PricingUsecase)
+	pricingHandler := handler.NewPricingHandler(pricingUsecase)
+
+	router.POST("/pricing-rules", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		pricingHandler.CreatePricingRule(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/pricing-rules", bytes.NewBufferString(pricingRuleRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	pricingUsecase.On("CreatePricingRule", uint(1), mock.Anything).Return(&domain.PricingRule{
+		PricingRuleID:       1,
+		PricingProductID:    1,
+		PricingTenor:        12,
+		PricingRiskGrade:    "A",
+		PricingAdminFee:     money.New(250000),
+		PricingInterestRate: 1.25,
+		PricingActive:       true,
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"message":"Pricing rule created successfully"`)
+	assert.Contains(t, w.Body.String(), `"pricing_risk_grade":"A"`)
+}
+
+func TestCreatePricingRule_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	pricingUsecase := new(mocks.PricingUsecase)
+	pricingHandler := handler.NewPricingHandler(pricingUsecase)
+
+	router.POST("/pricing-rules", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 2, UserRole: "user"})
+		pricingHandler.CreatePricingRule(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/pricing-rules", bytes.NewBufferString(pricingRuleRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized")
+	pricingUsecase.AssertNotCalled(t, "CreatePricingRule", mock.Anything, mock.Anything)
+}
+
+func TestCreatePricingRule_InvalidRiskGrade(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	pricingUsecase := new(mocks.PricingUsecase)
+	pricingHandler := handler.NewPricingHandler(pricingUsecase)
+
+	router.POST("/pricing-rules", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		pricingHandler.CreatePricingRule(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/pricing-rules", bytes.NewBufferString(`{
+		"pricing_product_id": 1,
+		"pricing_risk_grade": "Z",
+		"pricing_admin_fee": 250000,
+		"pricing_interest_rate": 1.25
+	}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	pricingUsecase.AssertNotCalled(t, "CreatePricingRule", mock.Anything, mock.Anything)
+}
+
+func TestUpdatePricingRule_TenorNotOffered(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	pricingUsecase := new(mocks.PricingUsecase)
+	pricingHandler := handler.NewPricingHandler(pricingUsecase)
+
+	router.PUT("/pricing-rules/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		pricingHandler.UpdatePricingRule(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/pricing-rules/1", bytes.NewBufferString(pricingRuleRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	rule := &domain.PricingRule{PricingRuleID: 1, PricingProductID: 1}
+	pricingUsecase.On("GetPricingRuleByID", uint(1)).Return(rule, nil)
+	pricingUsecase.On("UpdatePricingRule", uint(1), rule, mock.Anything).Return(usecase.ErrTenorNotOffered)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrTenorNotOffered.Error())
+}
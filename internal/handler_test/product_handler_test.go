@@ -0,0 +1,207 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const productRequestBody = `{
+	"product_code": "motorcycle",
+	"product_name": "Motorcycle Financing",
+	"product_min_otr": 10000000,
+	"product_max_otr": 60000000,
+	"product_tenors": [
+		{"tenor_months": 12, "tenor_interest_rate": 1.5, "tenor_admin_fee": 500000},
+		{"tenor_months": 24, "tenor_interest_rate": 1.8, "tenor_admin_fee": 750000}
+	]
+}`
+
+func TestCreateProduct_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	productUsecase := new(mocks.ProductUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+
+	router.POST("/products", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		productHandler.CreateProduct(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/products", bytes.NewBufferString(productRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	productUsecase.On("CreateProduct", uint(1), mock.Anything).Return(&domain.Product{
+		ProductID:     1,
+		ProductCode:   "motorcycle",
+		ProductMaxOTR: money.New(60000000),
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"message":"Product created successfully"`)
+	assert.Contains(t, w.Body.String(), `"product_code":"motorcycle"`)
+}
+
+func TestCreateProduct_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	productUsecase := new(mocks.ProductUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+
+	router.POST("/products", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 2, UserRole: "user"})
+		productHandler.CreateProduct(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/products", bytes.NewBufferString(productRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized")
+	productUsecase.AssertNotCalled(t, "CreateProduct", mock.Anything, mock.Anything)
+}
+
+func TestCreateProduct_ValidationError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	productUsecase := new(mocks.ProductUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+
+	router.POST("/products", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		productHandler.CreateProduct(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/products", bytes.NewBufferString(`{
+		"product_code": "motorcycle",
+		"product_name": "Motorcycle Financing",
+		"product_max_otr": 60000000,
+		"product_tenors": [
+			{"tenor_months": 12},
+			{"tenor_months": 12}
+		]
+	}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	productUsecase.AssertNotCalled(t, "CreateProduct", mock.Anything, mock.Anything)
+}
+
+func TestCreateProduct_Duplicate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	productUsecase := new(mocks.ProductUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+
+	router.POST("/products", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		productHandler.CreateProduct(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/products", bytes.NewBufferString(productRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	productUsecase.On("CreateProduct", uint(1), mock.Anything).Return(nil, usecase.ErrProductAlreadyExists)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict")
+}
+
+func TestGetProduct_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	productUsecase := new(mocks.ProductUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+
+	router.GET("/products", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		productHandler.GetProduct(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/products?limit=10&page=1", nil)
+
+	productUsecase.On("GetAllProducts", 10, 0).Return([]domain.Product{
+		{ProductID: 1, ProductCode: "electronics", ProductTenors: []domain.ProductTenor{{TenorMonths: 3}}},
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"product_code":"electronics"`)
+	assert.Contains(t, w.Body.String(), `"tenor_months":3`)
+}
+
+func TestGetProductByID_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	productUsecase := new(mocks.ProductUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+
+	router.GET("/products/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		productHandler.GetProductByID(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/products/99", nil)
+
+	productUsecase.On("GetProductByID", uint(99)).Return(nil, errors.New("record not found"))
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+}
+
+func TestUpdateProduct_InvalidOTRRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	productUsecase := new(mocks.ProductUsecase)
+	productHandler := handler.NewProductHandler(productUsecase)
+
+	router.PUT("/products/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		productHandler.UpdateProduct(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/products/1", bytes.NewBufferString(productRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	product := &domain.Product{ProductID: 1, ProductCode: "motorcycle"}
+	productUsecase.On("GetProductByID", uint(1)).Return(product, nil)
+	productUsecase.On("UpdateProduct", uint(1), product, mock.Anything).Return(usecase.ErrInvalidOTRRange)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrInvalidOTRRange.Error())
+}
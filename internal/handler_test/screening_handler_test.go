@@ -0,0 +1,188 @@
+package handler_test
+
+import (
+	"bytes"
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const negativeListRequestBody = `{
+	"negative_list_type": "phone",
+	"negative_list_value": "0812-3456-7890",
+	"negative_list_reason": "mule account",
+	"negative_list_source": "fraud_team",
+	"negative_list_severity": "block"
+}`
+
+func TestCreateNegativeListEntry_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	screeningUsecase := new(mocks.ScreeningUsecase)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+
+	router.POST("/negative-list", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		screeningHandler.CreateNegativeListEntry(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/negative-list", bytes.NewBufferString(negativeListRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	screeningUsecase.On("CreateNegativeListEntry", uint(1), mock.Anything).Return(&domain.NegativeListEntry{
+		NegativeListID:       1,
+		NegativeListType:     domain.NegativeListTypePhone,
+		NegativeListValue:    "6281234567890",
+		NegativeListSeverity: domain.NegativeListSeverityBlock,
+		NegativeListActive:   true,
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"message":"Negative list entry created successfully"`)
+	assert.Contains(t, w.Body.String(), `"negative_list_value":"6281234567890"`)
+}
+
+func TestCreateNegativeListEntry_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	screeningUsecase := new(mocks.ScreeningUsecase)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+
+	router.POST("/negative-list", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 2, UserRole: "user"})
+		screeningHandler.CreateNegativeListEntry(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/negative-list", bytes.NewBufferString(negativeListRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized")
+	screeningUsecase.AssertNotCalled(t, "CreateNegativeListEntry", mock.Anything, mock.Anything)
+}
+
+func TestCreateNegativeListEntry_InvalidSeverity(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	screeningUsecase := new(mocks.ScreeningUsecase)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+
+	router.POST("/negative-list", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		screeningHandler.CreateNegativeListEntry(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"negative_list_type": "nik",
+		"negative_list_value": "3171014509900001",
+		"negative_list_reason": "confirmed fraud",
+		"negative_list_source": "fraud_team",
+		"negative_list_severity": "deny"
+	}`
+	req, _ := http.NewRequest("POST", "/negative-list", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	screeningUsecase.AssertNotCalled(t, "CreateNegativeListEntry", mock.Anything, mock.Anything)
+}
+
+func TestUpdateNegativeListEntry_InvalidValue(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	screeningUsecase := new(mocks.ScreeningUsecase)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+
+	router.PUT("/negative-list/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		screeningHandler.UpdateNegativeListEntry(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/negative-list/1", bytes.NewBufferString(negativeListRequestBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	entry := &domain.NegativeListEntry{NegativeListID: 1}
+	screeningUsecase.On("GetNegativeListEntryByID", uint(1)).Return(entry, nil)
+	screeningUsecase.On("UpdateNegativeListEntry", uint(1), entry, mock.Anything).Return(usecase.ErrInvalidNegativeListValue)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrInvalidNegativeListValue.Error())
+}
+
+func TestGetCustomerScreening_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	screeningUsecase := new(mocks.ScreeningUsecase)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+
+	router.GET("/customers/:id/screening", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		screeningHandler.GetCustomerScreening(c)
+	})
+
+	customer := &domain.Customer{CustomerID: 3, CustomerNIK: "3171014509900001"}
+	screeningUsecase.On("GetCustomerByID", uint(3)).Return(customer, nil)
+	screeningUsecase.On("ScreenCustomer", customer).Return(&domain.ScreeningResult{
+		CustomerNIK: "3171014509900001",
+		Decision:    domain.ScreeningDecisionFlag,
+		Hits: []domain.ScreeningHit{
+			{NegativeListID: 1, MatchedField: "customer_phone", Severity: domain.NegativeListSeverityFlag, Reason: "mule account"},
+		},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/3/screening", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"decision":"flag"`)
+	assert.Contains(t, w.Body.String(), `"matched_field":"customer_phone"`)
+}
+
+func TestGetCustomerScreening_CustomerNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	screeningUsecase := new(mocks.ScreeningUsecase)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+
+	router.GET("/customers/:id/screening", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		screeningHandler.GetCustomerScreening(c)
+	})
+
+	screeningUsecase.On("GetCustomerByID", uint(9)).Return(nil, errors.New("record not found"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/customers/9/screening", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+	screeningUsecase.AssertNotCalled(t, "ScreenCustomer", mock.Anything)
+}
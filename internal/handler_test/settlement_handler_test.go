@@ -0,0 +1,126 @@
+package handler_test
+
+import (
+	"bytes"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/usecase/mocks"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetPayoffQuote_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	settlementUsecase := new(mocks.SettlementUsecase)
+	settlementHandler := handler.NewSettlementHandler(settlementUsecase)
+
+	router.GET("/transactions/:id/payoff", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		settlementHandler.GetPayoffQuote(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/1/payoff?as_of=2025-02-15", nil)
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionContractNumber: "TX12345"}
+	settlementUsecase.On("GetTransactionByID", uint(1)).Return(transaction, nil)
+	settlementUsecase.On("GetPayoffQuote", transaction, time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC)).Return(&domain.PayoffQuote{
+		PayoffTransactionID: 1,
+		PayoffTotalAmount:   money.New(214000),
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"payoff_total_amount":"214000.00"`)
+}
+
+func TestGetPayoffQuote_InvalidDate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	settlementUsecase := new(mocks.SettlementUsecase)
+	settlementHandler := handler.NewSettlementHandler(settlementUsecase)
+
+	router.GET("/transactions/:id/payoff", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		settlementHandler.GetPayoffQuote(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/1/payoff?as_of=15-02-2025", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	settlementUsecase.AssertNotCalled(t, "GetPayoffQuote", mock.Anything, mock.Anything)
+}
+
+func TestSettleTransaction_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	settlementUsecase := new(mocks.SettlementUsecase)
+	settlementHandler := handler.NewSettlementHandler(settlementUsecase)
+
+	router.POST("/transactions/:id/settle", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		settlementHandler.SettleTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"payment_amount": 214000, "payment_channel": "bank_transfer"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/settle", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionContractNumber: "TX12345"}
+	settlementUsecase.On("GetTransactionByID", uint(1)).Return(transaction, nil)
+	settlementUsecase.On("SettleTransaction", uint(1), transaction, mock.Anything).Return(&domain.Payment{
+		PaymentID:     1,
+		PaymentAmount: money.New(214000),
+		PaymentType:   domain.PaymentTypeSettlement,
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"message":"Transaction settled successfully"`)
+	assert.Contains(t, w.Body.String(), `"payment_type":"settlement"`)
+}
+
+func TestSettleTransaction_AmountMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	settlementUsecase := new(mocks.SettlementUsecase)
+	settlementHandler := handler.NewSettlementHandler(settlementUsecase)
+
+	router.POST("/transactions/:id/settle", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		settlementHandler.SettleTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"payment_amount": 100000, "payment_channel": "cash"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/settle", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionContractNumber: "TX12345"}
+	settlementUsecase.On("GetTransactionByID", uint(1)).Return(transaction, nil)
+	settlementUsecase.On("SettleTransaction", uint(1), transaction, mock.Anything).Return(nil, usecase.ErrSettlementAmountMismatch)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrSettlementAmountMismatch.Error())
+}
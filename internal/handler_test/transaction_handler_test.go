@@ -5,6 +5,8 @@ import (
 	"errors"
 	"kreditplus/internal/domain"
 	"kreditplus/internal/handler"
+	"kreditplus/internal/money"
+	"kreditplus/internal/usecase"
 	"kreditplus/internal/usecase/mocks"
 	"net/http"
 	"net/http/httptest"
@@ -31,18 +33,18 @@ func TestCreateTransaction_Success(t *testing.T) {
 	w := httptest.NewRecorder()
 	reqBody := `{
 		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
 		"transaction_amount": 5000000,
 		"transaction_otr": 5500000,
-		"transaction_admin_fee": 200000,
 		"transaction_installment": 12,
-		"transaction_interest": 2.5,
 		"transaction_asset_name": "Motorcycle"
 	}`
 	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
 	req.Header.Set("Content-Type", "application/json")
 
 	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
-	transactionUsecase.On("CreateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+	transactionUsecase.On("CreateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	router.ServeHTTP(w, req)
 
@@ -106,11 +108,10 @@ func TestCreateTransaction_CustomerNotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 	reqBody := `{
 		"transaction_nik": "9999999999999999",
+		"transaction_product": "electronics",
 		"transaction_amount": 5000000,
 		"transaction_otr": 5500000,
-		"transaction_admin_fee": 200000,
 		"transaction_installment": 12,
-		"transaction_interest": 2.5,
 		"transaction_asset_name": "Motorcycle"
 	}`
 	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
@@ -124,6 +125,223 @@ func TestCreateTransaction_CustomerNotFound(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"error"`)
 }
 
+func TestCreateTransaction_ProductNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.CreateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "yacht",
+		"transaction_otr": 5500000,
+		"transaction_installment": 12,
+		"transaction_asset_name": "Yacht"
+	}`
+	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	transactionUsecase.On("GetProductByCode", "yacht").Return(nil, errors.New("record not found"))
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+	assert.Contains(t, w.Body.String(), `"error":"Product not found"`)
+	transactionUsecase.AssertNotCalled(t, "CreateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaction_TenorNotOffered(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.CreateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_otr": 5500000,
+		"transaction_installment": 36,
+		"transaction_asset_name": "Laptop"
+	}`
+	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+	transactionUsecase.On("CreateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(usecase.ErrTenorNotOffered)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), `"error":"tenor is not offered"`)
+}
+
+func TestCreateTransaction_PricingOverrideForbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.CreateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_otr": 5500000,
+		"transaction_admin_fee": 1,
+		"transaction_installment": 12,
+		"transaction_asset_name": "Motorcycle"
+	}`
+	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden")
+	assert.Contains(t, w.Body.String(), usecase.ErrPricingOverrideNotAllowed.Error())
+	transactionUsecase.AssertNotCalled(t, "CreateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaction_PricingOverrideAllowed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserCanOverridePricing: true})
+		transactionHandler.CreateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_otr": 5500000,
+		"transaction_admin_fee": 150000,
+		"transaction_installment": 12,
+		"transaction_interest": 1.5,
+		"transaction_asset_name": "Motorcycle"
+	}`
+	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+	transactionUsecase.On("CreateTransactionWithLimitUpdate", uint(1), mock.Anything, mock.Anything, mock.MatchedBy(func(input domain.TransactionInput) bool {
+		return input.TransactionAdminFee == money.New(150000) && input.TransactionInterest == 1.5
+	})).Return(nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+}
+
+func TestCreateTransaction_AffordabilityRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.CreateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_otr": 6000000,
+		"transaction_installment": 6,
+		"transaction_asset_name": "Motorcycle"
+	}`
+	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+	transactionUsecase.On("CreateTransactionWithLimitUpdate", uint(1), mock.Anything, mock.Anything, mock.Anything).Return(&usecase.AffordabilityError{
+		Rejection: domain.AffordabilityRejection{
+			Reason:              domain.AffordabilityReasonRatioExceeded,
+			CustomerNIK:         "1234567890123456",
+			InstallmentRatio:    44,
+			MaxInstallmentRatio: 30,
+		},
+	})
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrAffordabilityExceeded.Error())
+	assert.Contains(t, w.Body.String(), `"reason":"installment_ratio_exceeded"`)
+	assert.Contains(t, w.Body.String(), `"installment_ratio":44`)
+}
+
+func TestCreateTransaction_NegativeListBlocked(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.CreateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_otr": 6000000,
+		"transaction_installment": 6,
+		"transaction_asset_name": "Motorcycle"
+	}`
+	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+	transactionUsecase.On("CreateTransactionWithLimitUpdate", uint(1), mock.Anything, mock.Anything, mock.Anything).Return(&usecase.ScreeningError{
+		Result: &domain.ScreeningResult{
+			CustomerNIK: "1234567890123456",
+			Decision:    domain.ScreeningDecisionBlock,
+			Hits: []domain.ScreeningHit{
+				{NegativeListID: 1, MatchedField: "customer_nik", Severity: domain.NegativeListSeverityBlock, Reason: "confirmed fraud"},
+			},
+		},
+	})
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrNegativeListBlocked.Error())
+	assert.Contains(t, w.Body.String(), `"decision":"block"`)
+}
+
 func TestCreateTransaction_DBError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
@@ -139,18 +357,18 @@ func TestCreateTransaction_DBError(t *testing.T) {
 	w := httptest.NewRecorder()
 	reqBody := `{
 		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
 		"transaction_amount": 5000000,
 		"transaction_otr": 5500000,
-		"transaction_admin_fee": 200000,
 		"transaction_installment": 12,
-		"transaction_interest": 2.5,
 		"transaction_asset_name": "Motorcycle"
 	}`
 	req, _ := http.NewRequest("POST", "/transactions", bytes.NewBuffer([]byte(reqBody)))
 	req.Header.Set("Content-Type", "application/json")
 
 	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
-	transactionUsecase.On("CreateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+	transactionUsecase.On("CreateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
 
 	router.ServeHTTP(w, req)
 
@@ -174,8 +392,8 @@ func TestGetTransaction_Success(t *testing.T) {
 	req, _ := http.NewRequest("GET", "/transactions?limit=10&page=1", nil)
 
 	mockTransactions := []domain.Transaction{
-		{TransactionID: 1, TransactionContractNumber: "TX123456", TransactionOTR: 5000000, TransactionInstallment: 500000},
-		{TransactionID: 2, TransactionContractNumber: "TX654321", TransactionOTR: 10000000, TransactionInstallment: 800000},
+		{TransactionID: 1, TransactionContractNumber: "TX123456", TransactionOTR: money.New(5000000), TransactionInstallment: 500000},
+		{TransactionID: 2, TransactionContractNumber: "TX654321", TransactionOTR: money.New(10000000), TransactionInstallment: 800000},
 	}
 	transactionUsecase.On("GetAllTransactions", 10, 0).Return(mockTransactions, nil)
 
@@ -243,6 +461,53 @@ func TestGetTransaction_DBError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"error":"Failed to retrieve transactions"`)
 }
 
+func TestGetTransactionRetryMetrics_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.GET("/transactions/retry-metrics", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.GetTransactionRetryMetrics(c)
+	})
+
+	transactionUsecase.On("GetTransactionRetryMetrics").Return(domain.TransactionRetryMetrics{
+		Transactions:  10,
+		Retries:       2,
+		Recovered:     1,
+		RetriesByCode: map[string]int64{"40P01": 2},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/retry-metrics", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"retries_by_code":{"40P01":2}`)
+}
+
+func TestGetTransactionRetryMetrics_NotAdmin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.GET("/transactions/retry-metrics", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.GetTransactionRetryMetrics(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/retry-metrics", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	transactionUsecase.AssertNotCalled(t, "GetTransactionRetryMetrics")
+}
+
 func TestGetTransactionByID_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
@@ -269,15 +534,21 @@ func TestGetTransactionByID_Success(t *testing.T) {
 		TransactionLimit: 1,
 		IDLimit: domain.Limit{
 			LimitID:     1,
-			LimitAmount: 5000000,
+			LimitAmount: money.New(5000000),
 		},
-		TransactionOTR:         5000000,
-		TransactionAdminFee:    250000,
-		TransactionInstallment: 1250000,
-		TransactionInterest:    5.5,
-		TransactionAssetName:   "Motorcycle",
-		TransactionDate:        time.Now(),
-		TransactionCreatedBy:   1,
+		TransactionOTR:            money.New(5000000),
+		TransactionAdminFee:       money.New(250000),
+		TransactionInstallment:    1250000,
+		TransactionInterest:       5.5,
+		TransactionAssetName:      "Motorcycle",
+		TransactionDaysPastDue:    12,
+		TransactionCollectibility: "1-30",
+		TransactionStatus:         "active",
+		TransactionScheme:         "flat",
+		TransactionVersion:        1,
+		TransactionOriginalNumber: "TX123456",
+		TransactionDate:           time.Now(),
+		TransactionCreatedBy:      1,
 		CreatedByUser: domain.User{
 			UserID:       1,
 			UserUsername: "admin",
@@ -301,13 +572,23 @@ func TestGetTransactionByID_Success(t *testing.T) {
 		"transaction_limit": 1,
 		"IDLimit": {
 			"limit_id": 1,
-			"limit_amount": 5000000
+			"limit_amount": "5000000.00"
 		},
-		"transaction_otr": 5000000,
-		"transaction_admin_fee": 250000,
+		"transaction_otr": "5000000.00",
+		"transaction_admin_fee": "250000.00",
 		"transaction_installment": 1250000,
 		"transaction_interest": 5.5,
 		"transaction_asset_name": "Motorcycle",
+		"transaction_days_past_due": 12,
+		"transaction_collectibility": "1-30",
+		"transaction_status": "active",
+		"transaction_scheme": "flat",
+		"transaction_parent_id": null,
+		"transaction_product_id": null,
+		"transaction_pricing_rule_id": null,
+		"transaction_pricing_override": false,
+		"transaction_version": 1,
+		"transaction_original_number": "TX123456",
 		"transaction_created_by": 1,
 		"CreatedByUser": {
 			"user_id": 1,
@@ -388,71 +669,53 @@ func TestGetTransactionByID_DBError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"error":"Transaction not found"`)
 }
 
-func TestUpdateTransaction_Success(t *testing.T) {
+func TestGetTransactionSchedule_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
 	transactionUsecase := new(mocks.TransactionUsecase)
 	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
 
-	router.PUT("/transactions/:id", func(c *gin.Context) {
-		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
-		transactionHandler.UpdateTransaction(c)
+	router.GET("/transactions/:id/schedule", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.GetTransactionSchedule(c)
 	})
 
 	w := httptest.NewRecorder()
-	reqBody := `{
-		"transaction_nik": "1234567890123456",
-		"transaction_amount": 7000000,
-		"transaction_otr": 8000000,
-		"transaction_admin_fee": 500000,
-		"transaction_installment": 12,
-		"transaction_interest": 5.0,
-		"transaction_asset_name": "Motorcycle"
-	}`
-	req, _ := http.NewRequest("PUT", "/transactions/1", bytes.NewBuffer([]byte(reqBody)))
-	req.Header.Set("Content-Type", "application/json")
+	req, _ := http.NewRequest("GET", "/transactions/1/schedule", nil)
 
-	// ✅ Mock Usecase Calls
 	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
 		TransactionID:             1,
 		TransactionContractNumber: "TX123456",
-		TransactionNIK:            "1234567890123456",
 	}, nil)
-
-	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{
-		CustomerNIK: "1234567890123456",
+	transactionUsecase.On("GetTransactionSchedule", uint(1)).Return([]domain.Installment{
+		{InstallmentID: 1, InstallmentTransactionID: 1, InstallmentNumber: 1, InstallmentAmount: money.New(370000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentID: 2, InstallmentTransactionID: 1, InstallmentNumber: 2, InstallmentAmount: money.New(370000), InstallmentStatus: domain.InstallmentStatusUnpaid},
 	}, nil)
 
-	transactionUsecase.On("UpdateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
-
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
-	assert.Contains(t, w.Body.String(), `"message":"Transaction edited successfully"`)
+	assert.Contains(t, w.Body.String(), `"transaction_contract_number":"TX123456"`)
+	assert.Contains(t, w.Body.String(), `"installment_number":2`)
 }
 
-func TestUpdateTransaction_NotFound(t *testing.T) {
+func TestGetTransactionSchedule_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
 	transactionUsecase := new(mocks.TransactionUsecase)
 	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
 
-	router.PUT("/transactions/:id", func(c *gin.Context) {
-		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
-		transactionHandler.UpdateTransaction(c)
+	router.GET("/transactions/:id/schedule", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.GetTransactionSchedule(c)
 	})
 
 	w := httptest.NewRecorder()
-	reqBody := `{
-		"transaction_nik": "1234567890123456",
-		"transaction_amount": 7000000
-	}`
-	req, _ := http.NewRequest("PUT", "/transactions/999", bytes.NewBuffer([]byte(reqBody)))
-	req.Header.Set("Content-Type", "application/json")
+	req, _ := http.NewRequest("GET", "/transactions/99/schedule", nil)
 
-	transactionUsecase.On("GetTransactionByID", uint(999)).Return(nil, errors.New("transaction not found"))
+	transactionUsecase.On("GetTransactionByID", uint(99)).Return(nil, errors.New("transaction not found"))
 
 	router.ServeHTTP(w, req)
 
@@ -460,7 +723,7 @@ func TestUpdateTransaction_NotFound(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"error":"Transaction not found"`)
 }
 
-func TestUpdateTransaction_ValidationError(t *testing.T) {
+func TestUpdateTransaction_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
@@ -474,25 +737,37 @@ func TestUpdateTransaction_ValidationError(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	reqBody := `{
-		"transaction_nik": "",
-		"transaction_amount": -500
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_amount": 7000000,
+		"transaction_otr": 8000000,
+		"transaction_installment": 12,
+		"transaction_asset_name": "Motorcycle"
 	}`
 	req, _ := http.NewRequest("PUT", "/transactions/1", bytes.NewBuffer([]byte(reqBody)))
 	req.Header.Set("Content-Type", "application/json")
 
+	// ✅ Mock Usecase Calls
 	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
 		TransactionID:             1,
 		TransactionContractNumber: "TX123456",
 		TransactionNIK:            "1234567890123456",
 	}, nil)
 
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{
+		CustomerNIK: "1234567890123456",
+	}, nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+
+	transactionUsecase.On("UpdateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
-	assert.Contains(t, w.Body.String(), `"error"`)
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"message":"Transaction edited successfully"`)
 }
 
-func TestUpdateTransaction_DBError(t *testing.T) {
+func TestUpdateTransaction_DifferentNIK(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
@@ -506,12 +781,10 @@ func TestUpdateTransaction_DBError(t *testing.T) {
 
 	w := httptest.NewRecorder()
 	reqBody := `{
-		"transaction_nik": "1234567890123456",
-		"transaction_amount": 5000000,
+		"transaction_nik": "6543210987654321",
+		"transaction_product": "electronics",
 		"transaction_otr": 8000000,
-		"transaction_admin_fee": 500000,
 		"transaction_installment": 12,
-		"transaction_interest": 5.0,
 		"transaction_asset_name": "Motorcycle"
 	}`
 	req, _ := http.NewRequest("PUT", "/transactions/1", bytes.NewBuffer([]byte(reqBody)))
@@ -523,19 +796,161 @@ func TestUpdateTransaction_DBError(t *testing.T) {
 		TransactionNIK:            "1234567890123456",
 	}, nil)
 
-	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{
-		CustomerNIK: "1234567890123456",
-	}, nil)
-
-	transactionUsecase.On("UpdateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
-
 	router.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusInternalServerError, w.Code, "Expected HTTP 500 Internal Server Error")
-	assert.Contains(t, w.Body.String(), `"error":"Failed to update transaction"`)
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrTransactionNIKMismatch.Error())
+	transactionUsecase.AssertNotCalled(t, "GetCustomerByNIK", mock.Anything)
+	transactionUsecase.AssertNotCalled(t, "UpdateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestDeleteTransaction_Success(t *testing.T) {
+func TestUpdateTransaction_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.PUT("/transactions/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.UpdateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_amount": 7000000
+	}`
+	req, _ := http.NewRequest("PUT", "/transactions/999", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetTransactionByID", uint(999)).Return(nil, errors.New("transaction not found"))
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+	assert.Contains(t, w.Body.String(), `"error":"Transaction not found"`)
+}
+
+func TestUpdateTransaction_ValidationError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.PUT("/transactions/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.UpdateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "",
+		"transaction_amount": -500
+	}`
+	req, _ := http.NewRequest("PUT", "/transactions/1", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX123456",
+		TransactionNIK:            "1234567890123456",
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), `"error"`)
+}
+
+func TestUpdateTransaction_DBError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.PUT("/transactions/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.UpdateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_amount": 5000000,
+		"transaction_otr": 8000000,
+		"transaction_installment": 12,
+		"transaction_asset_name": "Motorcycle"
+	}`
+	req, _ := http.NewRequest("PUT", "/transactions/1", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX123456",
+		TransactionNIK:            "1234567890123456",
+	}, nil)
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{
+		CustomerNIK: "1234567890123456",
+	}, nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+
+	transactionUsecase.On("UpdateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("database error"))
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code, "Expected HTTP 500 Internal Server Error")
+	assert.Contains(t, w.Body.String(), `"error":"Failed to update transaction"`)
+}
+
+func TestUpdateTransaction_InsufficientLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.PUT("/transactions/:id", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.UpdateTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_otr": 8000000,
+		"transaction_installment": 6,
+		"transaction_asset_name": "Motorcycle"
+	}`
+	req, _ := http.NewRequest("PUT", "/transactions/1", bytes.NewBuffer([]byte(reqBody)))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX123456",
+		TransactionNIK:            "1234567890123456",
+	}, nil)
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{
+		CustomerNIK: "1234567890123456",
+	}, nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+
+	transactionUsecase.On("UpdateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(usecase.ErrInsufficientLimit)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), `"error":"insufficient limit"`)
+}
+
+func TestCancelTransaction_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
@@ -544,7 +959,7 @@ func TestDeleteTransaction_Success(t *testing.T) {
 
 	router.DELETE("/transactions/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
-		transactionHandler.DeleteTransaction(c)
+		transactionHandler.CancelTransaction(c)
 	})
 
 	w := httptest.NewRecorder()
@@ -553,15 +968,15 @@ func TestDeleteTransaction_Success(t *testing.T) {
 	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
 		TransactionContractNumber: "TX123456",
 	}, nil)
-	transactionUsecase.On("DeleteTransactionWithLimitUpdate", mock.Anything, mock.Anything).Return(nil)
+	transactionUsecase.On("TransitionTransactionStatus", mock.Anything, mock.Anything, domain.TransactionStatusCancelled, "").Return(nil)
 
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
-	assert.Contains(t, w.Body.String(), `"message":"Transaction deleted successfully"`)
+	assert.Contains(t, w.Body.String(), `"message":"Transaction cancelled successfully"`)
 }
 
-func TestDeleteTransaction_NotFound(t *testing.T) {
+func TestCancelTransaction_NotFound(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
@@ -570,7 +985,7 @@ func TestDeleteTransaction_NotFound(t *testing.T) {
 
 	router.DELETE("/transactions/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
-		transactionHandler.DeleteTransaction(c)
+		transactionHandler.CancelTransaction(c)
 	})
 
 	w := httptest.NewRecorder()
@@ -584,7 +999,7 @@ func TestDeleteTransaction_NotFound(t *testing.T) {
 	assert.Contains(t, w.Body.String(), `"error":"Transaction not found"`)
 }
 
-func TestDeleteTransaction_DBError(t *testing.T) {
+func TestCancelTransaction_DBError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 
@@ -593,7 +1008,7 @@ func TestDeleteTransaction_DBError(t *testing.T) {
 
 	router.DELETE("/transactions/:id", func(c *gin.Context) {
 		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
-		transactionHandler.DeleteTransaction(c)
+		transactionHandler.CancelTransaction(c)
 	})
 
 	w := httptest.NewRecorder()
@@ -602,10 +1017,402 @@ func TestDeleteTransaction_DBError(t *testing.T) {
 	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
 		TransactionContractNumber: "TX123456",
 	}, nil)
-	transactionUsecase.On("DeleteTransactionWithLimitUpdate", mock.Anything, mock.Anything).Return(errors.New("database error"))
+	transactionUsecase.On("TransitionTransactionStatus", mock.Anything, mock.Anything, domain.TransactionStatusCancelled, "").Return(errors.New("database error"))
 
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code, "Expected HTTP 500 Internal Server Error")
-	assert.Contains(t, w.Body.String(), `"error":"Failed to delete transaction"`)
+	assert.Contains(t, w.Body.String(), `"error":"Failed to change transaction status"`)
+}
+
+func TestGetDelinquentTransactions_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.GET("/transactions/delinquent", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.GetDelinquentTransactions(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/delinquent?bucket=31-60&min_dpd=40", nil)
+
+	transactionUsecase.On("GetDelinquentTransactions", "31-60", 40, 10, 0).Return([]domain.Transaction{
+		{TransactionID: 1, TransactionContractNumber: "TX12345", TransactionDaysPastDue: 45, TransactionCollectibility: "31-60"},
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"transaction_days_past_due":45`)
+}
+
+func TestGetDelinquentTransactions_InvalidBucket(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.GET("/transactions/delinquent", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.GetDelinquentTransactions(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/delinquent?bucket=120", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), `"error":"Invalid bucket value"`)
+}
+
+func TestCancelTransaction_InvalidTransition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/:id/cancel", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.CancelTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/transactions/1/cancel", nil)
+
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
+		TransactionContractNumber: "TX123456",
+		TransactionStatus:         "paid_off",
+	}, nil)
+	transactionUsecase.On("TransitionTransactionStatus", mock.Anything, mock.Anything, domain.TransactionStatusCancelled, "").Return(usecase.ErrInvalidStatusTransition)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code, "Expected HTTP 409 Conflict")
+	assert.Contains(t, w.Body.String(), `"error":"invalid transaction status transition"`)
+}
+
+func TestWriteOffTransaction_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/:id/write-off", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.WriteOffTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/transactions/1/write-off", bytes.NewBufferString(`{"reason":"uncollectible after 180 days"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
+		TransactionContractNumber: "TX123456",
+		TransactionStatus:         "active",
+	}, nil)
+	transactionUsecase.On("TransitionTransactionStatus", uint(1), mock.Anything, domain.TransactionStatusWrittenOff, "uncollectible after 180 days").Return(nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"message":"Transaction written off successfully"`)
+	transactionUsecase.AssertExpectations(t)
+}
+
+func TestPayOffTransaction_Unauthorized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/:id/pay-off", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 2, UserRole: "user"})
+		transactionHandler.PayOffTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/transactions/1/pay-off", nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code, "Expected HTTP 401 Unauthorized")
+	transactionUsecase.AssertNotCalled(t, "TransitionTransactionStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetTransactionStatusHistory_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.GET("/transactions/:id/history", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.GetTransactionStatusHistory(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/1/history", nil)
+
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX123456",
+		TransactionStatus:         "cancelled",
+	}, nil)
+	transactionUsecase.On("GetTransactionStatusHistory", uint(1)).Return([]domain.TransactionStatusHistory{
+		{HistoryID: 1, HistoryTransactionID: 1, HistoryToStatus: "active"},
+		{HistoryID: 2, HistoryTransactionID: 1, HistoryFromStatus: "active", HistoryToStatus: "cancelled"},
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"transaction_status":"cancelled"`)
+	assert.Contains(t, w.Body.String(), `"history_from_status":"active"`)
+}
+
+func TestSimulateTransaction_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/simulate", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.SimulateTransaction(c)
+	})
+
+	body := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_otr": 1200000,
+		"transaction_installment": 3,
+		"transaction_asset_name": "Laptop"
+	}`
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/transactions/simulate", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456"}, nil)
+	transactionUsecase.On("GetProductByCode", "electronics").Return(&domain.Product{ProductCode: "electronics", ProductActive: true}, nil)
+	transactionUsecase.On("SimulateTransaction", mock.Anything, mock.Anything, mock.Anything).Return([]domain.TransactionSimulation{
+		{SimulationTenor: 3, SimulationRequested: true, SimulationTotalPayable: money.New(1296000), SimulationEligible: true},
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"simulation_total_payable":"1296000.00"`)
+	transactionUsecase.AssertNotCalled(t, "CreateTransactionWithLimitUpdate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSimulateTransaction_CustomerNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/simulate", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.SimulateTransaction(c)
+	})
+
+	body := `{
+		"transaction_nik": "1234567890123456",
+		"transaction_product": "electronics",
+		"transaction_otr": 1200000,
+		"transaction_installment": 3,
+		"transaction_asset_name": "Laptop"
+	}`
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/transactions/simulate", bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetCustomerByNIK", "1234567890123456").Return(nil, errors.New("record not found"))
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code, "Expected HTTP 404 Not Found")
+}
+
+func TestRestructureTransaction_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/:id/restructure", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.RestructureTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"restructure_type":"extend_tenor","restructure_tenor":6,"restructure_reason":"income reduced"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/restructure", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionContractNumber: "CTR-1", TransactionStatus: "active"}
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(transaction, nil)
+	transactionUsecase.On("RestructureTransaction", uint(1), transaction, mock.Anything).Return(&domain.Transaction{
+		TransactionID:             2,
+		TransactionContractNumber: "CTR-1-V2",
+		TransactionVersion:        2,
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"transaction_contract_number":"CTR-1-V2"`)
+}
+
+func TestRestructureTransaction_HolidayRequiresMonths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/:id/restructure", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.RestructureTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"restructure_type":"payment_holiday","restructure_tenor":3,"restructure_reason":"hospitalised"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/restructure", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{TransactionID: 1}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	transactionUsecase.AssertNotCalled(t, "RestructureTransaction", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestructureTransaction_InterestOverrideForbidden(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/:id/restructure", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.RestructureTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"restructure_type":"extend_tenor","restructure_tenor":6,"restructure_interest":0.5,"restructure_reason":"rate relief"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/restructure", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(&domain.Transaction{TransactionID: 1}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code, "Expected HTTP 403 Forbidden")
+	assert.Contains(t, w.Body.String(), usecase.ErrPricingOverrideNotAllowed.Error())
+	transactionUsecase.AssertNotCalled(t, "RestructureTransaction", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestructureTransaction_HasArrears(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/:id/restructure", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.RestructureTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"restructure_type":"extend_tenor","restructure_tenor":6,"restructure_reason":"income reduced"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/restructure", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionContractNumber: "CTR-1"}
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(transaction, nil)
+	transactionUsecase.On("RestructureTransaction", uint(1), transaction, mock.Anything).Return(nil, usecase.ErrRestructureHasArrears)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), usecase.ErrRestructureHasArrears.Error())
+}
+
+func TestRestructureTransaction_AffordabilityRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.POST("/transactions/:id/restructure", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1, UserRole: "admin"})
+		transactionHandler.RestructureTransaction(c)
+	})
+
+	w := httptest.NewRecorder()
+	reqBody := `{"restructure_type":"extend_tenor","restructure_tenor":6,"restructure_reason":"income reduced"}`
+	req, _ := http.NewRequest("POST", "/transactions/1/restructure", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionContractNumber: "CTR-1"}
+	transactionUsecase.On("GetTransactionByID", uint(1)).Return(transaction, nil)
+	transactionUsecase.On("RestructureTransaction", uint(1), transaction, mock.Anything).Return(nil, &usecase.AffordabilityError{
+		Rejection: domain.AffordabilityRejection{CustomerNIK: "1234567890123456", Reason: domain.AffordabilityReasonRatioExceeded},
+	})
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Expected HTTP 400 Bad Request")
+	assert.Contains(t, w.Body.String(), `"rejection"`)
+}
+
+func TestGetTransactionVersions_Success(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+
+	transactionUsecase := new(mocks.TransactionUsecase)
+	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
+
+	router.GET("/transactions/:id/versions", func(c *gin.Context) {
+		c.Set("user", domain.User{UserID: 1})
+		transactionHandler.GetTransactionVersions(c)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/transactions/2/versions", nil)
+
+	transaction := &domain.Transaction{TransactionID: 2, TransactionContractNumber: "CTR-1-V2", TransactionOriginalNumber: "CTR-1"}
+	transactionUsecase.On("GetTransactionByID", uint(2)).Return(transaction, nil)
+	transactionUsecase.On("GetTransactionVersions", transaction).Return([]domain.Transaction{
+		{TransactionID: 1, TransactionContractNumber: "CTR-1", TransactionVersion: 1},
+		{TransactionID: 2, TransactionContractNumber: "CTR-1-V2", TransactionVersion: 2},
+	}, []domain.TransactionRestructure{
+		{RestructureID: 1, RestructureFromTransactionID: 1, RestructureToTransactionID: 2, RestructureType: "extend_tenor"},
+	}, nil)
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code, "Expected HTTP 200 OK")
+	assert.Contains(t, w.Body.String(), `"transaction_original_number":"CTR-1"`)
+	assert.Contains(t, w.Body.String(), `"restructure_type":"extend_tenor"`)
 }
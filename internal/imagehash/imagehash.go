@@ -0,0 +1,101 @@
+package imagehash
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math/bits"
+	"os"
+	"strconv"
+)
+
+const (
+	hashWidth  = 9
+	hashHeight = 8
+)
+
+var ErrInvalidHash = errors.New("invalid perceptual hash")
+
+func DifferenceHash(img image.Image) uint64 {
+	var pixels [hashHeight][hashWidth]float64
+
+	bounds := img.Bounds()
+	cellWidth := float64(bounds.Dx()) / hashWidth
+	cellHeight := float64(bounds.Dy()) / hashHeight
+
+	for row := 0; row < hashHeight; row++ {
+		for col := 0; col < hashWidth; col++ {
+			minX := bounds.Min.X + int(float64(col)*cellWidth)
+			maxX := max(bounds.Min.X+int(float64(col+1)*cellWidth), minX+1)
+			minY := bounds.Min.Y + int(float64(row)*cellHeight)
+			maxY := max(bounds.Min.Y+int(float64(row+1)*cellHeight), minY+1)
+
+			var sum float64
+			var count int
+			for y := minY; y < maxY && y < bounds.Max.Y; y++ {
+				for x := minX; x < maxX && x < bounds.Max.X; x++ {
+					r, g, b, _ := img.At(x, y).RGBA()
+					sum += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+					count++
+				}
+			}
+			if count > 0 {
+				pixels[row][col] = sum / float64(count)
+			}
+		}
+	}
+
+	var hash uint64
+	for row := 0; row < hashHeight; row++ {
+		for col := 0; col < hashWidth-1; col++ {
+			hash <<= 1
+			if pixels[row][col] > pixels[row][col+1] {
+				hash |= 1
+			}
+		}
+	}
+	return hash
+}
+
+func FromFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return "", err
+	}
+	return Format(DifferenceHash(img)), nil
+}
+
+func Format(hash uint64) string {
+	return fmt.Sprintf("%016x", hash)
+}
+
+func Parse(hash string) (uint64, error) {
+	if len(hash) != 16 {
+		return 0, ErrInvalidHash
+	}
+	value, err := strconv.ParseUint(hash, 16, 64)
+	if err != nil {
+		return 0, ErrInvalidHash
+	}
+	return value, nil
+}
+
+func Distance(a, b string) (int, error) {
+	left, err := Parse(a)
+	if err != nil {
+		return 0, err
+	}
+	right, err := Parse(b)
+	if err != nil {
+		return 0, err
+	}
+	return bits.OnesCount64(left ^ right), nil
+}
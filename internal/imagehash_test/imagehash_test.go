@@ -0,0 +1,69 @@
+package imagehash_test
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"kreditplus/internal/imagehash"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func gradient(width, height int, brightness int, reverse bool) *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			fx := float64(x) / float64(width)
+			fy := float64(y) / float64(height)
+			value := int(100 + 80*math.Sin(3*math.Pi*fx)*math.Cos(2*math.Pi*fy))
+			if reverse {
+				value = 200 - value
+			}
+			img.SetGray(x, y, color.Gray{Y: uint8(min(value+brightness, 255))})
+		}
+	}
+	return img
+}
+
+func TestDifferenceHash_IgnoresScaleAndBrightness(t *testing.T) {
+	original := imagehash.Format(imagehash.DifferenceHash(gradient(180, 160, 0, false)))
+	rescaled := imagehash.Format(imagehash.DifferenceHash(gradient(360, 320, 20, false)))
+
+	distance, err := imagehash.Distance(original, rescaled)
+
+	assert.Nil(t, err)
+	assert.LessOrEqual(t, distance, 10)
+}
+
+func TestDifferenceHash_DistinguishesDifferentImages(t *testing.T) {
+	original := imagehash.Format(imagehash.DifferenceHash(gradient(180, 160, 0, false)))
+	different := imagehash.Format(imagehash.DifferenceHash(gradient(180, 160, 0, true)))
+
+	distance, err := imagehash.Distance(original, different)
+
+	assert.Nil(t, err)
+	assert.Greater(t, distance, 20)
+}
+
+func TestFromFile_HashesPNG(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ktp.png")
+	file, _ := os.Create(path)
+	_ = png.Encode(file, gradient(90, 80, 0, false))
+	file.Close()
+
+	hash, err := imagehash.FromFile(path)
+
+	assert.Nil(t, err)
+	assert.Len(t, hash, 16)
+	assert.Equal(t, imagehash.Format(imagehash.DifferenceHash(gradient(90, 80, 0, false))), hash)
+}
+
+func TestDistance_InvalidHash(t *testing.T) {
+	_, err := imagehash.Distance("zz", "0000000000000000")
+
+	assert.ErrorIs(t, err, imagehash.ErrInvalidHash)
+}
@@ -0,0 +1,26 @@
+package job
+
+import (
+	"fmt"
+	"kreditplus/config"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/usecase"
+)
+
+func MergeDuplicateLimits() error {
+	limitRepo := repository.NewLimitRepository(config.DB)
+	customerRepo := repository.NewCustomerRepository(config.DB)
+	transactionRepo := repository.NewTransactionRepository(config.DB, config.LoadTransactionRetryConfig())
+	productRepo := repository.NewProductRepository(config.DB)
+	negativeListRepo := repository.NewNegativeListRepository(config.DB)
+	limitUsecase := usecase.NewLimitUsecase(limitRepo, customerRepo, transactionRepo, productRepo, negativeListRepo)
+
+	result, err := limitUsecase.MergeDuplicateLimits()
+	if err != nil {
+		return err
+	}
+	if result.FailedKeys > 0 {
+		return fmt.Errorf("failed to merge %d duplicate limit keys", result.FailedKeys)
+	}
+	return nil
+}
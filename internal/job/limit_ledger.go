@@ -0,0 +1,19 @@
+package job
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+)
+
+func OpenLimitLedgers() error {
+	opened, err := repository.NewLimitRepository(config.DB).CreateOpeningLimitMovements()
+	if err != nil {
+		utils.Logger.WithError(err).Error("Failed to record opening limit movements")
+		return err
+	}
+	if opened > 0 {
+		utils.Logger.WithField("opened_limits", opened).Info("Opening balance recorded for limits without movements")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package job
+
+import (
+	"encoding/json"
+	"flag"
+	"kreditplus/config"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"os"
+)
+
+func RunLimitReconciliation(args []string) int {
+	flags := flag.NewFlagSet("reconcile-limits", flag.ContinueOnError)
+	repair := flags.Bool("repair", false, "write an adjustment movement for every mismatched limit")
+	username := flags.String("user", "admin", "user recorded as the author of repair adjustments")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	user, err := repository.NewUserRepository(config.DB).GetUserByUsername(*username)
+	if err != nil {
+		utils.Logger.WithError(err).Errorf("Limit reconciliation user %s not found", *username)
+		return 1
+	}
+
+	limitRepo := repository.NewLimitRepository(config.DB)
+	transactionRepo := repository.NewTransactionRepository(config.DB, config.LoadTransactionRetryConfig())
+	installmentRepo := repository.NewInstallmentRepository(config.DB)
+	reconciliationUsecase := usecase.NewLimitReconciliationUsecase(limitRepo, transactionRepo, installmentRepo)
+
+	result, err := reconciliationUsecase.ReconcileLimits(user.UserID, *repair)
+	if err != nil {
+		return 1
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return 1
+	}
+
+	if result.FailedLimits > 0 || result.MismatchedLimits > result.RepairedLimits {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,34 @@
+package job
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/usecase"
+	"kreditplus/internal/utils"
+	"time"
+)
+
+const overdueJobInterval = 24 * time.Hour
+
+func StartOverdueJob() {
+	installmentRepo := repository.NewInstallmentRepository(config.DB)
+	transactionRepo := repository.NewTransactionRepository(config.DB, config.LoadTransactionRetryConfig())
+	overdueUsecase := usecase.NewOverdueUsecase(installmentRepo, transactionRepo, config.LoadPenaltyConfig())
+
+	go func() {
+		for {
+			if _, err := overdueUsecase.RunOverdueJob(time.Now()); err != nil {
+				utils.Logger.WithError(err).Error("Overdue job run failed")
+			}
+			time.Sleep(untilNextRun(time.Now()))
+		}
+	}()
+}
+
+func untilNextRun(now time.Time) time.Duration {
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 5, 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.Add(overdueJobInterval)
+	}
+	return next.Sub(now)
+}
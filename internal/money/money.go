@@ -0,0 +1,229 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const senPerRupiah = 100
+
+var ErrInvalidAmount = errors.New("invalid money amount")
+
+type Money struct {
+	sen int64
+}
+
+func New(rupiah int64) Money {
+	return Money{sen: rupiah * senPerRupiah}
+}
+
+func FromSen(sen int64) Money {
+	return Money{sen: sen}
+}
+
+func FromFloat(value float64) Money {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return Money{}
+	}
+	return Money{sen: int64(math.Round(value * senPerRupiah))}
+}
+
+func Parse(value string) (Money, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Money{}, ErrInvalidAmount
+	}
+
+	negative := strings.HasPrefix(value, "-")
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "-"), "+")
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" {
+		whole = "0"
+	}
+	if len(fraction) > 2 || strings.ContainsAny(whole+fraction, "+-eE") {
+		return Money{}, ErrInvalidAmount
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	rupiah, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+	sen, err := strconv.ParseInt(fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+
+	m := Money{sen: rupiah*senPerRupiah + sen}
+	if negative {
+		m.sen = -m.sen
+	}
+	return m, nil
+}
+
+func (m Money) Sen() int64 {
+	return m.sen
+}
+
+func (m Money) Float64() float64 {
+	return float64(m.sen) / senPerRupiah
+}
+
+func (m Money) Add(other Money) Money {
+	return Money{sen: m.sen + other.sen}
+}
+
+func (m Money) Sub(other Money) Money {
+	return Money{sen: m.sen - other.sen}
+}
+
+func (m Money) Neg() Money {
+	return Money{sen: -m.sen}
+}
+
+func (m Money) Mul(factor float64) Money {
+	return Money{sen: int64(math.Round(float64(m.sen) * factor))}
+}
+
+func (m Money) Percent(rate float64) Money {
+	return m.Mul(rate / 100)
+}
+
+func (m Money) Div(parts int) Money {
+	if parts <= 0 {
+		return m
+	}
+	return Money{sen: int64(math.Round(float64(m.sen) / float64(parts)))}
+}
+
+// RoundRupiah rounds half away from zero to a whole rupiah, the unit used for
+// installments, interest, fees and penalties.
+func (m Money) RoundRupiah() Money {
+	return Money{sen: int64(math.Round(float64(m.sen)/senPerRupiah)) * senPerRupiah}
+}
+
+func (m Money) Cmp(other Money) int {
+	switch {
+	case m.sen < other.sen:
+		return -1
+	case m.sen > other.sen:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (m Money) GreaterThan(other Money) bool {
+	return m.sen > other.sen
+}
+
+func (m Money) LessThan(other Money) bool {
+	return m.sen < other.sen
+}
+
+func (m Money) IsZero() bool {
+	return m.sen == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.sen > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.sen < 0
+}
+
+func Min(a, b Money) Money {
+	if a.sen < b.sen {
+		return a
+	}
+	return b
+}
+
+func Max(a, b Money) Money {
+	if a.sen > b.sen {
+		return a
+	}
+	return b
+}
+
+func (m Money) String() string {
+	sen := m.sen
+	sign := ""
+	if sen < 0 {
+		sign = "-"
+		sen = -sen
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, sen/senPerRupiah, sen%senPerRupiah)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.String())
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	value := strings.TrimSpace(string(data))
+	if value == "null" {
+		*m = Money{}
+		return nil
+	}
+
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	} else if f, err := strconv.ParseFloat(value, 64); err == nil {
+		*m = FromFloat(f)
+		return nil
+	}
+
+	parsed, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*m = Money{}
+	case []byte:
+		return m.scanString(string(v))
+	case string:
+		return m.scanString(v)
+	case float64:
+		*m = FromFloat(v)
+	case int64:
+		*m = New(v)
+	default:
+		return fmt.Errorf("money: cannot scan %T", value)
+	}
+	return nil
+}
+
+func (m *Money) scanString(value string) error {
+	parsed, err := Parse(value)
+	if err != nil {
+		f, ferr := strconv.ParseFloat(value, 64)
+		if ferr != nil {
+			return err
+		}
+		parsed = FromFloat(f)
+	}
+	*m = parsed
+	return nil
+}
+
+func (Money) GormDataType() string {
+	return "decimal(15,2)"
+}
@@ -0,0 +1,103 @@
+package money_test
+
+import (
+	"encoding/json"
+	"kreditplus/internal/money"
+	"kreditplus/internal/utils"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	amount, err := money.Parse("1500000.5")
+	assert.Nil(t, err)
+	assert.Equal(t, int64(150000050), amount.Sen())
+	assert.Equal(t, "1500000.50", amount.String())
+
+	amount, err = money.Parse("-12.05")
+	assert.Nil(t, err)
+	assert.Equal(t, "-12.05", amount.String())
+
+	_, err = money.Parse("1.005")
+	assert.ErrorIs(t, err, money.ErrInvalidAmount, "Sub-sen precision should be rejected")
+
+	_, err = money.Parse("abc")
+	assert.ErrorIs(t, err, money.ErrInvalidAmount)
+}
+
+func TestArithmetic_DoesNotDrift(t *testing.T) {
+	remaining := money.New(5000000)
+	tenth := money.FromFloat(0.1)
+	for i := 0; i < 1000; i++ {
+		remaining = remaining.Sub(tenth)
+	}
+	for i := 0; i < 1000; i++ {
+		remaining = remaining.Add(tenth)
+	}
+
+	assert.Equal(t, money.New(5000000), remaining, "Repeated add/sub should be exact")
+}
+
+func TestRoundRupiah(t *testing.T) {
+	assert.Equal(t, money.New(333333), money.New(1000000).Div(3).RoundRupiah())
+	assert.Equal(t, money.New(3), money.FromFloat(2.5).RoundRupiah(), "Halves round away from zero")
+	assert.Equal(t, money.New(-3), money.FromFloat(-2.5).RoundRupiah())
+	assert.Equal(t, money.New(2000), money.New(100000).Percent(2))
+}
+
+func TestJSON_SerializesAsString(t *testing.T) {
+	payload, err := json.Marshal(struct {
+		Amount money.Money `json:"amount"`
+	}{Amount: money.FromSen(123456789)})
+
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"amount":"1234567.89"}`, string(payload))
+}
+
+func TestJSON_AcceptsStringAndNumber(t *testing.T) {
+	var input struct {
+		Text   money.Money `json:"text"`
+		Number money.Money `json:"number"`
+		Empty  money.Money `json:"empty"`
+	}
+
+	err := json.Unmarshal([]byte(`{"text":"2500000.75","number":150000,"empty":null}`), &input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.FromSen(250000075), input.Text)
+	assert.Equal(t, money.New(150000), input.Number)
+	assert.True(t, input.Empty.IsZero())
+
+	err = json.Unmarshal([]byte(`{"text":"1.2.3"}`), &input)
+	assert.NotNil(t, err)
+}
+
+func TestScan(t *testing.T) {
+	var amount money.Money
+
+	assert.Nil(t, amount.Scan([]byte("5000000.00")))
+	assert.Equal(t, money.New(5000000), amount)
+
+	assert.Nil(t, amount.Scan(float64(12.5)))
+	assert.Equal(t, money.FromSen(1250), amount)
+
+	assert.Nil(t, amount.Scan(nil))
+	assert.True(t, amount.IsZero())
+
+	assert.NotNil(t, amount.Scan(true))
+
+	value, err := money.FromSen(1250).Value()
+	assert.Nil(t, err)
+	assert.Equal(t, "12.50", value)
+}
+
+func TestValidate_UsesAmountValue(t *testing.T) {
+	type input struct {
+		Salary money.Money `validate:"required,gte=1000000"`
+	}
+
+	assert.NotNil(t, utils.Validate.Struct(input{}), "Zero amount should fail required")
+	assert.NotNil(t, utils.Validate.Struct(input{Salary: money.New(999999)}))
+	assert.Nil(t, utils.Validate.Struct(input{Salary: money.New(1000000)}))
+}
@@ -0,0 +1,147 @@
+package nik
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	GenderMale   = "male"
+	GenderFemale = "female"
+
+	femaleDayOffset = 40
+)
+
+var (
+	ErrInvalidFormat    = errors.New("NIK must be 16 numeric characters")
+	ErrUnknownProvince  = errors.New("NIK province code is not recognised")
+	ErrInvalidRegion    = errors.New("NIK regency or district code is invalid")
+	ErrInvalidBirthDate = errors.New("NIK encodes an impossible date of birth")
+	ErrInvalidSerial    = errors.New("NIK serial number is invalid")
+)
+
+type NIK struct {
+	Number       string    `json:"number"`
+	ProvinceCode string    `json:"province_code"`
+	ProvinceName string    `json:"province_name"`
+	RegencyCode  string    `json:"regency_code"`
+	RegencyName  string    `json:"regency_name,omitempty"`
+	DistrictCode string    `json:"district_code"`
+	BirthDate    time.Time `json:"birth_date"`
+	Gender       string    `json:"gender"`
+	Serial       string    `json:"serial"`
+}
+
+type Mismatch struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+	Message  string `json:"message"`
+}
+
+func Parse(number string) (*NIK, error) {
+	return ParseAt(number, time.Now())
+}
+
+func ParseAt(number string, at time.Time) (*NIK, error) {
+	if len(number) != 16 {
+		return nil, ErrInvalidFormat
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return nil, ErrInvalidFormat
+		}
+	}
+
+	parsed := &NIK{
+		Number:       number,
+		ProvinceCode: number[0:2],
+		RegencyCode:  number[0:4],
+		DistrictCode: number[0:6],
+		Serial:       number[12:16],
+		Gender:       GenderMale,
+	}
+
+	provinceName, ok := provinces[parsed.ProvinceCode]
+	if !ok {
+		return nil, ErrUnknownProvince
+	}
+	parsed.ProvinceName = provinceName
+	parsed.RegencyName = regencies[parsed.RegencyCode]
+
+	if number[2:4] == "00" || number[4:6] == "00" {
+		return nil, ErrInvalidRegion
+	}
+
+	if parsed.Serial == "0000" {
+		return nil, ErrInvalidSerial
+	}
+
+	day, _ := strconv.Atoi(number[6:8])
+	month, _ := strconv.Atoi(number[8:10])
+	year, _ := strconv.Atoi(number[10:12])
+	if day > femaleDayOffset {
+		day -= femaleDayOffset
+		parsed.Gender = GenderFemale
+	}
+
+	birthDate, ok := birthDateAt(day, month, year, at)
+	if !ok {
+		return nil, ErrInvalidBirthDate
+	}
+	parsed.BirthDate = birthDate
+
+	return parsed, nil
+}
+
+func (n *NIK) CrossCheck(birthDate time.Time, birthPlace string) []Mismatch {
+	var mismatches []Mismatch
+
+	if !birthDate.IsZero() && (birthDate.Day() != n.BirthDate.Day() || birthDate.Month() != n.BirthDate.Month() || birthDate.Year()%100 != n.BirthDate.Year()%100) {
+		mismatches = append(mismatches, Mismatch{
+			Field:    "customer_birth_date",
+			Expected: n.BirthDate.Format("2006-01-02"),
+			Actual:   birthDate.Format("2006-01-02"),
+			Message:  "birth date does not match the date encoded in the NIK",
+		})
+	}
+
+	if birthPlace != "" {
+		if provinceCode, ok := ResolvePlaceProvince(birthPlace); ok && provinceCode != n.ProvinceCode {
+			actual := provinces[provinceCode]
+			mismatches = append(mismatches, Mismatch{
+				Field:    "customer_birth_place",
+				Expected: n.ProvinceName,
+				Actual:   birthPlace + " (" + actual + ")",
+				Message:  "birth place is outside the province encoded in the NIK",
+			})
+		}
+	}
+
+	return mismatches
+}
+
+func birthDateAt(day, month, year int, at time.Time) (time.Time, bool) {
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+
+	century := at.Year() / 100 * 100
+	fullYear := century + year
+	if fullYear > at.Year() {
+		fullYear -= 100
+	}
+
+	birthDate := time.Date(fullYear, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if birthDate.Day() != day {
+		return time.Time{}, false
+	}
+	if birthDate.After(at) {
+		birthDate = birthDate.AddDate(-100, 0, 0)
+		if birthDate.Day() != day {
+			return time.Time{}, false
+		}
+	}
+	return birthDate, true
+}
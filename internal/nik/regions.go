@@ -0,0 +1,138 @@
+package nik
+
+import "strings"
+
+var provinces = map[string]string{
+	"11": "Aceh",
+	"12": "Sumatera Utara",
+	"13": "Sumatera Barat",
+	"14": "Riau",
+	"15": "Jambi",
+	"16": "Sumatera Selatan",
+	"17": "Bengkulu",
+	"18": "Lampung",
+	"19": "Kepulauan Bangka Belitung",
+	"21": "Kepulauan Riau",
+	"31": "DKI Jakarta",
+	"32": "Jawa Barat",
+	"33": "Jawa Tengah",
+	"34": "DI Yogyakarta",
+	"35": "Jawa Timur",
+	"36": "Banten",
+	"51": "Bali",
+	"52": "Nusa Tenggara Barat",
+	"53": "Nusa Tenggara Timur",
+	"61": "Kalimantan Barat",
+	"62": "Kalimantan Tengah",
+	"63": "Kalimantan Selatan",
+	"64": "Kalimantan Timur",
+	"65": "Kalimantan Utara",
+	"71": "Sulawesi Utara",
+	"72": "Sulawesi Tengah",
+	"73": "Sulawesi Selatan",
+	"74": "Sulawesi Tenggara",
+	"75": "Gorontalo",
+	"76": "Sulawesi Barat",
+	"81": "Maluku",
+	"82": "Maluku Utara",
+	"91": "Papua",
+	"92": "Papua Barat",
+	"93": "Papua Selatan",
+	"94": "Papua Tengah",
+	"95": "Papua Pegunungan",
+	"96": "Papua Barat Daya",
+}
+
+var regencies = map[string]string{
+	"1171": "Kota Banda Aceh",
+	"1275": "Kota Medan",
+	"1371": "Kota Padang",
+	"1471": "Kota Pekanbaru",
+	"1571": "Kota Jambi",
+	"1671": "Kota Palembang",
+	"1771": "Kota Bengkulu",
+	"1871": "Kota Bandar Lampung",
+	"2171": "Kota Batam",
+	"3101": "Kabupaten Kepulauan Seribu",
+	"3171": "Kota Jakarta Selatan",
+	"3172": "Kota Jakarta Timur",
+	"3173": "Kota Jakarta Pusat",
+	"3174": "Kota Jakarta Barat",
+	"3175": "Kota Jakarta Utara",
+	"3201": "Kabupaten Bogor",
+	"3204": "Kabupaten Bandung",
+	"3216": "Kabupaten Bekasi",
+	"3271": "Kota Bogor",
+	"3272": "Kota Sukabumi",
+	"3273": "Kota Bandung",
+	"3274": "Kota Cirebon",
+	"3275": "Kota Bekasi",
+	"3276": "Kota Depok",
+	"3277": "Kota Cimahi",
+	"3371": "Kota Magelang",
+	"3372": "Kota Surakarta",
+	"3374": "Kota Semarang",
+	"3402": "Kabupaten Bantul",
+	"3404": "Kabupaten Sleman",
+	"3471": "Kota Yogyakarta",
+	"3515": "Kabupaten Sidoarjo",
+	"3573": "Kota Malang",
+	"3578": "Kota Surabaya",
+	"3603": "Kabupaten Tangerang",
+	"3671": "Kota Tangerang",
+	"3672": "Kota Cilegon",
+	"3673": "Kota Serang",
+	"3674": "Kota Tangerang Selatan",
+	"5103": "Kabupaten Badung",
+	"5171": "Kota Denpasar",
+	"5271": "Kota Mataram",
+	"5371": "Kota Kupang",
+	"6171": "Kota Pontianak",
+	"6371": "Kota Banjarmasin",
+	"6471": "Kota Balikpapan",
+	"6472": "Kota Samarinda",
+	"7171": "Kota Manado",
+	"7371": "Kota Makassar",
+	"8171": "Kota Ambon",
+	"9171": "Kota Jayapura",
+}
+
+var placeAliases = map[string]string{
+	"jakarta": "31",
+	"jogja":   "34",
+	"solo":    "33",
+}
+
+var placeProvinces = buildPlaceProvinces()
+
+func buildPlaceProvinces() map[string]string {
+	places := make(map[string]string, len(provinces)+len(regencies)+len(placeAliases))
+	for code, name := range provinces {
+		places[normalizePlace(name)] = code
+	}
+	for code, name := range regencies {
+		places[normalizePlace(name)] = code[:2]
+	}
+	for alias, code := range placeAliases {
+		places[alias] = code
+	}
+	return places
+}
+
+func normalizePlace(place string) string {
+	place = strings.ToLower(strings.Join(strings.Fields(place), " "))
+	for _, prefix := range []string{"kota administrasi ", "kabupaten ", "kab. ", "kab ", "kota ", "provinsi "} {
+		place = strings.TrimPrefix(place, prefix)
+	}
+	return place
+}
+
+func ProvinceName(code string) (string, bool) {
+	name, ok := provinces[code]
+	return name, ok
+}
+
+func ResolvePlaceProvince(place string) (string, bool) {
+	code, ok := placeProvinces[normalizePlace(place)]
+	return code, ok
+}
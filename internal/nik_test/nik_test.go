@@ -0,0 +1,90 @@
+package nik_test
+
+import (
+	"kreditplus/internal/nik"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var parsedAt = time.Date(2025, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+func TestParse_DecodesMaleNIK(t *testing.T) {
+	parsed, err := nik.ParseAt("3273011203850002", parsedAt)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "32", parsed.ProvinceCode)
+	assert.Equal(t, "Jawa Barat", parsed.ProvinceName)
+	assert.Equal(t, "3273", parsed.RegencyCode)
+	assert.Equal(t, "Kota Bandung", parsed.RegencyName)
+	assert.Equal(t, "327301", parsed.DistrictCode)
+	assert.Equal(t, time.Date(1985, time.March, 12, 0, 0, 0, 0, time.UTC), parsed.BirthDate)
+	assert.Equal(t, nik.GenderMale, parsed.Gender)
+	assert.Equal(t, "0002", parsed.Serial)
+}
+
+func TestParse_DecodesFemaleDayOffset(t *testing.T) {
+	parsed, err := nik.ParseAt("3171014509000001", parsedAt)
+
+	assert.Nil(t, err)
+	assert.Equal(t, nik.GenderFemale, parsed.Gender)
+	assert.Equal(t, time.Date(2000, time.September, 5, 0, 0, 0, 0, time.UTC), parsed.BirthDate)
+}
+
+func TestParse_ResolvesCenturyFromParseDate(t *testing.T) {
+	parsed, err := nik.ParseAt("3171011007250001", parsedAt)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1925, parsed.BirthDate.Year(), "A July 2025 birth date would be in the future")
+}
+
+func TestParse_RejectsImpossibleNIKs(t *testing.T) {
+	cases := map[string]error{
+		"31710145099":      nik.ErrInvalidFormat,
+		"31710145090A0001": nik.ErrInvalidFormat,
+		"2071014509900001": nik.ErrUnknownProvince,
+		"3100014509900001": nik.ErrInvalidRegion,
+		"3171004509900001": nik.ErrInvalidRegion,
+		"3171013202900001": nik.ErrInvalidBirthDate,
+		"3171017202900001": nik.ErrInvalidBirthDate,
+		"3171011513900001": nik.ErrInvalidBirthDate,
+		"3171012902010001": nik.ErrInvalidBirthDate,
+		"3171014509900000": nik.ErrInvalidSerial,
+	}
+
+	for number, expected := range cases {
+		parsed, err := nik.ParseAt(number, parsedAt)
+
+		assert.Nil(t, parsed, number)
+		assert.ErrorIs(t, err, expected, number)
+	}
+}
+
+func TestCrossCheck_Matches(t *testing.T) {
+	parsed, _ := nik.ParseAt("3171014509900001", parsedAt)
+
+	mismatches := parsed.CrossCheck(time.Date(1990, time.September, 5, 0, 0, 0, 0, time.UTC), "Kota Jakarta Selatan")
+
+	assert.Empty(t, mismatches)
+}
+
+func TestCrossCheck_ReportsFieldMismatches(t *testing.T) {
+	parsed, _ := nik.ParseAt("3171014509900001", parsedAt)
+
+	mismatches := parsed.CrossCheck(time.Date(1990, time.May, 9, 0, 0, 0, 0, time.UTC), "kab. sleman")
+
+	assert.Len(t, mismatches, 2)
+	assert.Equal(t, "customer_birth_date", mismatches[0].Field)
+	assert.Equal(t, "1990-09-05", mismatches[0].Expected)
+	assert.Equal(t, "customer_birth_place", mismatches[1].Field)
+	assert.Equal(t, "DKI Jakarta", mismatches[1].Expected)
+}
+
+func TestCrossCheck_SkipsUnknownBirthPlace(t *testing.T) {
+	parsed, _ := nik.ParseAt("3171014509900001", parsedAt)
+
+	mismatches := parsed.CrossCheck(time.Time{}, "Tokyo")
+
+	assert.Empty(t, mismatches)
+}
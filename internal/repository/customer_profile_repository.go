@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"kreditplus/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type CustomerProfileRepository interface {
+	GetCustomerAddressByID(customerID, id uint) (*domain.CustomerAddress, error)
+	SaveCustomerAddress(address *domain.CustomerAddress) error
+	DeleteCustomerAddress(address *domain.CustomerAddress) error
+	GetCustomerPhoneByID(customerID, id uint) (*domain.CustomerPhoneNumber, error)
+	SaveCustomerPhone(phone *domain.CustomerPhoneNumber) error
+	DeleteCustomerPhone(phone *domain.CustomerPhoneNumber) error
+	GetCustomerEmailByID(customerID, id uint) (*domain.CustomerEmailAddress, error)
+	SaveCustomerEmail(email *domain.CustomerEmailAddress) error
+	DeleteCustomerEmail(email *domain.CustomerEmailAddress) error
+	GetCustomerEmployment(customerID uint) (*domain.CustomerEmployment, error)
+	SaveCustomerEmployment(employment *domain.CustomerEmployment) error
+	DeleteCustomerEmployment(employment *domain.CustomerEmployment) error
+	GetCustomerEmergencyContactByID(customerID, id uint) (*domain.CustomerEmergencyContact, error)
+	SaveCustomerEmergencyContact(contact *domain.CustomerEmergencyContact) error
+	DeleteCustomerEmergencyContact(contact *domain.CustomerEmergencyContact) error
+}
+
+type customerProfileRepository struct {
+	db *gorm.DB
+}
+
+func NewCustomerProfileRepository(db *gorm.DB) CustomerProfileRepository {
+	return &customerProfileRepository{db: db}
+}
+
+func (r *customerProfileRepository) GetCustomerAddressByID(customerID, id uint) (*domain.CustomerAddress, error) {
+	var address domain.CustomerAddress
+	err := r.db.Where("address_customer_id = ?", customerID).
+		First(&address, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+func (r *customerProfileRepository) SaveCustomerAddress(address *domain.CustomerAddress) error {
+	return r.db.Save(address).Error
+}
+
+func (r *customerProfileRepository) DeleteCustomerAddress(address *domain.CustomerAddress) error {
+	return r.db.Delete(address).Error
+}
+
+func (r *customerProfileRepository) GetCustomerPhoneByID(customerID, id uint) (*domain.CustomerPhoneNumber, error) {
+	var phone domain.CustomerPhoneNumber
+	err := r.db.Where("phone_customer_id = ?", customerID).
+		First(&phone, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &phone, nil
+}
+
+func (r *customerProfileRepository) SaveCustomerPhone(phone *domain.CustomerPhoneNumber) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		others := tx.Model(&domain.CustomerPhoneNumber{}).
+			Where("phone_customer_id = ? AND phone_id <> ?", phone.PhoneCustomerID, phone.PhoneID)
+
+		if phone.PhonePrimary {
+			if err := others.Update("phone_primary", false).Error; err != nil {
+				return err
+			}
+		} else {
+			var primaries int64
+			if err := others.Where("phone_primary = ?", true).Count(&primaries).Error; err != nil {
+				return err
+			}
+			phone.PhonePrimary = primaries == 0
+		}
+		return tx.Save(phone).Error
+	})
+}
+
+func (r *customerProfileRepository) DeleteCustomerPhone(phone *domain.CustomerPhoneNumber) error {
+	return r.db.Delete(phone).Error
+}
+
+func (r *customerProfileRepository) GetCustomerEmailByID(customerID, id uint) (*domain.CustomerEmailAddress, error) {
+	var email domain.CustomerEmailAddress
+	err := r.db.Where("email_customer_id = ?", customerID).
+		First(&email, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &email, nil
+}
+
+func (r *customerProfileRepository) SaveCustomerEmail(email *domain.CustomerEmailAddress) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		others := tx.Model(&domain.CustomerEmailAddress{}).
+			Where("email_customer_id = ? AND email_id <> ?", email.EmailCustomerID, email.EmailID)
+
+		if email.EmailPrimary {
+			if err := others.Update("email_primary", false).Error; err != nil {
+				return err
+			}
+		} else {
+			var primaries int64
+			if err := others.Where("email_primary = ?", true).Count(&primaries).Error; err != nil {
+				return err
+			}
+			email.EmailPrimary = primaries == 0
+		}
+		return tx.Save(email).Error
+	})
+}
+
+func (r *customerProfileRepository) DeleteCustomerEmail(email *domain.CustomerEmailAddress) error {
+	return r.db.Delete(email).Error
+}
+
+func (r *customerProfileRepository) GetCustomerEmployment(customerID uint) (*domain.CustomerEmployment, error) {
+	var employment domain.CustomerEmployment
+	err := r.db.Where("employment_customer_id = ?", customerID).
+		First(&employment).Error
+	if err != nil {
+		return nil, err
+	}
+	return &employment, nil
+}
+
+func (r *customerProfileRepository) SaveCustomerEmployment(employment *domain.CustomerEmployment) error {
+	return r.db.Save(employment).Error
+}
+
+func (r *customerProfileRepository) DeleteCustomerEmployment(employment *domain.CustomerEmployment) error {
+	return r.db.Delete(employment).Error
+}
+
+func (r *customerProfileRepository) GetCustomerEmergencyContactByID(customerID, id uint) (*domain.CustomerEmergencyContact, error) {
+	var contact domain.CustomerEmergencyContact
+	err := r.db.Where("contact_customer_id = ?", customerID).
+		First(&contact, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &contact, nil
+}
+
+func (r *customerProfileRepository) SaveCustomerEmergencyContact(contact *domain.CustomerEmergencyContact) error {
+	return r.db.Save(contact).Error
+}
+
+func (r *customerProfileRepository) DeleteCustomerEmergencyContact(contact *domain.CustomerEmergencyContact) error {
+	return r.db.Delete(contact).Error
+}
@@ -2,8 +2,12 @@ package repository
 
 import (
 	"kreditplus/internal/domain"
+	"kreditplus/internal/utils"
+	"slices"
+	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CustomerRepository interface {
@@ -11,7 +15,10 @@ type CustomerRepository interface {
 	GetAllCustomers(limit, offset int) ([]domain.Customer, error)
 	GetCustomerByID(id uint) (*domain.Customer, error)
 	GetCustomerByNIK(nik string) (*domain.Customer, error)
+	GetCustomersByKYCStatus(status string, limit, offset int) ([]domain.Customer, error)
+	FindDuplicateCandidates(customer *domain.Customer, maxHashDistance int) ([]domain.Customer, error)
 	UpdateCustomer(customer *domain.Customer) error
+	AssignCustomerRiskGradeIfUngraded(id uint, grade string) (bool, error)
 	DeleteCustomer(id uint) error
 }
 
@@ -44,6 +51,19 @@ func (r *customerRepository) GetCustomerByID(id uint) (*domain.Customer, error)
 	var customer domain.Customer
 	err := r.db.Preload("CreatedByUser").
 		Preload("EditedByUser").
+		Preload("CustomerAddresses", func(db *gorm.DB) *gorm.DB {
+			return db.Order("address_type")
+		}).
+		Preload("CustomerPhones", func(db *gorm.DB) *gorm.DB {
+			return db.Order("phone_primary DESC, phone_id")
+		}).
+		Preload("CustomerEmails", func(db *gorm.DB) *gorm.DB {
+			return db.Order("email_primary DESC, email_id")
+		}).
+		Preload("CustomerEmployment").
+		Preload("CustomerEmergencyContacts", func(db *gorm.DB) *gorm.DB {
+			return db.Order("contact_id")
+		}).
 		First(&customer, id).Error
 	if err != nil {
 		return nil, err
@@ -61,8 +81,76 @@ func (r *customerRepository) GetCustomerByNIK(nik string) (*domain.Customer, err
 	return &customer, nil
 }
 
+func (r *customerRepository) GetCustomersByKYCStatus(status string, limit, offset int) ([]domain.Customer, error) {
+	var customers []domain.Customer
+	err := r.db.Where("customer_kyc_status = ?", status).
+		Order("customer_kyc_submitted_at ASC").
+		Limit(limit).
+		Offset(offset).
+		Find(&customers).Error
+	if err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
+func (r *customerRepository) FindDuplicateCandidates(customer *domain.Customer, maxHashDistance int) ([]domain.Customer, error) {
+	var clauses []string
+	var args []interface{}
+
+	nameTokens := utils.NameTokens(customer.CustomerFullName + " " + customer.CustomerLegalName)
+	slices.Sort(nameTokens)
+	nameTokens = slices.Compact(nameTokens)
+	if !customer.CustomerBirthDate.IsZero() && len(nameTokens) > 0 {
+		clauses = append(clauses, "(customer_birth_date::date = ?::date AND "+
+			"(regexp_split_to_array(lower(customer_full_name), '[^[:alpha:]]+') && string_to_array(?, ' ') OR "+
+			"regexp_split_to_array(lower(customer_legal_name), '[^[:alpha:]]+') && string_to_array(?, ' ')))")
+		names := strings.Join(nameTokens, " ")
+		args = append(args, customer.CustomerBirthDate, names, names)
+	}
+	if customer.CustomerPhone != "" {
+		clauses = append(clauses, "customer_phone = ?")
+		args = append(args, customer.CustomerPhone)
+	}
+	if customer.CustomerEmail != "" {
+		clauses = append(clauses, "customer_email = ?")
+		args = append(args, customer.CustomerEmail)
+	}
+	if customer.CustomerKTPPhash != "" {
+		clauses = append(clauses, "(customer_ktp_phash <> '' AND bit_count(('x' || customer_ktp_phash)::bit(64) # ('x' || ?)::bit(64)) <= ?)")
+		args = append(args, customer.CustomerKTPPhash, maxHashDistance)
+	}
+	if customer.CustomerSelfiePhash != "" {
+		clauses = append(clauses, "(customer_selfie_phash <> '' AND bit_count(('x' || customer_selfie_phash)::bit(64) # ('x' || ?)::bit(64)) <= ?)")
+		args = append(args, customer.CustomerSelfiePhash, maxHashDistance)
+	}
+
+	if len(clauses) == 0 {
+		return nil, nil
+	}
+
+	var customers []domain.Customer
+	err := r.db.Where("customer_nik <> ?", customer.CustomerNIK).
+		Where(strings.Join(clauses, " OR "), args...).
+		Find(&customers).Error
+	if err != nil {
+		return nil, err
+	}
+	return customers, nil
+}
+
 func (r *customerRepository) UpdateCustomer(customer *domain.Customer) error {
-	return r.db.Save(customer).Error
+	return r.db.Omit(clause.Associations).Save(customer).Error
+}
+
+func (r *customerRepository) AssignCustomerRiskGradeIfUngraded(id uint, grade string) (bool, error) {
+	result := r.db.Model(&domain.Customer{}).
+		Where("customer_id = ? AND customer_risk_grade = ?", id, "").
+		Update("customer_risk_grade", grade)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
 }
 
 func (r *customerRepository) DeleteCustomer(id uint) error {
@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InstallmentRepository interface {
+	CreateInstallmentsWithTx(tx *gorm.DB, installments []domain.Installment) error
+	GetInstallmentsByTransactionID(transactionID uint) ([]domain.Installment, error)
+	GetInstallmentsByTransactionIDWithTx(tx *gorm.DB, transactionID uint) ([]domain.Installment, error)
+	GetOutstandingInstallmentsWithTx(tx *gorm.DB, transactionID uint) ([]domain.Installment, error)
+	GetPaidPrincipalWithTx(tx *gorm.DB, transactionID uint) (money.Money, error)
+	HasPaidInstallmentsWithTx(tx *gorm.DB, transactionID uint) (bool, error)
+	UpdateInstallmentWithTx(tx *gorm.DB, installment *domain.Installment) error
+	UpdateInstallmentPenaltyWithTx(tx *gorm.DB, installment *domain.Installment) error
+	VoidInstallmentsByTransactionIDWithTx(tx *gorm.DB, transactionID uint) error
+	CloseOutstandingInstallmentsWithTx(tx *gorm.DB, transactionID uint, status string) error
+	GetMonthlyObligationByNIKWithTx(tx *gorm.DB, nik string, excludeTransactionID uint) (money.Money, error)
+}
+
+type installmentRepository struct {
+	db *gorm.DB
+}
+
+func NewInstallmentRepository(db *gorm.DB) InstallmentRepository {
+	return &installmentRepository{db: db}
+}
+
+func (r *installmentRepository) CreateInstallmentsWithTx(tx *gorm.DB, installments []domain.Installment) error {
+	if len(installments) == 0 {
+		return nil
+	}
+	return tx.Create(&installments).Error
+}
+
+func (r *installmentRepository) GetInstallmentsByTransactionID(transactionID uint) ([]domain.Installment, error) {
+	return r.GetInstallmentsByTransactionIDWithTx(r.db, transactionID)
+}
+
+func (r *installmentRepository) GetInstallmentsByTransactionIDWithTx(tx *gorm.DB, transactionID uint) ([]domain.Installment, error) {
+	var installments []domain.Installment
+	err := tx.Where("installment_transaction_id = ? AND installment_status <> ?", transactionID, domain.InstallmentStatusVoid).
+		Order("installment_number").
+		Find(&installments).Error
+	if err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
+
+func (r *installmentRepository) GetOutstandingInstallmentsWithTx(tx *gorm.DB, transactionID uint) ([]domain.Installment, error) {
+	var installments []domain.Installment
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("installment_transaction_id = ? AND installment_status IN ?", transactionID,
+			[]string{domain.InstallmentStatusUnpaid, domain.InstallmentStatusPartial}).
+		Order("installment_number").
+		Find(&installments).Error
+	if err != nil {
+		return nil, err
+	}
+	return installments, nil
+}
+
+func (r *installmentRepository) GetPaidPrincipalWithTx(tx *gorm.DB, transactionID uint) (money.Money, error) {
+	var paid money.Money
+	err := tx.Model(&domain.Installment{}).
+		Select("COALESCE(SUM(installment_paid_principal), 0)").
+		Where("installment_transaction_id = ? AND installment_status <> ?", transactionID, domain.InstallmentStatusVoid).
+		Row().
+		Scan(&paid)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return paid, nil
+}
+
+func (r *installmentRepository) HasPaidInstallmentsWithTx(tx *gorm.DB, transactionID uint) (bool, error) {
+	var count int64
+	err := tx.Model(&domain.Installment{}).
+		Where("installment_transaction_id = ? AND installment_status IN ?", transactionID,
+			[]string{domain.InstallmentStatusPartial, domain.InstallmentStatusPaid}).
+		Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *installmentRepository) UpdateInstallmentWithTx(tx *gorm.DB, installment *domain.Installment) error {
+	return tx.Save(installment).Error
+}
+
+func (r *installmentRepository) UpdateInstallmentPenaltyWithTx(tx *gorm.DB, installment *domain.Installment) error {
+	return tx.Model(installment).Updates(map[string]interface{}{
+		"installment_penalty":       installment.InstallmentPenalty,
+		"installment_penalty_until": installment.InstallmentPenaltyUntil,
+	}).Error
+}
+
+func (r *installmentRepository) VoidInstallmentsByTransactionIDWithTx(tx *gorm.DB, transactionID uint) error {
+	return tx.Model(&domain.Installment{}).
+		Where("installment_transaction_id = ? AND installment_status = ?", transactionID, domain.InstallmentStatusUnpaid).
+		Where("installment_paid_principal = 0 AND installment_paid_interest = 0 AND installment_paid_fee = 0 AND installment_paid_penalty = 0").
+		Update("installment_status", domain.InstallmentStatusVoid).Error
+}
+
+func (r *installmentRepository) CloseOutstandingInstallmentsWithTx(tx *gorm.DB, transactionID uint, status string) error {
+	return tx.Model(&domain.Installment{}).
+		Where("installment_transaction_id = ? AND installment_status IN ?", transactionID,
+			[]string{domain.InstallmentStatusUnpaid, domain.InstallmentStatusPartial}).
+		Update("installment_status", status).Error
+}
+
+func (r *installmentRepository) GetMonthlyObligationByNIKWithTx(tx *gorm.DB, nik string, excludeTransactionID uint) (money.Money, error) {
+	var obligation money.Money
+	err := tx.Raw(`SELECT COALESCE(SUM(monthly_amount), 0) FROM (
+			SELECT MAX(installments.installment_amount) AS monthly_amount FROM installments
+			JOIN transactions ON transactions.transaction_id = installments.installment_transaction_id
+			WHERE transactions.transaction_nik = ? AND transactions.transaction_status = ? AND transactions.transaction_id <> ?
+				AND installments.installment_status IN (?, ?)
+			GROUP BY installments.installment_transaction_id
+		) AS obligations`,
+		nik, domain.TransactionStatusActive, excludeTransactionID,
+		domain.InstallmentStatusUnpaid, domain.InstallmentStatusPartial).
+		Row().
+		Scan(&obligation)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return obligation, nil
+}
@@ -2,19 +2,40 @@ package repository
 
 import (
 	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type LimitRepository interface {
 	CreateLimit(limit *domain.Limit) error
+	CreateLimitWithTx(tx *gorm.DB, limit *domain.Limit) error
 	GetAllLimits(limit, offset int) ([]domain.Limit, error)
+	GetLimitIDs() ([]uint, error)
 	GetLimitByID(id uint) (*domain.Limit, error)
 	GetLimitByIDWithTx(tx *gorm.DB, id uint) (*domain.Limit, error)
+	GetLimitByNIKandTenor(nik string, tenor float64) (*domain.Limit, error)
 	GetLimitByNIKandTenorWithTx(tx *gorm.DB, nik string, tenor float64) (*domain.Limit, error)
 	UpdateLimitWithTx(tx *gorm.DB, limit *domain.Limit) error
+	ConsumeLimitWithTx(tx *gorm.DB, limit *domain.Limit, amount money.Money) (bool, error)
+	ReleaseLimitWithTx(tx *gorm.DB, limit *domain.Limit, amount money.Money) error
 	UpdateLimit(limit *domain.Limit) error
 	DeleteLimit(id uint) error
+	DeleteLimitWithTx(tx *gorm.DB, id uint) error
+	CreateLimitMovementWithTx(tx *gorm.DB, movement *domain.LimitMovement) error
+	GetLimitMovements(limitID uint, limit, offset int) ([]domain.LimitMovement, error)
+	GetLimitLedger(limitID uint) (*domain.LimitLedger, error)
+	GetLimitsByNIK(nik string) ([]domain.Limit, error)
+	GetUsedAmountByNIKWithTx(tx *gorm.DB, nik string) (money.Money, error)
+	GetCustomerLimitByNIK(nik string) (*domain.CustomerLimit, error)
+	GetCustomerLimitByNIKWithTx(tx *gorm.DB, nik string) (*domain.CustomerLimit, error)
+	SaveCustomerLimit(customerLimit *domain.CustomerLimit) error
+	CreateLimitIfAbsentWithTx(tx *gorm.DB, limit *domain.Limit) (bool, error)
+	GetDuplicateLimitKeys() ([]domain.LimitKey, error)
+	GetLimitsByNIKandTenorWithTx(tx *gorm.DB, nik string, tenor int) ([]domain.Limit, error)
+	ReassignLimitMovementsWithTx(tx *gorm.DB, fromLimitIDs []uint, toLimitID uint) error
+	CreateOpeningLimitMovements() (int64, error)
 }
 
 type limitRepository struct {
@@ -29,6 +50,10 @@ func (r *limitRepository) CreateLimit(limit *domain.Limit) error {
 	return r.db.Create(limit).Error
 }
 
+func (r *limitRepository) CreateLimitWithTx(tx *gorm.DB, limit *domain.Limit) error {
+	return tx.Create(limit).Error
+}
+
 func (r *limitRepository) GetAllLimits(limit, offset int) ([]domain.Limit, error) {
 	var limits []domain.Limit
 	err := r.db.Preload("NIKCustomer").
@@ -43,6 +68,15 @@ func (r *limitRepository) GetAllLimits(limit, offset int) ([]domain.Limit, error
 	return limits, nil
 }
 
+func (r *limitRepository) GetLimitIDs() ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.Limit{}).Order("limit_id").Pluck("limit_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (r *limitRepository) GetLimitByID(id uint) (*domain.Limit, error) {
 	var limit domain.Limit
 	err := r.db.Preload("NIKCustomer").
@@ -64,6 +98,15 @@ func (r *limitRepository) GetLimitByIDWithTx(tx *gorm.DB, id uint) (*domain.Limi
 	return &limit, nil
 }
 
+func (r *limitRepository) GetLimitByNIKandTenor(nik string, tenor float64) (*domain.Limit, error) {
+	var limit domain.Limit
+	err := r.db.Raw(`SELECT * FROM limits WHERE limit_nik = ? AND limit_tenor = ?`, nik, tenor).Scan(&limit).Error
+	if err != nil {
+		return nil, err
+	}
+	return &limit, nil
+}
+
 func (r *limitRepository) GetLimitByNIKandTenorWithTx(tx *gorm.DB, nik string, tenor float64) (*domain.Limit, error) {
 	var limit domain.Limit
 	err := tx.Raw(`SELECT * FROM limits WHERE limit_nik = ? AND limit_tenor = ? FOR UPDATE`, nik, tenor).Scan(&limit).Error
@@ -77,6 +120,27 @@ func (r *limitRepository) UpdateLimitWithTx(tx *gorm.DB, limit *domain.Limit) er
 	return tx.Save(limit).Error
 }
 
+func (r *limitRepository) ConsumeLimitWithTx(tx *gorm.DB, limit *domain.Limit, amount money.Money) (bool, error) {
+	result := tx.Raw(`UPDATE limits SET limit_used_amount = limit_used_amount + ?, limit_remaining_amount = limit_remaining_amount - ?
+		WHERE limit_id = ? AND limit_remaining_amount >= ? RETURNING *`, amount, amount, limit.LimitID, amount).Scan(limit)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *limitRepository) ReleaseLimitWithTx(tx *gorm.DB, limit *domain.Limit, amount money.Money) error {
+	result := tx.Raw(`UPDATE limits SET limit_used_amount = limit_used_amount - ?, limit_remaining_amount = limit_remaining_amount + ?
+		WHERE limit_id = ? RETURNING *`, amount, amount, limit.LimitID).Scan(limit)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 func (r *limitRepository) UpdateLimit(limit *domain.Limit) error {
 	return r.db.Save(limit).Error
 }
@@ -84,3 +148,137 @@ func (r *limitRepository) UpdateLimit(limit *domain.Limit) error {
 func (r *limitRepository) DeleteLimit(id uint) error {
 	return r.db.Delete(&domain.Limit{}, id).Error
 }
+
+func (r *limitRepository) DeleteLimitWithTx(tx *gorm.DB, id uint) error {
+	return tx.Delete(&domain.Limit{}, id).Error
+}
+
+func (r *limitRepository) CreateLimitMovementWithTx(tx *gorm.DB, movement *domain.LimitMovement) error {
+	return tx.Create(movement).Error
+}
+
+func (r *limitRepository) GetLimitMovements(limitID uint, limit, offset int) ([]domain.LimitMovement, error) {
+	var movements []domain.LimitMovement
+	err := r.db.Preload("CreatedByUser").
+		Where("movement_limit_id = ?", limitID).
+		Order("movement_id").
+		Limit(limit).
+		Offset(offset).
+		Find(&movements).Error
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+func (r *limitRepository) GetLimitLedger(limitID uint) (*domain.LimitLedger, error) {
+	var ledger domain.LimitLedger
+	err := r.db.Raw(`SELECT movement_limit_id AS ledger_limit_id,
+			COUNT(*) AS ledger_movement_count,
+			COALESCE(SUM(movement_limit_delta), 0) AS ledger_limit_amount,
+			COALESCE(SUM(movement_used_delta), 0) AS ledger_used_amount,
+			COALESCE(SUM(movement_remaining_delta), 0) AS ledger_remaining_amount
+		FROM limit_movements WHERE movement_limit_id = ? GROUP BY movement_limit_id`, limitID).Scan(&ledger).Error
+	if err != nil {
+		return nil, err
+	}
+	ledger.LedgerLimitID = limitID
+	return &ledger, nil
+}
+
+func (r *limitRepository) GetLimitsByNIK(nik string) ([]domain.Limit, error) {
+	var limits []domain.Limit
+	err := r.db.Where("limit_nik = ?", nik).Order("limit_tenor").Find(&limits).Error
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func (r *limitRepository) GetUsedAmountByNIKWithTx(tx *gorm.DB, nik string) (money.Money, error) {
+	var used money.Money
+	err := tx.Model(&domain.Limit{}).
+		Select("COALESCE(SUM(limit_used_amount), 0)").
+		Where("limit_nik = ?", nik).
+		Row().
+		Scan(&used)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return used, nil
+}
+
+func (r *limitRepository) GetCustomerLimitByNIK(nik string) (*domain.CustomerLimit, error) {
+	var customerLimit domain.CustomerLimit
+	err := r.db.Raw(`SELECT * FROM customer_limits WHERE customer_limit_nik = ?`, nik).Scan(&customerLimit).Error
+	if err != nil {
+		return nil, err
+	}
+	return &customerLimit, nil
+}
+
+func (r *limitRepository) GetCustomerLimitByNIKWithTx(tx *gorm.DB, nik string) (*domain.CustomerLimit, error) {
+	var customerLimit domain.CustomerLimit
+	err := tx.Raw(`SELECT * FROM customer_limits WHERE customer_limit_nik = ? FOR UPDATE`, nik).Scan(&customerLimit).Error
+	if err != nil {
+		return nil, err
+	}
+	return &customerLimit, nil
+}
+
+func (r *limitRepository) SaveCustomerLimit(customerLimit *domain.CustomerLimit) error {
+	return r.db.Save(customerLimit).Error
+}
+
+func (r *limitRepository) CreateLimitIfAbsentWithTx(tx *gorm.DB, limit *domain.Limit) (bool, error) {
+	result := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "limit_nik"}, {Name: "limit_tenor"}},
+		DoNothing: true,
+	}).Create(limit)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *limitRepository) GetDuplicateLimitKeys() ([]domain.LimitKey, error) {
+	var keys []domain.LimitKey
+	err := r.db.Model(&domain.Limit{}).
+		Select("limit_nik, limit_tenor").
+		Group("limit_nik, limit_tenor").
+		Having("COUNT(*) > 1").
+		Order("limit_nik, limit_tenor").
+		Scan(&keys).Error
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+func (r *limitRepository) GetLimitsByNIKandTenorWithTx(tx *gorm.DB, nik string, tenor int) ([]domain.Limit, error) {
+	var limits []domain.Limit
+	err := tx.Raw(`SELECT * FROM limits WHERE limit_nik = ? AND limit_tenor = ? ORDER BY limit_id FOR UPDATE`, nik, tenor).Scan(&limits).Error
+	if err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+func (r *limitRepository) ReassignLimitMovementsWithTx(tx *gorm.DB, fromLimitIDs []uint, toLimitID uint) error {
+	return tx.Model(&domain.LimitMovement{}).
+		Where("movement_limit_id IN ?", fromLimitIDs).
+		Update("movement_limit_id", toLimitID).Error
+}
+
+func (r *limitRepository) CreateOpeningLimitMovements() (int64, error) {
+	result := r.db.Exec(`INSERT INTO limit_movements (movement_limit_id, movement_type, movement_limit_delta, movement_used_delta, movement_remaining_delta,
+			movement_limit_balance, movement_used_balance, movement_remaining_balance, movement_reason, movement_created_by, movement_created_at)
+		SELECT limit_id, ?, limit_amount, limit_used_amount, limit_remaining_amount,
+			limit_amount, limit_used_amount, limit_remaining_amount, ?, limit_created_by, NOW()
+		FROM limits WHERE NOT EXISTS (SELECT 1 FROM limit_movements WHERE movement_limit_id = limits.limit_id)`,
+		domain.LimitMovementAdjust, "opening balance")
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
@@ -13,6 +13,34 @@ type CustomerRepository struct {
 	mock.Mock
 }
 
+// AssignCustomerRiskGradeIfUngraded provides a mock function with given fields: id, grade
+func (_m *CustomerRepository) AssignCustomerRiskGradeIfUngraded(id uint, grade string) (bool, error) {
+	ret := _m.Called(id, grade)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignCustomerRiskGradeIfUngraded")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, string) (bool, error)); ok {
+		return rf(id, grade)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string) bool); ok {
+		r0 = rf(id, grade)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, string) error); ok {
+		r1 = rf(id, grade)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateCustomer provides a mock function with given fields: customer
 func (_m *CustomerRepository) CreateCustomer(customer *domain.Customer) error {
 	ret := _m.Called(customer)
@@ -49,6 +77,36 @@ func (_m *CustomerRepository) DeleteCustomer(id uint) error {
 	return r0
 }
 
+// FindDuplicateCandidates provides a mock function with given fields: customer, maxHashDistance
+func (_m *CustomerRepository) FindDuplicateCandidates(customer *domain.Customer, maxHashDistance int) ([]domain.Customer, error) {
+	ret := _m.Called(customer, maxHashDistance)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicateCandidates")
+	}
+
+	var r0 []domain.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.Customer, int) ([]domain.Customer, error)); ok {
+		return rf(customer, maxHashDistance)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.Customer, int) []domain.Customer); ok {
+		r0 = rf(customer, maxHashDistance)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Customer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.Customer, int) error); ok {
+		r1 = rf(customer, maxHashDistance)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllCustomers provides a mock function with given fields: limit, offset
 func (_m *CustomerRepository) GetAllCustomers(limit int, offset int) ([]domain.Customer, error) {
 	ret := _m.Called(limit, offset)
@@ -139,6 +197,36 @@ func (_m *CustomerRepository) GetCustomerByNIK(nik string) (*domain.Customer, er
 	return r0, r1
 }
 
+// GetCustomersByKYCStatus provides a mock function with given fields: status, limit, offset
+func (_m *CustomerRepository) GetCustomersByKYCStatus(status string, limit int, offset int) ([]domain.Customer, error) {
+	ret := _m.Called(status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomersByKYCStatus")
+	}
+
+	var r0 []domain.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]domain.Customer, error)); ok {
+		return rf(status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []domain.Customer); ok {
+		r0 = rf(status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Customer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(status, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateCustomer provides a mock function with given fields: customer
 func (_m *CustomerRepository) UpdateCustomer(customer *domain.Customer) error {
 	ret := _m.Called(customer)
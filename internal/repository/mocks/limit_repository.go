@@ -8,6 +8,8 @@ import (
 	gorm "gorm.io/gorm"
 
 	mock "github.com/stretchr/testify/mock"
+
+	money "kreditplus/internal/money"
 )
 
 // LimitRepository is an autogenerated mock type for the LimitRepository type
@@ -15,6 +17,34 @@ type LimitRepository struct {
 	mock.Mock
 }
 
+// ConsumeLimitWithTx provides a mock function with given fields: tx, limit, amount
+func (_m *LimitRepository) ConsumeLimitWithTx(tx *gorm.DB, limit *domain.Limit, amount money.Money) (bool, error) {
+	ret := _m.Called(tx, limit, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConsumeLimitWithTx")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.Limit, money.Money) (bool, error)); ok {
+		return rf(tx, limit, amount)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.Limit, money.Money) bool); ok {
+		r0 = rf(tx, limit, amount)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, *domain.Limit, money.Money) error); ok {
+		r1 = rf(tx, limit, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateLimit provides a mock function with given fields: limit
 func (_m *LimitRepository) CreateLimit(limit *domain.Limit) error {
 	ret := _m.Called(limit)
@@ -33,6 +63,98 @@ func (_m *LimitRepository) CreateLimit(limit *domain.Limit) error {
 	return r0
 }
 
+// CreateLimitIfAbsentWithTx provides a mock function with given fields: tx, limit
+func (_m *LimitRepository) CreateLimitIfAbsentWithTx(tx *gorm.DB, limit *domain.Limit) (bool, error) {
+	ret := _m.Called(tx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLimitIfAbsentWithTx")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.Limit) (bool, error)); ok {
+		return rf(tx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.Limit) bool); ok {
+		r0 = rf(tx, limit)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, *domain.Limit) error); ok {
+		r1 = rf(tx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateLimitMovementWithTx provides a mock function with given fields: tx, movement
+func (_m *LimitRepository) CreateLimitMovementWithTx(tx *gorm.DB, movement *domain.LimitMovement) error {
+	ret := _m.Called(tx, movement)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLimitMovementWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.LimitMovement) error); ok {
+		r0 = rf(tx, movement)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateLimitWithTx provides a mock function with given fields: tx, limit
+func (_m *LimitRepository) CreateLimitWithTx(tx *gorm.DB, limit *domain.Limit) error {
+	ret := _m.Called(tx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLimitWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.Limit) error); ok {
+		r0 = rf(tx, limit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateOpeningLimitMovements provides a mock function with no fields
+func (_m *LimitRepository) CreateOpeningLimitMovements() (int64, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOpeningLimitMovements")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (int64, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteLimit provides a mock function with given fields: id
 func (_m *LimitRepository) DeleteLimit(id uint) error {
 	ret := _m.Called(id)
@@ -51,6 +173,24 @@ func (_m *LimitRepository) DeleteLimit(id uint) error {
 	return r0
 }
 
+// DeleteLimitWithTx provides a mock function with given fields: tx, id
+func (_m *LimitRepository) DeleteLimitWithTx(tx *gorm.DB, id uint) error {
+	ret := _m.Called(tx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteLimitWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, uint) error); ok {
+		r0 = rf(tx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetAllLimits provides a mock function with given fields: limit, offset
 func (_m *LimitRepository) GetAllLimits(limit int, offset int) ([]domain.Limit, error) {
 	ret := _m.Called(limit, offset)
@@ -81,6 +221,96 @@ func (_m *LimitRepository) GetAllLimits(limit int, offset int) ([]domain.Limit,
 	return r0, r1
 }
 
+// GetCustomerLimitByNIK provides a mock function with given fields: nik
+func (_m *LimitRepository) GetCustomerLimitByNIK(nik string) (*domain.CustomerLimit, error) {
+	ret := _m.Called(nik)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerLimitByNIK")
+	}
+
+	var r0 *domain.CustomerLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.CustomerLimit, error)); ok {
+		return rf(nik)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.CustomerLimit); ok {
+		r0 = rf(nik)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CustomerLimit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(nik)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCustomerLimitByNIKWithTx provides a mock function with given fields: tx, nik
+func (_m *LimitRepository) GetCustomerLimitByNIKWithTx(tx *gorm.DB, nik string) (*domain.CustomerLimit, error) {
+	ret := _m.Called(tx, nik)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerLimitByNIKWithTx")
+	}
+
+	var r0 *domain.CustomerLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) (*domain.CustomerLimit, error)); ok {
+		return rf(tx, nik)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) *domain.CustomerLimit); ok {
+		r0 = rf(tx, nik)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CustomerLimit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, string) error); ok {
+		r1 = rf(tx, nik)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicateLimitKeys provides a mock function with no fields
+func (_m *LimitRepository) GetDuplicateLimitKeys() ([]domain.LimitKey, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicateLimitKeys")
+	}
+
+	var r0 []domain.LimitKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]domain.LimitKey, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []domain.LimitKey); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LimitKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLimitByID provides a mock function with given fields: id
 func (_m *LimitRepository) GetLimitByID(id uint) (*domain.Limit, error) {
 	ret := _m.Called(id)
@@ -141,6 +371,36 @@ func (_m *LimitRepository) GetLimitByIDWithTx(tx *gorm.DB, id uint) (*domain.Lim
 	return r0, r1
 }
 
+// GetLimitByNIKandTenor provides a mock function with given fields: nik, tenor
+func (_m *LimitRepository) GetLimitByNIKandTenor(nik string, tenor float64) (*domain.Limit, error) {
+	ret := _m.Called(nik, tenor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimitByNIKandTenor")
+	}
+
+	var r0 *domain.Limit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, float64) (*domain.Limit, error)); ok {
+		return rf(nik, tenor)
+	}
+	if rf, ok := ret.Get(0).(func(string, float64) *domain.Limit); ok {
+		r0 = rf(nik, tenor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Limit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, float64) error); ok {
+		r1 = rf(nik, tenor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLimitByNIKandTenorWithTx provides a mock function with given fields: tx, nik, tenor
 func (_m *LimitRepository) GetLimitByNIKandTenorWithTx(tx *gorm.DB, nik string, tenor float64) (*domain.Limit, error) {
 	ret := _m.Called(tx, nik, tenor)
@@ -171,6 +431,238 @@ func (_m *LimitRepository) GetLimitByNIKandTenorWithTx(tx *gorm.DB, nik string,
 	return r0, r1
 }
 
+// GetLimitIDs provides a mock function with no fields
+func (_m *LimitRepository) GetLimitIDs() ([]uint, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimitIDs")
+	}
+
+	var r0 []uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]uint, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []uint); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLimitLedger provides a mock function with given fields: limitID
+func (_m *LimitRepository) GetLimitLedger(limitID uint) (*domain.LimitLedger, error) {
+	ret := _m.Called(limitID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimitLedger")
+	}
+
+	var r0 *domain.LimitLedger
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) (*domain.LimitLedger, error)); ok {
+		return rf(limitID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) *domain.LimitLedger); ok {
+		r0 = rf(limitID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LimitLedger)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(limitID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLimitMovements provides a mock function with given fields: limitID, limit, offset
+func (_m *LimitRepository) GetLimitMovements(limitID uint, limit int, offset int) ([]domain.LimitMovement, error) {
+	ret := _m.Called(limitID, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimitMovements")
+	}
+
+	var r0 []domain.LimitMovement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, int, int) ([]domain.LimitMovement, error)); ok {
+		return rf(limitID, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(uint, int, int) []domain.LimitMovement); ok {
+		r0 = rf(limitID, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LimitMovement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, int, int) error); ok {
+		r1 = rf(limitID, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLimitsByNIK provides a mock function with given fields: nik
+func (_m *LimitRepository) GetLimitsByNIK(nik string) ([]domain.Limit, error) {
+	ret := _m.Called(nik)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimitsByNIK")
+	}
+
+	var r0 []domain.Limit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]domain.Limit, error)); ok {
+		return rf(nik)
+	}
+	if rf, ok := ret.Get(0).(func(string) []domain.Limit); ok {
+		r0 = rf(nik)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Limit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(nik)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLimitsByNIKandTenorWithTx provides a mock function with given fields: tx, nik, tenor
+func (_m *LimitRepository) GetLimitsByNIKandTenorWithTx(tx *gorm.DB, nik string, tenor int) ([]domain.Limit, error) {
+	ret := _m.Called(tx, nik, tenor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimitsByNIKandTenorWithTx")
+	}
+
+	var r0 []domain.Limit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string, int) ([]domain.Limit, error)); ok {
+		return rf(tx, nik, tenor)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string, int) []domain.Limit); ok {
+		r0 = rf(tx, nik, tenor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Limit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, string, int) error); ok {
+		r1 = rf(tx, nik, tenor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUsedAmountByNIKWithTx provides a mock function with given fields: tx, nik
+func (_m *LimitRepository) GetUsedAmountByNIKWithTx(tx *gorm.DB, nik string) (money.Money, error) {
+	ret := _m.Called(tx, nik)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsedAmountByNIKWithTx")
+	}
+
+	var r0 money.Money
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) (money.Money, error)); ok {
+		return rf(tx, nik)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, string) money.Money); ok {
+		r0 = rf(tx, nik)
+	} else {
+		r0 = ret.Get(0).(money.Money)
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, string) error); ok {
+		r1 = rf(tx, nik)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignLimitMovementsWithTx provides a mock function with given fields: tx, fromLimitIDs, toLimitID
+func (_m *LimitRepository) ReassignLimitMovementsWithTx(tx *gorm.DB, fromLimitIDs []uint, toLimitID uint) error {
+	ret := _m.Called(tx, fromLimitIDs, toLimitID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignLimitMovementsWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, []uint, uint) error); ok {
+		r0 = rf(tx, fromLimitIDs, toLimitID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseLimitWithTx provides a mock function with given fields: tx, limit, amount
+func (_m *LimitRepository) ReleaseLimitWithTx(tx *gorm.DB, limit *domain.Limit, amount money.Money) error {
+	ret := _m.Called(tx, limit, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseLimitWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.Limit, money.Money) error); ok {
+		r0 = rf(tx, limit, amount)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SaveCustomerLimit provides a mock function with given fields: customerLimit
+func (_m *LimitRepository) SaveCustomerLimit(customerLimit *domain.CustomerLimit) error {
+	ret := _m.Called(customerLimit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCustomerLimit")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*domain.CustomerLimit) error); ok {
+		r0 = rf(customerLimit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateLimit provides a mock function with given fields: limit
 func (_m *LimitRepository) UpdateLimit(limit *domain.Limit) error {
 	ret := _m.Called(limit)
@@ -15,17 +15,17 @@ type TransactionRepository struct {
 	mock.Mock
 }
 
-// CreateTransaction provides a mock function with given fields: transaction
-func (_m *TransactionRepository) CreateTransaction(transaction *domain.Transaction) error {
-	ret := _m.Called(transaction)
+// CreateTransactionRestructureWithTx provides a mock function with given fields: tx, restructure
+func (_m *TransactionRepository) CreateTransactionRestructureWithTx(tx *gorm.DB, restructure *domain.TransactionRestructure) error {
+	ret := _m.Called(tx, restructure)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTransaction")
+		panic("no return value specified for CreateTransactionRestructureWithTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*domain.Transaction) error); ok {
-		r0 = rf(transaction)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.TransactionRestructure) error); ok {
+		r0 = rf(tx, restructure)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -33,17 +33,17 @@ func (_m *TransactionRepository) CreateTransaction(transaction *domain.Transacti
 	return r0
 }
 
-// CreateTransactionWithTx provides a mock function with given fields: tx, transaction
-func (_m *TransactionRepository) CreateTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
-	ret := _m.Called(tx, transaction)
+// CreateTransactionStatusHistoryWithTx provides a mock function with given fields: tx, history
+func (_m *TransactionRepository) CreateTransactionStatusHistoryWithTx(tx *gorm.DB, history *domain.TransactionStatusHistory) error {
+	ret := _m.Called(tx, history)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTransactionWithTx")
+		panic("no return value specified for CreateTransactionStatusHistoryWithTx")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.Transaction) error); ok {
-		r0 = rf(tx, transaction)
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.TransactionStatusHistory) error); ok {
+		r0 = rf(tx, history)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,12 +51,12 @@ func (_m *TransactionRepository) CreateTransactionWithTx(tx *gorm.DB, transactio
 	return r0
 }
 
-// DeleteTransactionWithTx provides a mock function with given fields: tx, transaction
-func (_m *TransactionRepository) DeleteTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
+// CreateTransactionWithTx provides a mock function with given fields: tx, transaction
+func (_m *TransactionRepository) CreateTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
 	ret := _m.Called(tx, transaction)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTransactionWithTx")
+		panic("no return value specified for CreateTransactionWithTx")
 	}
 
 	var r0 error
@@ -99,6 +99,84 @@ func (_m *TransactionRepository) GetAllTransactions(transaction int, offset int)
 	return r0, r1
 }
 
+// GetDelinquentTransactions provides a mock function with given fields: collectibility, minDaysPastDue, limit, offset
+func (_m *TransactionRepository) GetDelinquentTransactions(collectibility string, minDaysPastDue int, limit int, offset int) ([]domain.Transaction, error) {
+	ret := _m.Called(collectibility, minDaysPastDue, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelinquentTransactions")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int, int) ([]domain.Transaction, error)); ok {
+		return rf(collectibility, minDaysPastDue, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int, int) []domain.Transaction); ok {
+		r0 = rf(collectibility, minDaysPastDue, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int, int) error); ok {
+		r1 = rf(collectibility, minDaysPastDue, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRepaymentHistoryByNIK provides a mock function with given fields: nik
+func (_m *TransactionRepository) GetRepaymentHistoryByNIK(nik string) (*domain.RepaymentHistory, error) {
+	ret := _m.Called(nik)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRepaymentHistoryByNIK")
+	}
+
+	var r0 *domain.RepaymentHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.RepaymentHistory, error)); ok {
+		return rf(nik)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.RepaymentHistory); ok {
+		r0 = rf(nik)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.RepaymentHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(nik)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRetryMetrics provides a mock function with no fields
+func (_m *TransactionRepository) GetRetryMetrics() domain.TransactionRetryMetrics {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRetryMetrics")
+	}
+
+	var r0 domain.TransactionRetryMetrics
+	if rf, ok := ret.Get(0).(func() domain.TransactionRetryMetrics); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.TransactionRetryMetrics)
+	}
+
+	return r0
+}
+
 // GetTransactionByID provides a mock function with given fields: id
 func (_m *TransactionRepository) GetTransactionByID(id uint) (*domain.Transaction, error) {
 	ret := _m.Called(id)
@@ -129,6 +207,240 @@ func (_m *TransactionRepository) GetTransactionByID(id uint) (*domain.Transactio
 	return r0, r1
 }
 
+// GetTransactionByIDWithTx provides a mock function with given fields: tx, id
+func (_m *TransactionRepository) GetTransactionByIDWithTx(tx *gorm.DB, id uint) (*domain.Transaction, error) {
+	ret := _m.Called(tx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionByIDWithTx")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, uint) (*domain.Transaction, error)); ok {
+		return rf(tx, id)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, uint) *domain.Transaction); ok {
+		r0 = rf(tx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, uint) error); ok {
+		r1 = rf(tx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionIDsForOverdueReview provides a mock function with no fields
+func (_m *TransactionRepository) GetTransactionIDsForOverdueReview() ([]uint, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionIDsForOverdueReview")
+	}
+
+	var r0 []uint
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]uint, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []uint); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uint)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionRestructures provides a mock function with given fields: originalNumber
+func (_m *TransactionRepository) GetTransactionRestructures(originalNumber string) ([]domain.TransactionRestructure, error) {
+	ret := _m.Called(originalNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionRestructures")
+	}
+
+	var r0 []domain.TransactionRestructure
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]domain.TransactionRestructure, error)); ok {
+		return rf(originalNumber)
+	}
+	if rf, ok := ret.Get(0).(func(string) []domain.TransactionRestructure); ok {
+		r0 = rf(originalNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionRestructure)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(originalNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionStatusHistory provides a mock function with given fields: transactionID
+func (_m *TransactionRepository) GetTransactionStatusHistory(transactionID uint) ([]domain.TransactionStatusHistory, error) {
+	ret := _m.Called(transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionStatusHistory")
+	}
+
+	var r0 []domain.TransactionStatusHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]domain.TransactionStatusHistory, error)); ok {
+		return rf(transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []domain.TransactionStatusHistory); ok {
+		r0 = rf(transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionStatusHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionVersions provides a mock function with given fields: originalNumber
+func (_m *TransactionRepository) GetTransactionVersions(originalNumber string) ([]domain.Transaction, error) {
+	ret := _m.Called(originalNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionVersions")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]domain.Transaction, error)); ok {
+		return rf(originalNumber)
+	}
+	if rf, ok := ret.Get(0).(func(string) []domain.Transaction); ok {
+		r0 = rf(originalNumber)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(originalNumber)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionsByLimitIDWithTx provides a mock function with given fields: tx, limitID, statuses
+func (_m *TransactionRepository) GetTransactionsByLimitIDWithTx(tx *gorm.DB, limitID uint, statuses []string) ([]domain.Transaction, error) {
+	ret := _m.Called(tx, limitID, statuses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionsByLimitIDWithTx")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, uint, []string) ([]domain.Transaction, error)); ok {
+		return rf(tx, limitID, statuses)
+	}
+	if rf, ok := ret.Get(0).(func(*gorm.DB, uint, []string) []domain.Transaction); ok {
+		r0 = rf(tx, limitID, statuses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*gorm.DB, uint, []string) error); ok {
+		r1 = rf(tx, limitID, statuses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReassignTransactionsLimitWithTx provides a mock function with given fields: tx, fromLimitIDs, toLimitID
+func (_m *TransactionRepository) ReassignTransactionsLimitWithTx(tx *gorm.DB, fromLimitIDs []uint, toLimitID uint) error {
+	ret := _m.Called(tx, fromLimitIDs, toLimitID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReassignTransactionsLimitWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, []uint, uint) error); ok {
+		r0 = rf(tx, fromLimitIDs, toLimitID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTransactionDelinquencyWithTx provides a mock function with given fields: tx, transactionID, daysPastDue, collectibility
+func (_m *TransactionRepository) UpdateTransactionDelinquencyWithTx(tx *gorm.DB, transactionID uint, daysPastDue int, collectibility string) error {
+	ret := _m.Called(tx, transactionID, daysPastDue, collectibility)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransactionDelinquencyWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, uint, int, string) error); ok {
+		r0 = rf(tx, transactionID, daysPastDue, collectibility)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTransactionStatusWithTx provides a mock function with given fields: tx, transaction
+func (_m *TransactionRepository) UpdateTransactionStatusWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
+	ret := _m.Called(tx, transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransactionStatusWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gorm.DB, *domain.Transaction) error); ok {
+		r0 = rf(tx, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTransactionWithTx provides a mock function with given fields: tx, transaction
 func (_m *TransactionRepository) UpdateTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
 	ret := _m.Called(tx, transaction)
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"kreditplus/internal/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type NegativeListRepository interface {
+	CreateNegativeListEntry(entry *domain.NegativeListEntry) error
+	GetAllNegativeListEntries(limit, offset int) ([]domain.NegativeListEntry, error)
+	GetNegativeListEntryByID(id uint) (*domain.NegativeListEntry, error)
+	FindActiveMatches(nik string, names, phones []string, at time.Time) ([]domain.NegativeListEntry, error)
+	UpdateNegativeListEntry(entry *domain.NegativeListEntry) error
+}
+
+type negativeListRepository struct {
+	db *gorm.DB
+}
+
+func NewNegativeListRepository(db *gorm.DB) NegativeListRepository {
+	return &negativeListRepository{db: db}
+}
+
+func (r *negativeListRepository) CreateNegativeListEntry(entry *domain.NegativeListEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *negativeListRepository) GetAllNegativeListEntries(limit, offset int) ([]domain.NegativeListEntry, error) {
+	var entries []domain.NegativeListEntry
+	err := r.db.Order("negative_list_id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *negativeListRepository) GetNegativeListEntryByID(id uint) (*domain.NegativeListEntry, error) {
+	var entry domain.NegativeListEntry
+	if err := r.db.First(&entry, id).Error; err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *negativeListRepository) FindActiveMatches(nik string, names, phones []string, at time.Time) ([]domain.NegativeListEntry, error) {
+	matches := r.db.Where("negative_list_type = ? AND negative_list_value = ?", domain.NegativeListTypeNIK, nik)
+	if len(names) > 0 {
+		matches = matches.Or("negative_list_type = ? AND negative_list_value IN ?", domain.NegativeListTypeName, names)
+	}
+	if len(phones) > 0 {
+		matches = matches.Or("negative_list_type = ? AND negative_list_value IN ?", domain.NegativeListTypePhone, phones)
+	}
+
+	var entries []domain.NegativeListEntry
+	err := r.db.Where("negative_list_active = ?", true).
+		Where("negative_list_expires_at IS NULL OR negative_list_expires_at > ?", at).
+		Where(matches).
+		Order("negative_list_id").
+		Find(&entries).Error
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func (r *negativeListRepository) UpdateNegativeListEntry(entry *domain.NegativeListEntry) error {
+	return r.db.Save(entry).Error
+}
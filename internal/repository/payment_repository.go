@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"kreditplus/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type PaymentRepository interface {
+	CreatePaymentWithTx(tx *gorm.DB, payment *domain.Payment) error
+	GetPaymentsByTransactionID(transactionID uint) ([]domain.Payment, error)
+}
+
+type paymentRepository struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &paymentRepository{db: db}
+}
+
+func (r *paymentRepository) CreatePaymentWithTx(tx *gorm.DB, payment *domain.Payment) error {
+	return tx.Create(payment).Error
+}
+
+func (r *paymentRepository) GetPaymentsByTransactionID(transactionID uint) ([]domain.Payment, error) {
+	var payments []domain.Payment
+	err := r.db.Preload("CreatedByUser").
+		Where("payment_transaction_id = ?", transactionID).
+		Order("payment_paid_at, payment_id").
+		Find(&payments).Error
+	if err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
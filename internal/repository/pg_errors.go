@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	sqlStateUniqueViolation      = "23505"
+	sqlStateDeadlockDetected     = "40P01"
+	sqlStateSerializationFailure = "40001"
+	sqlStateLockNotAvailable     = "55P03"
+)
+
+func sqlState(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+func IsUniqueViolation(err error) bool {
+	return sqlState(err) == sqlStateUniqueViolation
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"kreditplus/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type PricingRuleRepository interface {
+	CreatePricingRule(rule *domain.PricingRule) error
+	GetAllPricingRules(limit, offset int) ([]domain.PricingRule, error)
+	GetPricingRuleByID(id uint) (*domain.PricingRule, error)
+	GetActivePricingRulesByProductID(productID uint) ([]domain.PricingRule, error)
+	UpdatePricingRule(rule *domain.PricingRule) error
+}
+
+type pricingRuleRepository struct {
+	db *gorm.DB
+}
+
+func NewPricingRuleRepository(db *gorm.DB) PricingRuleRepository {
+	return &pricingRuleRepository{db: db}
+}
+
+func (r *pricingRuleRepository) CreatePricingRule(rule *domain.PricingRule) error {
+	return r.db.Create(rule).Error
+}
+
+func (r *pricingRuleRepository) GetAllPricingRules(limit, offset int) ([]domain.PricingRule, error) {
+	var rules []domain.PricingRule
+	err := r.db.Order("pricing_product_id, pricing_rule_id").
+		Limit(limit).
+		Offset(offset).
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *pricingRuleRepository) GetPricingRuleByID(id uint) (*domain.PricingRule, error) {
+	var rule domain.PricingRule
+	if err := r.db.First(&rule, id).Error; err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *pricingRuleRepository) GetActivePricingRulesByProductID(productID uint) ([]domain.PricingRule, error) {
+	var rules []domain.PricingRule
+	err := r.db.Where("pricing_product_id = ? AND pricing_active = ?", productID, true).
+		Order("pricing_priority DESC, pricing_rule_id").
+		Find(&rules).Error
+	if err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *pricingRuleRepository) UpdatePricingRule(rule *domain.PricingRule) error {
+	return r.db.Save(rule).Error
+}
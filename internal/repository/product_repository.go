@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"kreditplus/internal/domain"
+
+	"gorm.io/gorm"
+)
+
+type ProductRepository interface {
+	CreateProduct(product *domain.Product) error
+	GetAllProducts(limit, offset int) ([]domain.Product, error)
+	GetProductByID(id uint) (*domain.Product, error)
+	GetProductByCode(code string) (*domain.Product, error)
+	UpdateProduct(product *domain.Product) error
+	GetOfferedTenors() ([]int, error)
+}
+
+type productRepository struct {
+	db *gorm.DB
+}
+
+func NewProductRepository(db *gorm.DB) ProductRepository {
+	return &productRepository{db: db}
+}
+
+func (r *productRepository) CreateProduct(product *domain.Product) error {
+	return r.db.Create(product).Error
+}
+
+func (r *productRepository) GetAllProducts(limit, offset int) ([]domain.Product, error) {
+	var products []domain.Product
+	err := r.db.Preload("ProductTenors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tenor_months")
+	}).
+		Order("product_id").
+		Limit(limit).
+		Offset(offset).
+		Find(&products).Error
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *productRepository) GetProductByID(id uint) (*domain.Product, error) {
+	var product domain.Product
+	err := r.db.Preload("ProductTenors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tenor_months")
+	}).
+		First(&product, id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) GetProductByCode(code string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.db.Preload("ProductTenors", func(db *gorm.DB) *gorm.DB {
+		return db.Order("tenor_months")
+	}).
+		Where("product_code = ?", code).
+		First(&product).Error
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *productRepository) UpdateProduct(product *domain.Product) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("tenor_product_id = ?", product.ProductID).Delete(&domain.ProductTenor{}).Error; err != nil {
+			return err
+		}
+
+		for i := range product.ProductTenors {
+			product.ProductTenors[i].TenorID = 0
+			product.ProductTenors[i].TenorProductID = product.ProductID
+		}
+		return tx.Save(product).Error
+	})
+}
+
+func (r *productRepository) GetOfferedTenors() ([]int, error) {
+	var tenors []int
+	err := r.db.Raw(`SELECT DISTINCT tenor_months FROM product_tenors
+		JOIN products ON products.product_id = product_tenors.tenor_product_id
+		WHERE products.product_active = true ORDER BY tenor_months`).Scan(&tenors).Error
+	if err != nil {
+		return nil, err
+	}
+	return tenors, nil
+}
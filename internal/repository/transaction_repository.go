@@ -1,55 +1,98 @@
 package repository
 
 import (
-	"errors"
+	"database/sql"
+	"fmt"
+	"kreditplus/config"
 	"kreditplus/internal/domain"
 	"kreditplus/internal/utils"
 	"time"
 
+	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
 type TransactionRepository interface {
 	WithTransaction(fn func(tx *gorm.DB) error) error
+	GetRetryMetrics() domain.TransactionRetryMetrics
 	CreateTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error
 	GetAllTransactions(transaction, offset int) ([]domain.Transaction, error)
 	GetTransactionByID(id uint) (*domain.Transaction, error)
+	GetTransactionByIDWithTx(tx *gorm.DB, id uint) (*domain.Transaction, error)
+	GetDelinquentTransactions(collectibility string, minDaysPastDue, limit, offset int) ([]domain.Transaction, error)
+	GetTransactionIDsForOverdueReview() ([]uint, error)
+	GetTransactionsByLimitIDWithTx(tx *gorm.DB, limitID uint, statuses []string) ([]domain.Transaction, error)
+	ReassignTransactionsLimitWithTx(tx *gorm.DB, fromLimitIDs []uint, toLimitID uint) error
 	UpdateTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error
-	DeleteTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error
+	UpdateTransactionDelinquencyWithTx(tx *gorm.DB, transactionID uint, daysPastDue int, collectibility string) error
+	UpdateTransactionStatusWithTx(tx *gorm.DB, transaction *domain.Transaction) error
+	CreateTransactionStatusHistoryWithTx(tx *gorm.DB, history *domain.TransactionStatusHistory) error
+	GetTransactionStatusHistory(transactionID uint) ([]domain.TransactionStatusHistory, error)
+	CreateTransactionRestructureWithTx(tx *gorm.DB, restructure *domain.TransactionRestructure) error
+	GetTransactionVersions(originalNumber string) ([]domain.Transaction, error)
+	GetTransactionRestructures(originalNumber string) ([]domain.TransactionRestructure, error)
+	GetRepaymentHistoryByNIK(nik string) (*domain.RepaymentHistory, error)
 }
 
 type transactionRepository struct {
-	db *gorm.DB
+	db          *gorm.DB
+	retryConfig config.TransactionRetryConfig
 }
 
-func NewTransactionRepository(db *gorm.DB) TransactionRepository {
-	return &transactionRepository{db: db}
+func NewTransactionRepository(db *gorm.DB, retryConfig config.TransactionRetryConfig) TransactionRepository {
+	return &transactionRepository{db: db, retryConfig: retryConfig}
 }
 
 func (r *transactionRepository) WithTransaction(fn func(tx *gorm.DB) error) error {
-	const maxRetries = 3
-	var err error
+	retryMetrics.recordTransaction()
 
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		tx := r.db.Begin()
-		if tx.Error != nil {
-			return tx.Error
-		}
-
-		err = fn(tx)
-		if err != nil {
-			tx.Rollback()
+	maxAttempts := max(r.retryConfig.MaxAttempts, 1)
+	for attempt := 1; ; attempt++ {
+		err := r.runTransaction(fn)
 
-			if err.Error() == "database is locked" || err.Error() == "deadlock detected" {
-				utils.Logger.Warnf("Deadlock detected. Retrying transaction %d/%d", attempt+1, maxRetries)
-				time.Sleep(time.Millisecond * 100)
-				continue
+		code := retryableSQLState(err)
+		if code == "" {
+			if err == nil && attempt > 1 {
+				retryMetrics.recordRecovered()
 			}
 			return err
 		}
-		return tx.Commit().Error
+
+		if attempt >= maxAttempts {
+			retryMetrics.recordExhausted()
+			utils.Logger.WithFields(logrus.Fields{
+				"sqlstate": code,
+				"attempts": attempt,
+			}).Error("Transaction failed after exhausting retries")
+			return fmt.Errorf("transaction failed after %d attempts: %w", attempt, err)
+		}
+
+		retryMetrics.recordRetry(code)
+		delay := retryBackoff(r.retryConfig, attempt)
+		utils.Logger.WithFields(logrus.Fields{
+			"sqlstate": code,
+			"attempt":  attempt,
+			"delay":    delay.String(),
+		}).Warn("Retrying transaction after concurrency conflict")
+		time.Sleep(delay)
 	}
-	return errors.New("failed to process transaction after multiple retries: " + err.Error())
+}
+
+func (r *transactionRepository) runTransaction(fn func(tx *gorm.DB) error) error {
+	tx := r.db.Begin(&sql.TxOptions{Isolation: r.retryConfig.IsolationLevel})
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit().Error
+}
+
+func (r *transactionRepository) GetRetryMetrics() domain.TransactionRetryMetrics {
+	return retryMetrics.snapshot()
 }
 
 func (r *transactionRepository) CreateTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
@@ -95,10 +138,161 @@ func (r *transactionRepository) GetTransactionByID(id uint) (*domain.Transaction
 	return &transaction, nil
 }
 
+func (r *transactionRepository) GetTransactionByIDWithTx(tx *gorm.DB, id uint) (*domain.Transaction, error) {
+	var transaction domain.Transaction
+	err := tx.Raw(`SELECT * FROM transactions WHERE transaction_id = ? FOR UPDATE`, id).Scan(&transaction).Error
+	if err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+func (r *transactionRepository) GetDelinquentTransactions(collectibility string, minDaysPastDue, limit, offset int) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	query := r.db.Preload("NIKCustomer").
+		Preload("IDLimit").
+		Where("transaction_days_past_due > ? AND transaction_days_past_due >= ?", 0, minDaysPastDue)
+
+	if collectibility != "" {
+		query = query.Where("transaction_collectibility = ?", collectibility)
+	}
+
+	err := query.Order("transaction_days_past_due DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *transactionRepository) GetTransactionIDsForOverdueReview() ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.Transaction{}).
+		Where("transaction_status = ? AND (transaction_days_past_due > ? OR transaction_id IN (?))", domain.TransactionStatusActive, 0,
+			r.db.Model(&domain.Installment{}).
+				Select("installment_transaction_id").
+				Where("installment_status IN ?", []string{domain.InstallmentStatusUnpaid, domain.InstallmentStatusPartial})).
+		Order("transaction_id").
+		Pluck("transaction_id", &ids).Error
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (r *transactionRepository) GetTransactionsByLimitIDWithTx(tx *gorm.DB, limitID uint, statuses []string) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := tx.Where("transaction_limit = ? AND transaction_status IN ?", limitID, statuses).
+		Order("transaction_id").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *transactionRepository) ReassignTransactionsLimitWithTx(tx *gorm.DB, fromLimitIDs []uint, toLimitID uint) error {
+	return tx.Model(&domain.Transaction{}).
+		Where("transaction_limit IN ?", fromLimitIDs).
+		Update("transaction_limit", toLimitID).Error
+}
+
 func (r *transactionRepository) UpdateTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
 	return tx.Save(transaction).Error
 }
 
-func (r *transactionRepository) DeleteTransactionWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
-	return tx.Delete(&transaction).Error
+func (r *transactionRepository) UpdateTransactionDelinquencyWithTx(tx *gorm.DB, transactionID uint, daysPastDue int, collectibility string) error {
+	return tx.Model(&domain.Transaction{}).
+		Where("transaction_id = ?", transactionID).
+		Updates(map[string]interface{}{
+			"transaction_days_past_due":  daysPastDue,
+			"transaction_collectibility": collectibility,
+		}).Error
+}
+
+func (r *transactionRepository) UpdateTransactionStatusWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
+	return tx.Model(&domain.Transaction{}).
+		Where("transaction_id = ?", transaction.TransactionID).
+		Updates(map[string]interface{}{
+			"transaction_status":         transaction.TransactionStatus,
+			"transaction_date":           transaction.TransactionDate,
+			"transaction_days_past_due":  transaction.TransactionDaysPastDue,
+			"transaction_collectibility": transaction.TransactionCollectibility,
+			"transaction_edited_by":      transaction.TransactionEditedBy,
+			"transaction_edited_at":      transaction.TransactionEditedAt,
+		}).Error
+}
+
+func (r *transactionRepository) CreateTransactionStatusHistoryWithTx(tx *gorm.DB, history *domain.TransactionStatusHistory) error {
+	return tx.Create(history).Error
+}
+
+func (r *transactionRepository) GetTransactionStatusHistory(transactionID uint) ([]domain.TransactionStatusHistory, error) {
+	var histories []domain.TransactionStatusHistory
+	err := r.db.Preload("ChangedByUser").
+		Where("history_transaction_id = ?", transactionID).
+		Order("history_changed_at, history_id").
+		Find(&histories).Error
+	if err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+func (r *transactionRepository) CreateTransactionRestructureWithTx(tx *gorm.DB, restructure *domain.TransactionRestructure) error {
+	return tx.Create(restructure).Error
+}
+
+func (r *transactionRepository) GetTransactionVersions(originalNumber string) ([]domain.Transaction, error) {
+	var transactions []domain.Transaction
+	err := r.db.Where("transaction_original_number = ? OR transaction_contract_number = ?", originalNumber, originalNumber).
+		Order("transaction_version, transaction_id").
+		Find(&transactions).Error
+	if err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+func (r *transactionRepository) GetTransactionRestructures(originalNumber string) ([]domain.TransactionRestructure, error) {
+	var restructures []domain.TransactionRestructure
+	err := r.db.Preload("CreatedByUser").
+		Where("restructure_to_transaction_id IN (?)", r.db.Model(&domain.Transaction{}).
+			Select("transaction_id").
+			Where("transaction_original_number = ?", originalNumber)).
+		Order("restructure_created_at, restructure_id").
+		Find(&restructures).Error
+	if err != nil {
+		return nil, err
+	}
+	return restructures, nil
+}
+
+func (r *transactionRepository) GetRepaymentHistoryByNIK(nik string) (*domain.RepaymentHistory, error) {
+	var history domain.RepaymentHistory
+	err := r.db.Raw(`SELECT COUNT(*) AS total_contracts,
+			COUNT(*) FILTER (WHERE transaction_status = ?) AS paid_off_contracts,
+			COUNT(*) FILTER (WHERE transaction_status = ? AND transaction_collectibility <> ?) AS delinquent_contracts,
+			COUNT(*) FILTER (WHERE transaction_status = ?) AS written_off_contracts,
+			COALESCE(MAX(transaction_days_past_due), 0) AS max_days_past_due
+		FROM transactions WHERE transaction_nik = ? AND transaction_status NOT IN (?, ?)`,
+		domain.TransactionStatusPaidOff,
+		domain.TransactionStatusActive, domain.CollectibilityCurrent,
+		domain.TransactionStatusWrittenOff,
+		nik, domain.TransactionStatusDraft, domain.TransactionStatusCancelled).Scan(&history).Error
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.Raw(`SELECT COUNT(*) FROM installments
+		JOIN transactions ON transactions.transaction_id = installments.installment_transaction_id
+		WHERE transactions.transaction_nik = ? AND installments.installment_status <> ?
+			AND installments.installment_paid_at::date > installments.installment_due_date::date`, nik, domain.InstallmentStatusVoid).
+		Scan(&history.LateInstallments).Error
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
 }
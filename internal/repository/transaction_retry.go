@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+var retryableSQLStates = map[string]bool{
+	sqlStateDeadlockDetected:     true,
+	sqlStateSerializationFailure: true,
+	sqlStateLockNotAvailable:     true,
+}
+
+type transactionRetryMetrics struct {
+	mu            sync.Mutex
+	transactions  int64
+	retries       int64
+	recovered     int64
+	exhausted     int64
+	retriesByCode map[string]int64
+}
+
+var retryMetrics = &transactionRetryMetrics{retriesByCode: map[string]int64{}}
+
+func (m *transactionRetryMetrics) recordTransaction() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.transactions++
+}
+
+func (m *transactionRetryMetrics) recordRetry(code string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.retries++
+	m.retriesByCode[code]++
+}
+
+func (m *transactionRetryMetrics) recordRecovered() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.recovered++
+}
+
+func (m *transactionRetryMetrics) recordExhausted() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.exhausted++
+}
+
+func (m *transactionRetryMetrics) snapshot() domain.TransactionRetryMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	retriesByCode := make(map[string]int64, len(m.retriesByCode))
+	for code, count := range m.retriesByCode {
+		retriesByCode[code] = count
+	}
+
+	return domain.TransactionRetryMetrics{
+		Transactions:  m.transactions,
+		Retries:       m.retries,
+		Recovered:     m.recovered,
+		Exhausted:     m.exhausted,
+		RetriesByCode: retriesByCode,
+	}
+}
+
+func retryableSQLState(err error) string {
+	if code := sqlState(err); retryableSQLStates[code] {
+		return code
+	}
+	return ""
+}
+
+func retryBackoff(cfg config.TransactionRetryConfig, attempt int) time.Duration {
+	if cfg.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := cfg.BaseDelay << (attempt - 1)
+	if cfg.MaxDelay > 0 && (delay > cfg.MaxDelay || delay <= 0) {
+		delay = cfg.MaxDelay
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
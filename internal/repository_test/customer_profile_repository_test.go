@@ -0,0 +1,35 @@
+package repository_test
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/repository"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestSaveCustomerPhone_PrimaryClearsOtherPrimaries(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	profileRepo := repository.NewCustomerProfileRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "customer_phone_numbers" SET "phone_primary"=\$1 WHERE phone_customer_id = \$2 AND phone_id <> \$3`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "customer_phone_numbers" SET .* WHERE "phone_id" = \$\d+`).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	phone := &domain.CustomerPhoneNumber{PhoneID: 2, PhoneCustomerID: 3, PhoneNumber: "6281234567890", PhonePrimary: true, PhoneCreatedBy: 1}
+	err = profileRepo.SaveCustomerPhone(phone)
+
+	assert.Nil(t, err)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
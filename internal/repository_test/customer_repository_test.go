@@ -3,6 +3,7 @@ package repository_test
 import (
 	"errors"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
 	"kreditplus/internal/repository"
 	"strings"
 	"testing"
@@ -30,16 +31,26 @@ func TestCreateCustomer_Success(t *testing.T) {
 
 	mock.ExpectBegin()
 
-	mock.ExpectQuery(`INSERT INTO "customers" \("customer_nik","customer_full_name","customer_legal_name","customer_birth_place","customer_birth_date","customer_salary","customer_ktp_photo","customer_selfie_photo","customer_created_by","customer_created_at","customer_edited_by","customer_edited_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10,\$11,\$12\) RETURNING "customer_id"`).
+	mock.ExpectQuery(`INSERT INTO "customers" \("customer_nik","customer_full_name","customer_legal_name","customer_birth_place","customer_birth_date","customer_salary","customer_ktp_photo","customer_selfie_photo","customer_phone","customer_email","customer_ktp_phash","customer_selfie_phash","customer_risk_grade","customer_kyc_status","customer_kyc_reason","customer_kyc_submitted_at","customer_kyc_reviewed_by","customer_kyc_reviewed_at","customer_created_by","customer_created_at","customer_edited_by","customer_edited_at"\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10,\$11,\$12,\$13,\$14,\$15,\$16,\$17,\$18,\$19,\$20,\$21,\$22\) RETURNING "customer_id"`).
 		WithArgs(
 			"1234567890123456",
 			"John Doe",
 			"John D",
 			"Jakarta",
 			sqlmock.AnyArg(),
-			float64(0),
+			"0.00",
 			"",
 			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"pending",
+			"",
+			nil,
+			nil,
+			nil,
 			0,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
@@ -55,7 +66,7 @@ func TestCreateCustomer_Success(t *testing.T) {
 		CustomerLegalName:   "John D",
 		CustomerBirthPlace:  "Jakarta",
 		CustomerBirthDate:   time.Now(),
-		CustomerSalary:      0,
+		CustomerSalary:      money.New(0),
 		CustomerKTPPhoto:    "",
 		CustomerSelfiePhoto: "",
 		CustomerCreatedBy:   0,
@@ -101,9 +112,19 @@ func TestCreateCustomer_DBError(t *testing.T) {
 			"John D",           // customer_legal_name
 			"Jakarta",          // customer_birth_place
 			sqlmock.AnyArg(),   // customer_birth_date (dynamic timestamp)
-			float64(0),         // customer_salary (must be float64)
+			"0.00",             // customer_salary (decimal string)
 			"",                 // customer_ktp_photo (empty string)
 			"",                 // customer_selfie_photo (empty string)
+			"",                 // customer_phone
+			"",                 // customer_email
+			"",                 // customer_ktp_phash
+			"",                 // customer_selfie_phash
+			"",                 // customer_risk_grade (ungraded)
+			"pending",          // customer_kyc_status (default)
+			"",                 // customer_kyc_reason
+			nil,                // customer_kyc_submitted_at
+			nil,                // customer_kyc_reviewed_by
+			nil,                // customer_kyc_reviewed_at
 			0,                  // customer_created_by
 			sqlmock.AnyArg(),   // customer_created_at (dynamic timestamp)
 			sqlmock.AnyArg(),   // customer_edited_by (can be nil)
@@ -120,7 +141,7 @@ func TestCreateCustomer_DBError(t *testing.T) {
 		CustomerLegalName:   "John D",
 		CustomerBirthPlace:  "Jakarta",
 		CustomerBirthDate:   time.Now(),
-		CustomerSalary:      0,
+		CustomerSalary:      money.New(0),
 		CustomerKTPPhoto:    "",
 		CustomerSelfiePhoto: "",
 		CustomerCreatedBy:   0,
@@ -223,6 +244,24 @@ func TestGetCustomerByID_Success(t *testing.T) {
 		WithArgs(1, 1).
 		WillReturnRows(sqlmock.NewRows([]string{"customer_id", "customer_nik", "customer_full_name"}).
 			AddRow(1, "1234567890123456", "John Doe"))
+	mock.ExpectQuery(`SELECT \* FROM "customer_addresses" WHERE "customer_addresses"."address_customer_id" = \$1 ORDER BY address_type`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"address_id", "address_customer_id", "address_type", "address_city"}).
+			AddRow(1, 1, "ktp", "Jakarta Selatan"))
+	mock.ExpectQuery(`SELECT \* FROM "customer_email_addresses" WHERE "customer_email_addresses"."email_customer_id" = \$1 ORDER BY email_primary DESC, email_id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"email_id"}))
+	mock.ExpectQuery(`SELECT \* FROM "customer_emergency_contacts" WHERE "customer_emergency_contacts"."contact_customer_id" = \$1 ORDER BY contact_id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"contact_id"}))
+	mock.ExpectQuery(`SELECT \* FROM "customer_employments" WHERE "customer_employments"."employment_customer_id" = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"employment_id", "employment_customer_id", "employment_status"}).
+			AddRow(1, 1, "employed"))
+	mock.ExpectQuery(`SELECT \* FROM "customer_phone_numbers" WHERE "customer_phone_numbers"."phone_customer_id" = \$1 ORDER BY phone_primary DESC, phone_id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"phone_id", "phone_customer_id", "phone_number", "phone_primary"}).
+			AddRow(1, 1, "6281234567890", true))
 
 	customer, err := customerRepo.GetCustomerByID(1)
 
@@ -231,6 +270,10 @@ func TestGetCustomerByID_Success(t *testing.T) {
 	assert.Equal(t, uint(1), customer.CustomerID)
 	assert.Equal(t, "1234567890123456", customer.CustomerNIK)
 	assert.Equal(t, "John Doe", customer.CustomerFullName)
+	assert.Len(t, customer.CustomerAddresses, 1)
+	assert.Equal(t, domain.CustomerAddressTypeKTP, customer.CustomerAddresses[0].AddressType)
+	assert.Len(t, customer.CustomerPhones, 1)
+	assert.Equal(t, domain.EmploymentStatusEmployed, customer.CustomerEmployment.EmploymentStatus)
 
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("Unfulfilled expectations: %v", err)
@@ -379,9 +422,19 @@ func TestUpdateCustomer_Success(t *testing.T) {
 			"John D Updated",
 			"",
 			sqlmock.AnyArg(),
-			float64(0),
+			"0.00",
+			"",
 			"",
 			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			nil,
+			nil,
+			nil,
 			0,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
@@ -399,7 +452,7 @@ func TestUpdateCustomer_Success(t *testing.T) {
 		CustomerLegalName:   "John D Updated",
 		CustomerBirthPlace:  "",
 		CustomerBirthDate:   time.Time{},
-		CustomerSalary:      0,
+		CustomerSalary:      money.New(0),
 		CustomerKTPPhoto:    "",
 		CustomerSelfiePhoto: "",
 		CustomerCreatedBy:   0,
@@ -440,9 +493,19 @@ func TestUpdateCustomer_DBError(t *testing.T) {
 			"John D Updated",
 			"",
 			sqlmock.AnyArg(),
-			float64(0),
+			"0.00",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
 			"",
 			"",
+			"",
+			nil,
+			nil,
+			nil,
 			0,
 			sqlmock.AnyArg(),
 			nil,
@@ -460,7 +523,7 @@ func TestUpdateCustomer_DBError(t *testing.T) {
 		CustomerLegalName:   "John D Updated",
 		CustomerBirthPlace:  "",
 		CustomerBirthDate:   time.Time{},
-		CustomerSalary:      0,
+		CustomerSalary:      money.New(0),
 		CustomerKTPPhoto:    "",
 		CustomerSelfiePhoto: "",
 		CustomerCreatedBy:   0,
@@ -572,3 +635,56 @@ func TestDeleteCustomer_DBError(t *testing.T) {
 		t.Errorf("Unfulfilled expectations: %v", err)
 	}
 }
+
+func TestFindDuplicateCandidates_Success(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	customerRepo := repository.NewCustomerRepository(gormDB)
+
+	birthDate := time.Date(1990, time.September, 5, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT \* FROM "customers" WHERE customer_nik <> \$1 AND \(\(customer_birth_date::date = \$2::date AND \(regexp_split_to_array\(lower\(customer_full_name\), '\[\^\[:alpha:\]\]\+'\) && string_to_array\(\$3, ' '\) OR regexp_split_to_array\(lower\(customer_legal_name\), '\[\^\[:alpha:\]\]\+'\) && string_to_array\(\$4, ' '\)\)\) OR customer_phone = \$5 OR \(customer_ktp_phash <> '' AND bit_count\(\('x' \|\| customer_ktp_phash\)::bit\(64\) # \('x' \|\| \$6\)::bit\(64\)\) <= \$7\)\)`).
+		WithArgs("3171014509900001", birthDate, "budi santoso", "budi santoso", "6281234567890", "0f0f0f0f0f0f0f0f", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"customer_id", "customer_nik", "customer_phone"}).
+			AddRow(4, "3273014509900002", "6281234567890"))
+
+	candidates, err := customerRepo.FindDuplicateCandidates(&domain.Customer{
+		CustomerNIK:       "3171014509900001",
+		CustomerFullName:  "Budi Santoso",
+		CustomerLegalName: "H. Budi Santoso",
+		CustomerBirthDate: birthDate,
+		CustomerPhone:     "6281234567890",
+		CustomerKTPPhash:  "0f0f0f0f0f0f0f0f",
+	}, 10)
+
+	assert.Nil(t, err)
+	assert.Len(t, candidates, 1)
+	assert.Equal(t, "3273014509900002", candidates[0].CustomerNIK)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
+
+func TestFindDuplicateCandidates_BirthDateNeedsNameToken(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	customerRepo := repository.NewCustomerRepository(gormDB)
+
+	candidates, err := customerRepo.FindDuplicateCandidates(&domain.Customer{
+		CustomerNIK:       "3171014509900001",
+		CustomerBirthDate: time.Date(1990, time.September, 5, 0, 0, 0, 0, time.UTC),
+	}, 10)
+
+	assert.Nil(t, err)
+	assert.Empty(t, candidates, "A birth date without a name should not match every customer born that day")
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
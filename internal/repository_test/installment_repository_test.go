@@ -0,0 +1,268 @@
+package repository_test
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestCreateInstallmentsWithTx_Success(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	installmentRepo := repository.NewInstallmentRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "installments"`).
+		WillReturnRows(sqlmock.NewRows([]string{"installment_id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	installments := []domain.Installment{
+		{InstallmentTransactionID: 1, InstallmentNumber: 1, InstallmentDueDate: time.Now(), InstallmentAmount: money.New(370000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentTransactionID: 1, InstallmentNumber: 2, InstallmentDueDate: time.Now(), InstallmentAmount: money.New(370000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}
+
+	tx := gormDB.Begin()
+	err = installmentRepo.CreateInstallmentsWithTx(tx, installments)
+	tx.Commit()
+
+	assert.Nil(t, err, "Error should be nil on successful insert")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCreateInstallmentsWithTx_Empty(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	installmentRepo := repository.NewInstallmentRepository(gormDB)
+
+	err = installmentRepo.CreateInstallmentsWithTx(gormDB, nil)
+
+	assert.Nil(t, err, "Empty schedule should be a no-op")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetInstallmentsByTransactionID_Success(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	installmentRepo := repository.NewInstallmentRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT \* FROM "installments" WHERE installment_transaction_id = \$1 AND installment_status <> \$2 ORDER BY installment_number`).
+		WithArgs(1, domain.InstallmentStatusVoid).
+		WillReturnRows(sqlmock.NewRows([]string{"installment_id", "installment_transaction_id", "installment_number"}).
+			AddRow(1, 1, 1).
+			AddRow(2, 1, 2))
+
+	installments, err := installmentRepo.GetInstallmentsByTransactionID(1)
+
+	assert.Nil(t, err, "Error should be nil")
+	assert.Len(t, installments, 2, "Should return 2 installments")
+	assert.Equal(t, 2, installments[1].InstallmentNumber)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestVoidInstallmentsByTransactionIDWithTx_Success(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	installmentRepo := repository.NewInstallmentRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "installments" SET "installment_status"=\$1 WHERE \(installment_transaction_id = \$2 AND installment_status = \$3\) AND \(installment_paid_principal = 0 AND installment_paid_interest = 0 AND installment_paid_fee = 0 AND installment_paid_penalty = 0\)`).
+		WithArgs(domain.InstallmentStatusVoid, 1, domain.InstallmentStatusUnpaid).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	tx := gormDB.Begin()
+	err = installmentRepo.VoidInstallmentsByTransactionIDWithTx(tx, 1)
+	tx.Commit()
+
+	assert.Nil(t, err, "Error should be nil on successful void")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestCloseOutstandingInstallmentsWithTx_Success(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	installmentRepo := repository.NewInstallmentRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "installments" SET "installment_status"=\$1 WHERE installment_transaction_id = \$2 AND installment_status IN \(\$3,\$4\)`).
+		WithArgs(domain.InstallmentStatusRestructured, 1, domain.InstallmentStatusUnpaid, domain.InstallmentStatusPartial).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	tx := gormDB.Begin()
+	err = installmentRepo.CloseOutstandingInstallmentsWithTx(tx, 1, domain.InstallmentStatusRestructured)
+	tx.Commit()
+
+	assert.Nil(t, err, "Error should be nil when closing outstanding installments")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPaidPrincipalWithTx_Success(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	installmentRepo := repository.NewInstallmentRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(installment_paid_principal\), 0\) FROM "installments" WHERE installment_transaction_id = \$1 AND installment_status <> \$2`).
+		WithArgs(1, domain.InstallmentStatusVoid).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("300000.00"))
+	mock.ExpectCommit()
+
+	tx := gormDB.Begin()
+	paid, err := installmentRepo.GetPaidPrincipalWithTx(tx, 1)
+	tx.Commit()
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(300000), paid)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetOutstandingInstallmentsWithTx_LocksRows(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	installmentRepo := repository.NewInstallmentRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "installments" WHERE installment_transaction_id = \$1 AND installment_status IN \(\$2,\$3\) ORDER BY installment_number FOR UPDATE`).
+		WithArgs(1, domain.InstallmentStatusUnpaid, domain.InstallmentStatusPartial).
+		WillReturnRows(sqlmock.NewRows([]string{"installment_id", "installment_transaction_id", "installment_number"}).
+			AddRow(2, 1, 2))
+	mock.ExpectCommit()
+
+	tx := gormDB.Begin()
+	installments, err := installmentRepo.GetOutstandingInstallmentsWithTx(tx, 1)
+	tx.Commit()
+
+	assert.Nil(t, err, "Error should be nil")
+	assert.Len(t, installments, 1)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
+
+func TestUpdateInstallmentPenaltyWithTx_OnlyWritesPenaltyColumns(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	installmentRepo := repository.NewInstallmentRepository(gormDB)
+
+	accruedUntil := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE "installments" SET "installment_penalty"=\$1,"installment_penalty_until"=\$2 WHERE "installment_id" = \$3`).
+		WithArgs("1250.00", accruedUntil, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	tx := gormDB.Begin()
+	err = installmentRepo.UpdateInstallmentPenaltyWithTx(tx, &domain.Installment{
+		InstallmentID:           1,
+		InstallmentPenalty:      money.New(1250),
+		InstallmentPenaltyUntil: &accruedUntil,
+		InstallmentPaidInterest: money.New(20000),
+		InstallmentStatus:       domain.InstallmentStatusPartial,
+	})
+	tx.Commit()
+
+	assert.Nil(t, err, "Error should be nil")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -3,6 +3,7 @@ package repository_test
 import (
 	"errors"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
 	"kreditplus/internal/repository"
 	"testing"
 	"time"
@@ -33,9 +34,9 @@ func TestCreateLimit_Success(t *testing.T) {
 		WithArgs(
 			"1234567890123456",
 			12,
-			float64(5000000),
-			float64(0),
-			float64(0),
+			"5000000.00",
+			"0.00",
+			"0.00",
 			0,
 			sqlmock.AnyArg(),
 			nil,
@@ -48,9 +49,9 @@ func TestCreateLimit_Success(t *testing.T) {
 	limit := &domain.Limit{
 		LimitNIK:             "1234567890123456",
 		LimitTenor:           12,
-		LimitAmount:          5000000,
-		LimitUsedAmount:      0,
-		LimitRemainingAmount: 0,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(0),
+		LimitRemainingAmount: money.New(0),
 		LimitCreatedBy:       0,
 		LimitCreatedAt:       time.Now(),
 		LimitEditedBy:        nil,
@@ -82,9 +83,9 @@ func TestCreateLimit_DBError(t *testing.T) {
 		WithArgs(
 			"1234567890123456",
 			12,
-			float64(5000000),
-			float64(0),
-			float64(0),
+			"5000000.00",
+			"0.00",
+			"0.00",
 			0,
 			sqlmock.AnyArg(),
 			nil,
@@ -96,9 +97,9 @@ func TestCreateLimit_DBError(t *testing.T) {
 	limit := &domain.Limit{
 		LimitNIK:             "1234567890123456",
 		LimitTenor:           12,
-		LimitAmount:          5000000,
-		LimitUsedAmount:      0,
-		LimitRemainingAmount: 0,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(0),
+		LimitRemainingAmount: money.New(0),
 		LimitCreatedBy:       0,
 		LimitCreatedAt:       time.Now(),
 		LimitEditedBy:        nil,
@@ -211,7 +212,7 @@ func TestGetLimitByIDWithTx_Success(t *testing.T) {
 	assert.Nil(t, err, "Error should be nil on successful retrieval")
 	assert.NotNil(t, limit, "Limit should not be nil")
 	assert.Equal(t, "1234567890123456", limit.LimitNIK, "Expected limit NIK to match")
-	assert.Equal(t, 5000000.0, limit.LimitAmount, "Expected limit amount to match")
+	assert.Equal(t, money.New(5000000), limit.LimitAmount, "Expected limit amount to match")
 
 	if err == nil {
 		tx.Commit()
@@ -299,9 +300,9 @@ func TestUpdateLimitWithTx_Success(t *testing.T) {
 		WithArgs(
 			"",
 			0,
-			float64(6000000),
-			float64(1000000),
-			float64(5000000),
+			"6000000.00",
+			"1000000.00",
+			"5000000.00",
 			0,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
@@ -318,9 +319,9 @@ func TestUpdateLimitWithTx_Success(t *testing.T) {
 		LimitID:              1,
 		LimitNIK:             "",
 		LimitTenor:           0,
-		LimitAmount:          6000000,
-		LimitUsedAmount:      1000000,
-		LimitRemainingAmount: 5000000,
+		LimitAmount:          money.New(6000000),
+		LimitUsedAmount:      money.New(1000000),
+		LimitRemainingAmount: money.New(5000000),
 		LimitCreatedBy:       0,
 		LimitCreatedAt:       time.Time{},
 		LimitEditedBy:        new(uint),
@@ -357,9 +358,9 @@ func TestUpdateLimitWithTx_DBError(t *testing.T) {
 		WithArgs(
 			"",
 			0,
-			float64(6000000),
-			float64(1000000),
-			float64(5000000),
+			"6000000.00",
+			"1000000.00",
+			"5000000.00",
 			0,
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
@@ -376,9 +377,9 @@ func TestUpdateLimitWithTx_DBError(t *testing.T) {
 		LimitID:              1,
 		LimitNIK:             "",
 		LimitTenor:           0,
-		LimitAmount:          6000000,
-		LimitUsedAmount:      1000000,
-		LimitRemainingAmount: 5000000,
+		LimitAmount:          money.New(6000000),
+		LimitUsedAmount:      money.New(1000000),
+		LimitRemainingAmount: money.New(5000000),
 		LimitCreatedBy:       0,
 		LimitCreatedAt:       time.Time{},
 		LimitEditedBy:        new(uint),
@@ -477,3 +478,292 @@ func TestDeleteLimit_DBError(t *testing.T) {
 		t.Errorf("Unfulfilled expectations: %v", err)
 	}
 }
+
+func TestGetLimitByNIKandTenor_DoesNotLock(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT \* FROM limits WHERE limit_nik = \$1 AND limit_tenor = \$2$`).
+		WithArgs("1234567890123456", 3.0).
+		WillReturnRows(sqlmock.NewRows([]string{"limit_id", "limit_nik", "limit_tenor", "limit_remaining_amount"}).
+			AddRow(1, "1234567890123456", 3, 2000000.0))
+
+	limit, err := limitRepo.GetLimitByNIKandTenor("1234567890123456", 3)
+
+	assert.Nil(t, err, "Error should be nil on successful retrieval")
+	assert.Equal(t, uint(1), limit.LimitID)
+	assert.Equal(t, money.New(2000000), limit.LimitRemainingAmount)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetLimitLedger_Success(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	rows := sqlmock.NewRows([]string{"ledger_limit_id", "ledger_movement_count", "ledger_limit_amount", "ledger_used_amount", "ledger_remaining_amount"}).
+		AddRow(1, 3, "5000000.00", "1110000.00", "3890000.00")
+
+	mock.ExpectQuery(`SELECT movement_limit_id AS ledger_limit_id, .* FROM limit_movements WHERE movement_limit_id = \$1 GROUP BY movement_limit_id`).
+		WithArgs(1).
+		WillReturnRows(rows)
+
+	ledger, err := limitRepo.GetLimitLedger(1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), ledger.LedgerLimitID)
+	assert.Equal(t, int64(3), ledger.LedgerMovementCount)
+	assert.Equal(t, money.New(1110000), ledger.LedgerUsedAmount)
+	assert.Equal(t, money.New(3890000), ledger.LedgerRemainingAmount)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetLimitLedger_NoMovements(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectQuery(`FROM limit_movements WHERE movement_limit_id = \$1`).
+		WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"ledger_limit_id", "ledger_movement_count", "ledger_limit_amount", "ledger_used_amount", "ledger_remaining_amount"}))
+
+	ledger, err := limitRepo.GetLimitLedger(7)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(7), ledger.LedgerLimitID)
+	assert.Equal(t, int64(0), ledger.LedgerMovementCount)
+	assert.True(t, ledger.LedgerUsedAmount.IsZero())
+}
+
+func TestConsumeLimitWithTx_Applied(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE limits SET limit_used_amount = limit_used_amount \+ \$1, limit_remaining_amount = limit_remaining_amount - \$2\s+WHERE limit_id = \$3 AND limit_remaining_amount >= \$4 RETURNING \*`).
+		WithArgs("1110000.00", "1110000.00", 1, "1110000.00").
+		WillReturnRows(sqlmock.NewRows([]string{"limit_id", "limit_amount", "limit_used_amount", "limit_remaining_amount"}).
+			AddRow(1, "5000000.00", "1110000.00", "3890000.00"))
+	mock.ExpectCommit()
+
+	limit := &domain.Limit{LimitID: 1}
+
+	tx := gormDB.Begin()
+	applied, err := limitRepo.ConsumeLimitWithTx(tx, limit, money.New(1110000))
+	tx.Commit()
+
+	assert.Nil(t, err)
+	assert.True(t, applied)
+	assert.Equal(t, money.New(3890000), limit.LimitRemainingAmount, "Returned row should refresh the balance")
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestConsumeLimitWithTx_InsufficientRemaining(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE limits SET limit_used_amount = limit_used_amount \+ \$1`).
+		WithArgs("6000000.00", "6000000.00", 1, "6000000.00").
+		WillReturnRows(sqlmock.NewRows([]string{"limit_id", "limit_amount", "limit_used_amount", "limit_remaining_amount"}))
+	mock.ExpectRollback()
+
+	limit := &domain.Limit{LimitID: 1, LimitRemainingAmount: money.New(5000000)}
+
+	tx := gormDB.Begin()
+	applied, err := limitRepo.ConsumeLimitWithTx(tx, limit, money.New(6000000))
+	tx.Rollback()
+
+	assert.Nil(t, err)
+	assert.False(t, applied, "No row should match when remaining is below the amount")
+	assert.Equal(t, money.New(5000000), limit.LimitRemainingAmount)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestReleaseLimitWithTx_NotFound(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`UPDATE limits SET limit_used_amount = limit_used_amount - \$1, limit_remaining_amount = limit_remaining_amount \+ \$2\s+WHERE limit_id = \$3 RETURNING \*`).
+		WithArgs("100000.00", "100000.00", 9).
+		WillReturnRows(sqlmock.NewRows([]string{"limit_id"}))
+	mock.ExpectRollback()
+
+	tx := gormDB.Begin()
+	err = limitRepo.ReleaseLimitWithTx(tx, &domain.Limit{LimitID: 9}, money.New(100000))
+	tx.Rollback()
+
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestGetUsedAmountByNIKWithTx_Success(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(limit_used_amount\), 0\) FROM "limits" WHERE limit_nik = \$1`).
+		WithArgs("1234567890123456").
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow("4500000.00"))
+
+	used, err := limitRepo.GetUsedAmountByNIKWithTx(gormDB, "1234567890123456")
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(4500000), used)
+}
+
+func TestCreateLimitIfAbsentWithTx_Conflict(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "limits" .* ON CONFLICT \("limit_nik","limit_tenor"\) DO NOTHING RETURNING "limit_id"`).
+		WillReturnRows(sqlmock.NewRows([]string{"limit_id"}))
+	mock.ExpectCommit()
+
+	created, err := limitRepo.CreateLimitIfAbsentWithTx(gormDB, &domain.Limit{
+		LimitNIK:             "1234567890123456",
+		LimitTenor:           3,
+		LimitAmount:          money.New(5000000),
+		LimitRemainingAmount: money.New(5000000),
+	})
+
+	assert.Nil(t, err)
+	assert.False(t, created, "Existing NIK and tenor should not be inserted again")
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %v", err)
+	}
+}
+
+func TestGetDuplicateLimitKeys_Success(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT limit_nik, limit_tenor FROM "limits" GROUP BY limit_nik, limit_tenor HAVING COUNT\(\*\) > 1`).
+		WillReturnRows(sqlmock.NewRows([]string{"limit_nik", "limit_tenor"}).AddRow("1234567890123456", 3))
+
+	keys, err := limitRepo.GetDuplicateLimitKeys()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []domain.LimitKey{{LimitNIK: "1234567890123456", LimitTenor: 3}}, keys)
+}
+
+func TestCreateOpeningLimitMovements_SkipsLimitsWithMovements(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectExec(`INSERT INTO limit_movements .+ SELECT limit_id, \$1, limit_amount, limit_used_amount, limit_remaining_amount, .+ FROM limits WHERE NOT EXISTS \(SELECT 1 FROM limit_movements WHERE movement_limit_id = limits.limit_id\)`).
+		WithArgs(domain.LimitMovementAdjust, "opening balance").
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	opened, err := limitRepo.CreateOpeningLimitMovements()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), opened)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCreateOpeningLimitMovements_DBError(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	limitRepo := repository.NewLimitRepository(gormDB)
+
+	mock.ExpectExec(`INSERT INTO limit_movements`).WillReturnError(errors.New("db error"))
+
+	opened, err := limitRepo.CreateOpeningLimitMovements()
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), opened)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
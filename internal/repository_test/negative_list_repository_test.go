@@ -0,0 +1,38 @@
+package repository_test
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestFindActiveMatches_Success(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	negativeListRepo := repository.NewNegativeListRepository(gormDB)
+
+	at := time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	mock.ExpectQuery(`SELECT \* FROM "negative_list_entries" WHERE negative_list_active = \$1 AND \(negative_list_expires_at IS NULL OR negative_list_expires_at > \$2\) AND \(\(negative_list_type = \$3 AND negative_list_value = \$4\) OR \(negative_list_type = \$5 AND negative_list_value IN \(\$6\)\) OR \(negative_list_type = \$7 AND negative_list_value IN \(\$8\)\)\) ORDER BY negative_list_id`).
+		WithArgs(true, at, "nik", "3171014509900001", "name", "rahayu siti", "phone", "6281234567890").
+		WillReturnRows(sqlmock.NewRows([]string{"negative_list_id", "negative_list_type", "negative_list_value", "negative_list_severity"}).
+			AddRow(1, "phone", "6281234567890", "block"))
+
+	entries, err := negativeListRepo.FindActiveMatches("3171014509900001", []string{"rahayu siti"}, []string{"6281234567890"}, at)
+
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, domain.NegativeListSeverityBlock, entries[0].NegativeListSeverity)
+	assert.Nil(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestCreatePaymentWithTx_Success(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create SQL mock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	paymentRepo := repository.NewPaymentRepository(gormDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO "payments"`).
+		WillReturnRows(sqlmock.NewRows([]string{"payment_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	payment := &domain.Payment{
+		PaymentTransactionID:   1,
+		PaymentAmount:          money.New(150000),
+		PaymentChannel:         "bank_transfer",
+		PaymentPaidAt:          time.Now(),
+		PaymentPrincipalAmount: money.New(100000),
+		PaymentCreatedBy:       1,
+	}
+
+	tx := gormDB.Begin()
+	err = paymentRepo.CreatePaymentWithTx(tx, payment)
+	tx.Commit()
+
+	assert.Nil(t, err, "Error should be nil on successful insert")
+	assert.Equal(t, uint(1), payment.PaymentID)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %s", err)
+	}
+}
+
+func TestGetPaymentsByTransactionID_Success(t *testing.T) {
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Failed to create sqlmock: %v", err)
+	}
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	paymentRepo := repository.NewPaymentRepository(gormDB)
+
+	mock.ExpectQuery(`SELECT \* FROM "payments" WHERE payment_transaction_id = \$1 ORDER BY payment_paid_at, payment_id`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"payment_id", "payment_transaction_id", "payment_amount", "payment_created_by"}).
+			AddRow(1, 1, 150000.0, 1))
+
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."user_id" = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "user_username"}).AddRow(1, "admin"))
+
+	payments, err := paymentRepo.GetPaymentsByTransactionID(1)
+
+	assert.Nil(t, err, "Error should be nil")
+	assert.Len(t, payments, 1)
+	assert.Equal(t, money.New(150000), payments[0].PaymentAmount)
+	assert.Equal(t, "admin", payments[0].CreatedByUser.UserUsername)
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("There were unfulfilled expectations: %s", err)
+	}
+}
@@ -1,13 +1,18 @@
 package repository_test
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"kreditplus/config"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
 	"kreditplus/internal/repository"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -22,7 +27,7 @@ func TestWithTransaction_Success(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectBegin()
 	mock.ExpectCommit()
@@ -43,14 +48,15 @@ func TestWithTransaction_DeadlockRetry(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{MaxAttempts: 3})
+	before := transactionRepo.GetRetryMetrics()
 
 	// Simulate deadlock on the first two attempts
 	mock.ExpectBegin()
-	mock.ExpectRollback().WillReturnError(errors.New("deadlock detected"))
+	mock.ExpectRollback()
 
 	mock.ExpectBegin()
-	mock.ExpectRollback().WillReturnError(errors.New("deadlock detected"))
+	mock.ExpectRollback()
 
 	// Third attempt should succeed
 	mock.ExpectBegin()
@@ -63,7 +69,7 @@ func TestWithTransaction_DeadlockRetry(t *testing.T) {
 	err = transactionRepo.WithTransaction(func(tx *gorm.DB) error {
 		attempt++
 		if attempt < 3 {
-			return errors.New("deadlock detected") // Simulate deadlock for the first two attempts
+			return fmt.Errorf("update limit: %w", &pgconn.PgError{Code: "40P01", Message: "deadlock detected"})
 		}
 		return nil // Success on the third attempt
 	})
@@ -72,12 +78,106 @@ func TestWithTransaction_DeadlockRetry(t *testing.T) {
 	assert.Nil(t, err, "Transaction should succeed after retrying deadlock")
 	assert.Equal(t, 3, attempt, "Transaction should be retried 3 times")
 
+	after := transactionRepo.GetRetryMetrics()
+	assert.Equal(t, int64(2), after.Retries-before.Retries)
+	assert.Equal(t, int64(2), after.RetriesByCode["40P01"]-before.RetriesByCode["40P01"])
+	assert.Equal(t, int64(1), after.Recovered-before.Recovered)
+
 	// Ensure all mock expectations were met
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("Unfulfilled expectations: %s", err)
 	}
 }
 
+func TestWithTransaction_SerializationFailureOnCommitRetried(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{MaxAttempts: 2, IsolationLevel: sql.LevelSerializable})
+
+	mock.ExpectBegin()
+	mock.ExpectCommit().WillReturnError(&pgconn.PgError{Code: "40001", Message: "could not serialize access"})
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+
+	attempt := 0
+	err = transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		attempt++
+		return nil
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempt, "Commit serialization failure should rerun the transaction")
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %s", err)
+	}
+}
+
+func TestWithTransaction_RetriesExhausted(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{MaxAttempts: 2})
+	before := transactionRepo.GetRetryMetrics()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	lockErr := &pgconn.PgError{Code: "55P03", Message: "could not obtain lock"}
+	attempt := 0
+	err = transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		attempt++
+		return lockErr
+	})
+
+	var pgErr *pgconn.PgError
+	assert.True(t, errors.As(err, &pgErr), "Original SQLSTATE error should be preserved")
+	assert.Equal(t, 2, attempt)
+	assert.Equal(t, int64(1), transactionRepo.GetRetryMetrics().Exhausted-before.Exhausted)
+}
+
+func TestWithTransaction_NonRetryableErrorNotRetried(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{MaxAttempts: 3})
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	attempt := 0
+	err = transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		attempt++
+		if attempt == 1 {
+			return &pgconn.PgError{Code: "23505", Message: "duplicate key value"}
+		}
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempt, "Unique violations should not be retried")
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Unfulfilled expectations: %s", err)
+	}
+}
+
 func TestWithTransaction_Failure(t *testing.T) {
 	sqlDB, mock, _ := sqlmock.New()
 	defer sqlDB.Close()
@@ -87,7 +187,7 @@ func TestWithTransaction_Failure(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectBegin()
 	mock.ExpectRollback()
@@ -111,7 +211,7 @@ func TestCreateTransactionWithTx_Success(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectBegin()
 
@@ -120,8 +220,11 @@ func TestCreateTransactionWithTx_Success(t *testing.T) {
 			"TRX-001",
 			"1234567890123456",
 			0,
-			1000000.0,
-			50000.0,
+			nil,
+			nil,
+			false,
+			"1000000.00",
+			"50000.00",
 			200000.0,
 			10000.0,
 			"Car",
@@ -130,6 +233,13 @@ func TestCreateTransactionWithTx_Success(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			0,
+			"",
+			"",
+			"",
+			nil,
+			0,
+			"",
 		).
 		WillReturnRows(sqlmock.NewRows([]string{"transaction_id"}).AddRow(1))
 
@@ -138,8 +248,8 @@ func TestCreateTransactionWithTx_Success(t *testing.T) {
 	transaction := &domain.Transaction{
 		TransactionContractNumber: "TRX-001",
 		TransactionNIK:            "1234567890123456",
-		TransactionOTR:            1000000.0,
-		TransactionAdminFee:       50000.0,
+		TransactionOTR:            money.New(1000000),
+		TransactionAdminFee:       money.New(50000),
 		TransactionInstallment:    200000.0,
 		TransactionInterest:       10000.0,
 		TransactionAssetName:      "Car",
@@ -178,7 +288,7 @@ func TestCreateTransactionWithTx_DBError(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectBegin()
 
@@ -187,8 +297,11 @@ func TestCreateTransactionWithTx_DBError(t *testing.T) {
 			"TRX-001",
 			"1234567890123456",
 			0,
-			1000000.0,
-			50000.0,
+			nil,
+			nil,
+			false,
+			"1000000.00",
+			"50000.00",
 			200000.0,
 			10000.0,
 			"Car",
@@ -197,6 +310,13 @@ func TestCreateTransactionWithTx_DBError(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			0,
+			"",
+			"",
+			"",
+			nil,
+			0,
+			"",
 		).
 		WillReturnError(gorm.ErrInvalidTransaction)
 
@@ -205,8 +325,8 @@ func TestCreateTransactionWithTx_DBError(t *testing.T) {
 	transaction := &domain.Transaction{
 		TransactionContractNumber: "TRX-001",
 		TransactionNIK:            "1234567890123456",
-		TransactionOTR:            1000000.0,
-		TransactionAdminFee:       50000.0,
+		TransactionOTR:            money.New(1000000),
+		TransactionAdminFee:       money.New(50000),
 		TransactionInstallment:    200000.0,
 		TransactionInterest:       10000.0,
 		TransactionAssetName:      "Car",
@@ -237,7 +357,7 @@ func TestGetAllTransactions_Success(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectQuery(`SELECT \* FROM "transactions" LIMIT \$1`).
 		WithArgs(10).
@@ -276,7 +396,7 @@ func TestGetAllTransactions_DBError(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectQuery(`SELECT \* FROM "transactions" LIMIT \$1`).
 		WithArgs(10).
@@ -302,7 +422,7 @@ func TestGetTransactionByID_Success(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "transactions"."transaction_id" = \$1 ORDER BY "transactions"."transaction_id" LIMIT \$2`).
 		WithArgs(1, 1).
@@ -326,7 +446,7 @@ func TestGetTransactionByID_Success(t *testing.T) {
 	assert.Nil(t, err, "Error should be nil")
 	assert.NotNil(t, transaction, "Transaction should not be nil")
 	assert.Equal(t, "1234567890123456", transaction.TransactionNIK)
-	assert.Equal(t, 5000000.0, transaction.TransactionOTR)
+	assert.Equal(t, money.New(5000000), transaction.TransactionOTR)
 }
 
 func TestGetTransactionByID_NotFound(t *testing.T) {
@@ -338,7 +458,7 @@ func TestGetTransactionByID_NotFound(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "transactions"."transaction_id" = \$1 ORDER BY "transactions"."transaction_id" LIMIT \$2`).
 		WithArgs(99, 1).
@@ -364,7 +484,7 @@ func TestGetTransactionByID_DBError(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "transactions"."transaction_id" = \$1 ORDER BY "transactions"."transaction_id" LIMIT \$2`).
 		WithArgs(1, 1).
@@ -390,7 +510,7 @@ func TestUpdateTransactionWithTx_Success(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectBegin()
 
@@ -399,8 +519,11 @@ func TestUpdateTransactionWithTx_Success(t *testing.T) {
 			"",
 			"1234567890123456",
 			int64(0),
-			5000000.0,
-			100000.0,
+			nil,
+			nil,
+			false,
+			"5000000.00",
+			"100000.00",
 			500000.0,
 			5.0,
 			"Motorcycle",
@@ -409,6 +532,13 @@ func TestUpdateTransactionWithTx_Success(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			int64(0),
+			"",
+			"",
+			"",
+			nil,
+			int64(0),
+			"",
 			int64(1),
 		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
@@ -421,8 +551,8 @@ func TestUpdateTransactionWithTx_Success(t *testing.T) {
 		TransactionID:             1,
 		TransactionNIK:            "1234567890123456",
 		TransactionLimit:          0,
-		TransactionOTR:            5000000.0,
-		TransactionAdminFee:       100000.0,
+		TransactionOTR:            money.New(5000000),
+		TransactionAdminFee:       money.New(100000),
 		TransactionInstallment:    500000.0,
 		TransactionInterest:       5.0,
 		TransactionAssetName:      "Motorcycle",
@@ -448,7 +578,7 @@ func TestUpdateTransactionWithTx_DBError(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectBegin()
 
@@ -457,8 +587,11 @@ func TestUpdateTransactionWithTx_DBError(t *testing.T) {
 			"",
 			"1234567890123456",
 			int64(0),
-			5000000.0,
-			100000.0,
+			nil,
+			nil,
+			false,
+			"5000000.00",
+			"100000.00",
 			500000.0,
 			5.0,
 			"Motorcycle",
@@ -467,6 +600,13 @@ func TestUpdateTransactionWithTx_DBError(t *testing.T) {
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
 			sqlmock.AnyArg(),
+			int64(0),
+			"",
+			"",
+			"",
+			nil,
+			int64(0),
+			"",
 			int64(1),
 		).
 		WillReturnError(gorm.ErrInvalidTransaction)
@@ -479,8 +619,8 @@ func TestUpdateTransactionWithTx_DBError(t *testing.T) {
 		TransactionID:             1,
 		TransactionNIK:            "1234567890123456",
 		TransactionLimit:          0,
-		TransactionOTR:            5000000.0,
-		TransactionAdminFee:       100000.0,
+		TransactionOTR:            money.New(5000000),
+		TransactionAdminFee:       money.New(100000),
 		TransactionInstallment:    500000.0,
 		TransactionInterest:       5.0,
 		TransactionAssetName:      "Motorcycle",
@@ -498,7 +638,7 @@ func TestUpdateTransactionWithTx_DBError(t *testing.T) {
 	assert.Equal(t, gorm.ErrInvalidTransaction, err, "Expected gorm.ErrInvalidTransaction error")
 }
 
-func TestDeleteTransactionWithTx_Success(t *testing.T) {
+func TestUpdateTransactionStatusWithTx_Success(t *testing.T) {
 	sqlDB, mock, _ := sqlmock.New()
 	defer sqlDB.Close()
 
@@ -507,30 +647,47 @@ func TestDeleteTransactionWithTx_Success(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectBegin()
 
-	mock.ExpectExec(`DELETE FROM "transactions" WHERE "transactions"."transaction_id" = \$1`).
-		WithArgs(1).
+	mock.ExpectExec(`UPDATE "transactions" SET .* WHERE transaction_id = \$7`).
+		WithArgs(
+			"current",
+			sqlmock.AnyArg(),
+			0,
+			sqlmock.AnyArg(),
+			sqlmock.AnyArg(),
+			"cancelled",
+			1,
+		).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 
 	mock.ExpectCommit()
 
 	tx := gormDB.Begin()
-	transaction := &domain.Transaction{TransactionID: 1}
+	editedBy := uint(1)
+	editedAt := time.Now()
+	transaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionStatus:         "cancelled",
+		TransactionCollectibility: "current",
+		TransactionDate:           time.Now(),
+		TransactionEditedBy:       &editedBy,
+		TransactionEditedAt:       &editedAt,
+	}
 
-	err = transactionRepo.DeleteTransactionWithTx(tx, transaction)
+	err = transactionRepo.UpdateTransactionStatusWithTx(tx, transaction)
 
 	tx.Commit()
 
-	assert.Nil(t, err, "Error should be nil on successful delete")
+	assert.Nil(t, err, "Error should be nil on successful status update")
 
 	err = mock.ExpectationsWereMet()
 	assert.Nil(t, err, "All expected database operations should be met")
 }
 
-func TestDeleteTransactionWithTx_DBError(t *testing.T) {
+func TestUpdateTransactionStatusWithTx_DBError(t *testing.T) {
 	sqlDB, mock, _ := sqlmock.New()
 	defer sqlDB.Close()
 
@@ -539,26 +696,106 @@ func TestDeleteTransactionWithTx_DBError(t *testing.T) {
 		t.Fatalf("Failed to open GORM DB: %v", err)
 	}
 
-	transactionRepo := repository.NewTransactionRepository(gormDB)
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
 
 	mock.ExpectBegin()
 
-	mock.ExpectExec(`DELETE FROM "transactions" WHERE "transactions"."transaction_id" = \$1`).
-		WithArgs(1).
+	mock.ExpectExec(`UPDATE "transactions" SET`).
 		WillReturnError(gorm.ErrInvalidTransaction)
 
 	mock.ExpectRollback()
 
 	tx := gormDB.Begin()
-	transaction := &domain.Transaction{TransactionID: 1}
+	transaction := &domain.Transaction{TransactionID: 1, TransactionStatus: "cancelled"}
 
-	err = transactionRepo.DeleteTransactionWithTx(tx, transaction)
+	err = transactionRepo.UpdateTransactionStatusWithTx(tx, transaction)
 
 	tx.Rollback()
 
 	assert.Error(t, err, "Error should not be nil on DB error")
 	assert.Equal(t, gorm.ErrInvalidTransaction, err, "Expected gorm.ErrInvalidTransaction error")
+}
 
-	err = mock.ExpectationsWereMet()
-	assert.Nil(t, err, "All expected database operations should be met")
+func TestGetTransactionStatusHistory_Success(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
+
+	rows := sqlmock.NewRows([]string{"history_id", "history_transaction_id", "history_from_status", "history_to_status", "history_changed_by"}).
+		AddRow(1, 1, "", "active", 1).
+		AddRow(2, 1, "active", "cancelled", 1)
+
+	mock.ExpectQuery(`SELECT \* FROM "transaction_status_histories" WHERE history_transaction_id = \$1 ORDER BY history_changed_at, history_id`).
+		WithArgs(1).
+		WillReturnRows(rows)
+	mock.ExpectQuery(`SELECT \* FROM "users" WHERE "users"."user_id" = \$1`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "user_username"}).AddRow(1, "admin"))
+
+	histories, err := transactionRepo.GetTransactionStatusHistory(1)
+
+	assert.Nil(t, err)
+	assert.Len(t, histories, 2)
+	assert.Equal(t, "cancelled", histories[1].HistoryToStatus)
+	assert.Equal(t, "admin", histories[1].ChangedByUser.UserUsername)
+}
+
+func TestGetTransactionVersions_Success(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
+
+	rows := sqlmock.NewRows([]string{"transaction_id", "transaction_contract_number", "transaction_version", "transaction_original_number", "transaction_status"}).
+		AddRow(1, "CTR-1", 1, "CTR-1", "restructured").
+		AddRow(2, "CTR-1-V2", 2, "CTR-1", "active")
+
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE transaction_original_number = \$1 OR transaction_contract_number = \$2 ORDER BY transaction_version, transaction_id`).
+		WithArgs("CTR-1", "CTR-1").
+		WillReturnRows(rows)
+
+	versions, err := transactionRepo.GetTransactionVersions("CTR-1")
+
+	assert.Nil(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "CTR-1-V2", versions[1].TransactionContractNumber)
+	assert.Equal(t, 2, versions[1].TransactionVersion)
+}
+
+func TestGetTransactionByIDWithTx_LocksRow(t *testing.T) {
+	sqlDB, mock, _ := sqlmock.New()
+	defer sqlDB.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("Failed to open GORM DB: %v", err)
+	}
+
+	transactionRepo := repository.NewTransactionRepository(gormDB, config.TransactionRetryConfig{})
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM transactions WHERE transaction_id = \$1 FOR UPDATE`).
+		WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "transaction_contract_number", "transaction_status"}).
+			AddRow(1, "CTR-1", "cancelled"))
+	mock.ExpectCommit()
+
+	tx := gormDB.Begin()
+	transaction, err := transactionRepo.GetTransactionByIDWithTx(tx, 1)
+	tx.Commit()
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.TransactionStatusCancelled, transaction.TransactionStatus)
+	assert.NoError(t, mock.ExpectationsWereMet())
 }
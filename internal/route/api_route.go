@@ -23,8 +23,13 @@ func SetupRouter() *gin.Engine {
 
 	SetupUserRoutes(protected)
 	SetupCustomerRoutes(protected)
+	SetupProductRoutes(protected)
+	SetupPricingRoutes(protected)
+	SetupNegativeListRoutes(protected)
 	SetupLimitRoutes(protected)
 	SetupTransactionRoutes(protected)
+	SetupPaymentRoutes(protected)
+	SetupSettlementRoutes(protected)
 
 	return r
 }
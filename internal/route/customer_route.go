@@ -11,13 +11,47 @@ import (
 
 func SetupCustomerRoutes(protected *gin.RouterGroup) {
 	customerRepo := repository.NewCustomerRepository(config.DB)
-	customerUsecase := usecase.NewCustomerUsecase(customerRepo)
+	limitRepo := repository.NewLimitRepository(config.DB)
+	transactionRepo := repository.NewTransactionRepository(config.DB, config.LoadTransactionRetryConfig())
+	productRepo := repository.NewProductRepository(config.DB)
+	negativeListRepo := repository.NewNegativeListRepository(config.DB)
+	profileRepo := repository.NewCustomerProfileRepository(config.DB)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeListRepo, config.LoadDuplicateDetectionConfig())
 	customerHandler := handler.NewCustomerHandler(customerUsecase)
+	scoringUsecase := usecase.NewCreditScoringUsecase(customerRepo, limitRepo, transactionRepo, productRepo, config.LoadCreditScoringConfig())
+	scoringHandler := handler.NewCreditScoringHandler(scoringUsecase)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, customerRepo)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+	profileUsecase := usecase.NewCustomerProfileUsecase(customerRepo, profileRepo)
+	profileHandler := handler.NewCustomerProfileHandler(profileUsecase)
 
 	customers := protected.Group("/customers")
 	customers.GET("/", customerHandler.GetCustomer)
+	customers.GET("/kyc-queue", customerHandler.GetKYCReviewQueue)
 	customers.GET("/:id", customerHandler.GetCustomerByID)
+	customers.GET("/:id/limit-recommendation", scoringHandler.GetLimitRecommendation)
+	customers.GET("/:id/duplicates", customerHandler.GetSuspectedDuplicates)
+	customers.GET("/:id/screening", screeningHandler.GetCustomerScreening)
 	customers.POST("/", customerHandler.CreateCustomer)
 	customers.PUT("/:id", customerHandler.UpdateCustomer)
+	customers.PUT("/:id/kyc", customerHandler.ReviewCustomerKYC)
 	customers.DELETE("/:id", customerHandler.DeleteCustomer)
+
+	customers.GET("/:id/profile", profileHandler.GetCustomerProfile)
+	customers.POST("/:id/addresses", profileHandler.AddCustomerAddress)
+	customers.PUT("/:id/addresses/:addressId", profileHandler.UpdateCustomerAddress)
+	customers.DELETE("/:id/addresses/:addressId", profileHandler.DeleteCustomerAddress)
+	customers.POST("/:id/phones", profileHandler.AddCustomerPhone)
+	customers.PUT("/:id/phones/:phoneId", profileHandler.UpdateCustomerPhone)
+	customers.PUT("/:id/phones/:phoneId/verify", profileHandler.VerifyCustomerPhone)
+	customers.DELETE("/:id/phones/:phoneId", profileHandler.DeleteCustomerPhone)
+	customers.POST("/:id/emails", profileHandler.AddCustomerEmail)
+	customers.PUT("/:id/emails/:emailId", profileHandler.UpdateCustomerEmail)
+	customers.PUT("/:id/emails/:emailId/verify", profileHandler.VerifyCustomerEmail)
+	customers.DELETE("/:id/emails/:emailId", profileHandler.DeleteCustomerEmail)
+	customers.PUT("/:id/employment", profileHandler.SaveCustomerEmployment)
+	customers.DELETE("/:id/employment", profileHandler.DeleteCustomerEmployment)
+	customers.POST("/:id/emergency-contacts", profileHandler.AddCustomerEmergencyContact)
+	customers.PUT("/:id/emergency-contacts/:contactId", profileHandler.UpdateCustomerEmergencyContact)
+	customers.DELETE("/:id/emergency-contacts/:contactId", profileHandler.DeleteCustomerEmergencyContact)
 }
@@ -12,13 +12,25 @@ import (
 func SetupLimitRoutes(protected *gin.RouterGroup) {
 	limitRepo := repository.NewLimitRepository(config.DB)
 	customerRepo := repository.NewCustomerRepository(config.DB)
-	limitUsecase := usecase.NewLimitUsecase(limitRepo, customerRepo)
-	limitHandler := handler.NewLimitHandler(limitUsecase)
+	transactionRepo := repository.NewTransactionRepository(config.DB, config.LoadTransactionRetryConfig())
+	installmentRepo := repository.NewInstallmentRepository(config.DB)
+	productRepo := repository.NewProductRepository(config.DB)
+	negativeListRepo := repository.NewNegativeListRepository(config.DB)
+	limitUsecase := usecase.NewLimitUsecase(limitRepo, customerRepo, transactionRepo, productRepo, negativeListRepo)
+	scoringUsecase := usecase.NewCreditScoringUsecase(customerRepo, limitRepo, transactionRepo, productRepo, config.LoadCreditScoringConfig())
+	limitHandler := handler.NewLimitHandler(limitUsecase, scoringUsecase)
+	reconciliationUsecase := usecase.NewLimitReconciliationUsecase(limitRepo, transactionRepo, installmentRepo)
+	reconciliationHandler := handler.NewLimitReconciliationHandler(reconciliationUsecase)
 
 	limits := protected.Group("/limits")
 	limits.GET("/", limitHandler.GetLimit)
 	limits.GET("/:id", limitHandler.GetLimitByID)
+	limits.GET("/:id/movements", limitHandler.GetLimitMovements)
+	limits.GET("/by-nik/:nik", limitHandler.GetCustomerLimit)
 	limits.POST("/", limitHandler.CreateLimit)
+	limits.POST("/reconcile", reconciliationHandler.ReconcileLimits)
 	limits.PUT("/:id", limitHandler.UpdateLimit)
+	limits.PUT("/by-nik/:nik", limitHandler.SetCustomerLimit)
+	limits.PUT("/by-nik/:nik/tenors/:tenor", limitHandler.UpsertLimit)
 	limits.DELETE("/:id", limitHandler.DeleteLimit)
 }
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupNegativeListRoutes(protected *gin.RouterGroup) {
+	negativeListRepo := repository.NewNegativeListRepository(config.DB)
+	customerRepo := repository.NewCustomerRepository(config.DB)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, customerRepo)
+	screeningHandler := handler.NewScreeningHandler(screeningUsecase)
+
+	negativeList := protected.Group("/negative-list")
+	negativeList.GET("/", screeningHandler.GetNegativeListEntry)
+	negativeList.GET("/:id", screeningHandler.GetNegativeListEntryByID)
+	negativeList.POST("/", screeningHandler.CreateNegativeListEntry)
+	negativeList.PUT("/:id", screeningHandler.UpdateNegativeListEntry)
+}
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPaymentRoutes(protected *gin.RouterGroup) {
+	paymentRepo := repository.NewPaymentRepository(config.DB)
+	installmentRepo := repository.NewInstallmentRepository(config.DB)
+	limitRepo := repository.NewLimitRepository(config.DB)
+	transactionRepo := repository.NewTransactionRepository(config.DB, config.LoadTransactionRetryConfig())
+	paymentUsecase := usecase.NewPaymentUsecase(paymentRepo, installmentRepo, limitRepo, transactionRepo)
+	paymentHandler := handler.NewPaymentHandler(paymentUsecase)
+
+	payments := protected.Group("/transactions/:id/payments")
+	payments.GET("/", paymentHandler.GetPayments)
+	payments.POST("/", paymentHandler.CreatePayment)
+}
@@ -0,0 +1,23 @@
+package route
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupPricingRoutes(protected *gin.RouterGroup) {
+	pricingRuleRepo := repository.NewPricingRuleRepository(config.DB)
+	productRepo := repository.NewProductRepository(config.DB)
+	pricingUsecase := usecase.NewPricingUsecase(pricingRuleRepo, productRepo)
+	pricingHandler := handler.NewPricingHandler(pricingUsecase)
+
+	pricingRules := protected.Group("/pricing-rules")
+	pricingRules.GET("/", pricingHandler.GetPricingRule)
+	pricingRules.GET("/:id", pricingHandler.GetPricingRuleByID)
+	pricingRules.POST("/", pricingHandler.CreatePricingRule)
+	pricingRules.PUT("/:id", pricingHandler.UpdatePricingRule)
+}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupProductRoutes(protected *gin.RouterGroup) {
+	productRepo := repository.NewProductRepository(config.DB)
+	productUsecase := usecase.NewProductUsecase(productRepo)
+	productHandler := handler.NewProductHandler(productUsecase)
+
+	products := protected.Group("/products")
+	products.GET("/", productHandler.GetProduct)
+	products.GET("/:id", productHandler.GetProductByID)
+	products.POST("/", productHandler.CreateProduct)
+	products.PUT("/:id", productHandler.UpdateProduct)
+}
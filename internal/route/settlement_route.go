@@ -0,0 +1,23 @@
+package route
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/handler"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupSettlementRoutes(protected *gin.RouterGroup) {
+	paymentRepo := repository.NewPaymentRepository(config.DB)
+	installmentRepo := repository.NewInstallmentRepository(config.DB)
+	limitRepo := repository.NewLimitRepository(config.DB)
+	transactionRepo := repository.NewTransactionRepository(config.DB, config.LoadTransactionRetryConfig())
+	settlementUsecase := usecase.NewSettlementUsecase(paymentRepo, installmentRepo, limitRepo, transactionRepo, config.LoadSettlementConfig())
+	settlementHandler := handler.NewSettlementHandler(settlementUsecase)
+
+	settlement := protected.Group("/transactions/:id")
+	settlement.GET("/payoff", settlementHandler.GetPayoffQuote)
+	settlement.POST("/settle", settlementHandler.SettleTransaction)
+}
@@ -10,16 +10,31 @@ import (
 )
 
 func SetupTransactionRoutes(protected *gin.RouterGroup) {
-	transactionRepo := repository.NewTransactionRepository(config.DB)
+	transactionRepo := repository.NewTransactionRepository(config.DB, config.LoadTransactionRetryConfig())
 	limitRepo := repository.NewLimitRepository(config.DB)
 	customerRepo := repository.NewCustomerRepository(config.DB)
-	transactionUsecase := usecase.NewTransactionUsecase(customerRepo, limitRepo, transactionRepo)
+	installmentRepo := repository.NewInstallmentRepository(config.DB)
+	productRepo := repository.NewProductRepository(config.DB)
+	pricingRuleRepo := repository.NewPricingRuleRepository(config.DB)
+	negativeListRepo := repository.NewNegativeListRepository(config.DB)
+	transactionUsecase := usecase.NewTransactionUsecase(customerRepo, limitRepo, transactionRepo, installmentRepo, productRepo, pricingRuleRepo, negativeListRepo, config.LoadAffordabilityConfig())
 	transactionHandler := handler.NewTransactionHandler(transactionUsecase)
 
 	transactions := protected.Group("/transactions")
 	transactions.GET("/", transactionHandler.GetTransaction)
+	transactions.GET("/delinquent", transactionHandler.GetDelinquentTransactions)
+	transactions.GET("/retry-metrics", transactionHandler.GetTransactionRetryMetrics)
 	transactions.GET("/:id", transactionHandler.GetTransactionByID)
+	transactions.GET("/:id/schedule", transactionHandler.GetTransactionSchedule)
+	transactions.GET("/:id/history", transactionHandler.GetTransactionStatusHistory)
+	transactions.GET("/:id/versions", transactionHandler.GetTransactionVersions)
 	transactions.POST("/", transactionHandler.CreateTransaction)
+	transactions.POST("/simulate", transactionHandler.SimulateTransaction)
+	transactions.POST("/:id/activate", transactionHandler.ActivateTransaction)
+	transactions.POST("/:id/cancel", transactionHandler.CancelTransaction)
+	transactions.POST("/:id/pay-off", transactionHandler.PayOffTransaction)
+	transactions.POST("/:id/write-off", transactionHandler.WriteOffTransaction)
+	transactions.POST("/:id/restructure", transactionHandler.RestructureTransaction)
 	transactions.PUT("/:id", transactionHandler.UpdateTransaction)
-	transactions.DELETE("/:id", transactionHandler.DeleteTransaction)
+	transactions.DELETE("/:id", transactionHandler.CancelTransaction)
 }
@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var ErrNoLimitRecommended = errors.New("no limit can be recommended for this customer and tenor")
+
+var riskGradeCapacity = map[string]float64{
+	domain.RiskGradeA: 1.0,
+	domain.RiskGradeB: 0.8,
+	domain.RiskGradeC: 0.6,
+	domain.RiskGradeD: 0.4,
+	domain.RiskGradeE: 0,
+}
+
+type CreditScoringUsecase interface {
+	GetCustomerByID(id uint) (*domain.Customer, error)
+	RecommendLimit(customer *domain.Customer) (*domain.LimitRecommendation, error)
+	AssignRiskGrade(customer *domain.Customer, grade string) error
+}
+
+type creditScoringUsecase struct {
+	customerRepo    repository.CustomerRepository
+	limitRepo       repository.LimitRepository
+	transactionRepo repository.TransactionRepository
+	productRepo     repository.ProductRepository
+	scoringConfig   config.CreditScoringConfig
+}
+
+func NewCreditScoringUsecase(customerRepo repository.CustomerRepository, limitRepo repository.LimitRepository, transactionRepo repository.TransactionRepository, productRepo repository.ProductRepository, scoringConfig config.CreditScoringConfig) CreditScoringUsecase {
+	return &creditScoringUsecase{customerRepo: customerRepo, limitRepo: limitRepo, transactionRepo: transactionRepo, productRepo: productRepo, scoringConfig: scoringConfig}
+}
+
+func (u *creditScoringUsecase) GetCustomerByID(id uint) (*domain.Customer, error) {
+	return u.customerRepo.GetCustomerByID(id)
+}
+
+func (u *creditScoringUsecase) RecommendLimit(customer *domain.Customer) (*domain.LimitRecommendation, error) {
+	limits, err := u.limitRepo.GetLimitsByNIK(customer.CustomerNIK)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to retrieve limits for credit scoring")
+		return nil, err
+	}
+
+	history, err := u.transactionRepo.GetRepaymentHistoryByNIK(customer.CustomerNIK)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to retrieve repayment history for credit scoring")
+		return nil, err
+	}
+
+	tenors, err := u.productRepo.GetOfferedTenors()
+	if err != nil {
+		utils.Logger.WithError(err).Error("Failed to retrieve offered tenors for credit scoring")
+		return nil, err
+	}
+
+	now := time.Now()
+	recommendation := &domain.LimitRecommendation{
+		CustomerID:       customer.CustomerID,
+		CustomerNIK:      customer.CustomerNIK,
+		CustomerAge:      ageAt(customer.CustomerBirthDate, now),
+		CustomerSalary:   customer.CustomerSalary,
+		RepaymentHistory: *history,
+		CurrentRiskGrade: customer.CustomerRiskGrade,
+		RecommendationAt: now,
+	}
+	for _, limit := range limits {
+		recommendation.ExistingExposure = recommendation.ExistingExposure.Add(limit.LimitUsedAmount)
+	}
+
+	u.scoreCustomer(recommendation)
+	u.recommendTenorLimits(recommendation, tenors)
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_nik": customer.CustomerNIK,
+		"score":        recommendation.Score,
+		"risk_grade":   recommendation.RiskGrade,
+	}).Info("Limit recommendation calculated")
+
+	return recommendation, nil
+}
+
+func (u *creditScoringUsecase) AssignRiskGrade(customer *domain.Customer, grade string) error {
+	if customer.CustomerRiskGrade != "" {
+		return nil
+	}
+
+	assigned, err := u.customerRepo.AssignCustomerRiskGradeIfUngraded(customer.CustomerID, grade)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to assign scored risk grade")
+		return err
+	}
+
+	if assigned {
+		customer.CustomerRiskGrade = grade
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"risk_grade":   grade,
+		}).Info("Scored risk grade assigned to customer")
+	}
+	return nil
+}
+
+func (u *creditScoringUsecase) scoreCustomer(recommendation *domain.LimitRecommendation) {
+	salary := recommendation.CustomerSalary
+	history := recommendation.RepaymentHistory
+
+	salaryPoints := 10
+	switch {
+	case !salary.LessThan(money.New(15000000)):
+		salaryPoints = 30
+	case !salary.LessThan(money.New(8000000)):
+		salaryPoints = 25
+	case !salary.LessThan(money.New(4000000)):
+		salaryPoints = 18
+	}
+
+	agePoints := 5
+	switch age := recommendation.CustomerAge; {
+	case age >= 25 && age <= 50:
+		agePoints = 20
+	case age >= u.scoringConfig.MinAge && age <= 55:
+		agePoints = 12
+	}
+
+	exposurePoints := 20
+	if recommendation.ExistingExposure.IsPositive() {
+		exposurePoints = 0
+		if salary.IsPositive() {
+			switch ratio := recommendation.ExistingExposure.Float64() / salary.Float64(); {
+			case ratio <= 2:
+				exposurePoints = 15
+			case ratio <= 5:
+				exposurePoints = 8
+			}
+		}
+	}
+
+	historyPoints := 25
+	switch {
+	case history.WrittenOffContracts > 0 || history.MaxDaysPastDue > 90:
+		historyPoints = 0
+	case history.DelinquentContracts > 0 || history.MaxDaysPastDue > 30:
+		historyPoints = 10
+	case history.LateInstallments > 0:
+		historyPoints = 20
+	case history.TotalContracts == 0:
+		historyPoints = 20
+	case history.PaidOffContracts > 0:
+		historyPoints = 30
+	}
+
+	recommendation.Factors = []domain.CreditScoreFactor{
+		{Factor: "salary", Points: salaryPoints, MaxPoints: 30},
+		{Factor: "age", Points: agePoints, MaxPoints: 20},
+		{Factor: "existing_exposure", Points: exposurePoints, MaxPoints: 20},
+		{Factor: "repayment_history", Points: historyPoints, MaxPoints: 30},
+	}
+	recommendation.Score = salaryPoints + agePoints + exposurePoints + historyPoints
+
+	switch {
+	case recommendation.CustomerAge < u.scoringConfig.MinAge || history.WrittenOffContracts > 0:
+		recommendation.RiskGrade = domain.RiskGradeE
+	case recommendation.Score >= 80:
+		recommendation.RiskGrade = domain.RiskGradeA
+	case recommendation.Score >= 65:
+		recommendation.RiskGrade = domain.RiskGradeB
+	case recommendation.Score >= 50:
+		recommendation.RiskGrade = domain.RiskGradeC
+	case recommendation.Score >= 35:
+		recommendation.RiskGrade = domain.RiskGradeD
+	default:
+		recommendation.RiskGrade = domain.RiskGradeE
+	}
+}
+
+func (u *creditScoringUsecase) recommendTenorLimits(recommendation *domain.LimitRecommendation, tenors []int) {
+	capacity := recommendation.CustomerSalary.
+		Percent(u.scoringConfig.MaxInstallmentRatio).
+		Mul(riskGradeCapacity[recommendation.RiskGrade])
+	rounding := money.New(u.scoringConfig.LimitRounding)
+
+	recommendation.Recommendations = make([]domain.TenorLimitRecommendation, 0, len(tenors))
+	for _, tenor := range tenors {
+		tenorRecommendation := domain.TenorLimitRecommendation{Tenor: tenor}
+
+		switch {
+		case recommendation.RiskGrade == domain.RiskGradeE:
+			tenorRecommendation.Reason = "risk grade E is not eligible for a limit"
+		case recommendation.CustomerAge+(tenor+11)/12 > u.scoringConfig.MaxAgeAtMaturity:
+			tenorRecommendation.Reason = fmt.Sprintf("customer would exceed the maximum age of %d at maturity", u.scoringConfig.MaxAgeAtMaturity)
+		default:
+			amount := capacity.Mul(float64(tenor)).Sub(recommendation.ExistingExposure)
+			if amount.IsPositive() && rounding.IsPositive() {
+				amount = money.FromSen(amount.Sen() / rounding.Sen() * rounding.Sen())
+			}
+			if !amount.IsPositive() {
+				tenorRecommendation.Reason = "existing exposure exceeds the repayment capacity for this tenor"
+				amount = money.Money{}
+			}
+			tenorRecommendation.RecommendedAmount = amount
+		}
+
+		recommendation.Recommendations = append(recommendation.Recommendations, tenorRecommendation)
+	}
+}
+
+func ageAt(birthDate, at time.Time) int {
+	age := at.Year() - birthDate.Year()
+	if at.Month() < birthDate.Month() || (at.Month() == birthDate.Month() && at.Day() < birthDate.Day()) {
+		age--
+	}
+	return age
+}
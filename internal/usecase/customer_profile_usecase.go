@@ -0,0 +1,438 @@
+package usecase
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrCustomerAddressTypeExists  = errors.New("customer already has an address of this type")
+	ErrCustomerPhoneExists        = errors.New("phone number is already registered for this customer")
+	ErrCustomerEmailExists        = errors.New("email address is already registered for this customer")
+	ErrEmployerRequired           = errors.New("employer name is required for employed and self-employed customers")
+	ErrInvalidEmploymentStartDate = errors.New("employment start date cannot be in the future")
+	ErrEmergencyContactIsCustomer = errors.New("emergency contact phone must not be one of the customer's own numbers")
+)
+
+type CustomerProfileUsecase interface {
+	GetCustomerByID(id uint) (*domain.Customer, error)
+	AddCustomerAddress(userID uint, customer *domain.Customer, input domain.CustomerAddressInput) (*domain.CustomerAddress, error)
+	GetCustomerAddressByID(customerID, id uint) (*domain.CustomerAddress, error)
+	UpdateCustomerAddress(userID uint, address *domain.CustomerAddress, input domain.CustomerAddressInput) error
+	DeleteCustomerAddress(address *domain.CustomerAddress) error
+	AddCustomerPhone(userID uint, customer *domain.Customer, input domain.CustomerPhoneInput) (*domain.CustomerPhoneNumber, error)
+	GetCustomerPhoneByID(customerID, id uint) (*domain.CustomerPhoneNumber, error)
+	UpdateCustomerPhone(userID uint, phone *domain.CustomerPhoneNumber, input domain.CustomerPhoneInput) error
+	VerifyCustomerPhone(userID uint, phone *domain.CustomerPhoneNumber) error
+	DeleteCustomerPhone(phone *domain.CustomerPhoneNumber) error
+	AddCustomerEmail(userID uint, customer *domain.Customer, input domain.CustomerEmailInput) (*domain.CustomerEmailAddress, error)
+	GetCustomerEmailByID(customerID, id uint) (*domain.CustomerEmailAddress, error)
+	UpdateCustomerEmail(userID uint, email *domain.CustomerEmailAddress, input domain.CustomerEmailInput) error
+	VerifyCustomerEmail(userID uint, email *domain.CustomerEmailAddress) error
+	DeleteCustomerEmail(email *domain.CustomerEmailAddress) error
+	GetCustomerEmployment(customerID uint) (*domain.CustomerEmployment, error)
+	SaveCustomerEmployment(userID uint, customer *domain.Customer, input domain.CustomerEmploymentInput) (*domain.CustomerEmployment, error)
+	DeleteCustomerEmployment(employment *domain.CustomerEmployment) error
+	AddCustomerEmergencyContact(userID uint, customer *domain.Customer, input domain.CustomerEmergencyContactInput) (*domain.CustomerEmergencyContact, error)
+	GetCustomerEmergencyContactByID(customerID, id uint) (*domain.CustomerEmergencyContact, error)
+	UpdateCustomerEmergencyContact(userID uint, customer *domain.Customer, contact *domain.CustomerEmergencyContact, input domain.CustomerEmergencyContactInput) error
+	DeleteCustomerEmergencyContact(contact *domain.CustomerEmergencyContact) error
+}
+
+type customerProfileUsecase struct {
+	customerRepo repository.CustomerRepository
+	profileRepo  repository.CustomerProfileRepository
+}
+
+func NewCustomerProfileUsecase(customerRepo repository.CustomerRepository, profileRepo repository.CustomerProfileRepository) CustomerProfileUsecase {
+	return &customerProfileUsecase{customerRepo: customerRepo, profileRepo: profileRepo}
+}
+
+func (u *customerProfileUsecase) GetCustomerByID(id uint) (*domain.Customer, error) {
+	return u.customerRepo.GetCustomerByID(id)
+}
+
+func (u *customerProfileUsecase) AddCustomerAddress(userID uint, customer *domain.Customer, input domain.CustomerAddressInput) (*domain.CustomerAddress, error) {
+	address := &domain.CustomerAddress{
+		AddressCustomerID: customer.CustomerID,
+		AddressCreatedBy:  userID,
+		AddressCreatedAt:  time.Now(),
+	}
+	applyCustomerAddressInput(address, input)
+
+	if err := u.saveCustomerAddress(address); err != nil {
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":      userID,
+		"customer_id":  customer.CustomerID,
+		"address_id":   address.AddressID,
+		"address_type": address.AddressType,
+	}).Info("Customer address successfully added")
+
+	return address, nil
+}
+
+func (u *customerProfileUsecase) GetCustomerAddressByID(customerID, id uint) (*domain.CustomerAddress, error) {
+	return u.profileRepo.GetCustomerAddressByID(customerID, id)
+}
+
+func (u *customerProfileUsecase) UpdateCustomerAddress(userID uint, address *domain.CustomerAddress, input domain.CustomerAddressInput) error {
+	applyCustomerAddressInput(address, input)
+
+	timeNow := time.Now()
+	address.AddressEditedBy = &userID
+	address.AddressEditedAt = &timeNow
+
+	return u.saveCustomerAddress(address)
+}
+
+func (u *customerProfileUsecase) DeleteCustomerAddress(address *domain.CustomerAddress) error {
+	return u.profileRepo.DeleteCustomerAddress(address)
+}
+
+func (u *customerProfileUsecase) saveCustomerAddress(address *domain.CustomerAddress) error {
+	if err := u.profileRepo.SaveCustomerAddress(address); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id":  address.AddressCustomerID,
+			"address_type": address.AddressType,
+			"error":        err.Error(),
+		}).Error("Failed to save customer address")
+		if repository.IsUniqueViolation(err) {
+			return ErrCustomerAddressTypeExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *customerProfileUsecase) AddCustomerPhone(userID uint, customer *domain.Customer, input domain.CustomerPhoneInput) (*domain.CustomerPhoneNumber, error) {
+	phone := &domain.CustomerPhoneNumber{
+		PhoneCustomerID: customer.CustomerID,
+		PhoneNumber:     normalizePhone(input.PhoneNumber),
+		PhoneLabel:      input.PhoneLabel,
+		PhonePrimary:    input.PhonePrimary,
+		PhoneCreatedBy:  userID,
+		PhoneCreatedAt:  time.Now(),
+	}
+
+	if err := u.saveCustomerPhone(phone); err != nil {
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"customer_id": customer.CustomerID,
+		"phone_id":    phone.PhoneID,
+	}).Info("Customer phone successfully added")
+
+	return phone, nil
+}
+
+func (u *customerProfileUsecase) GetCustomerPhoneByID(customerID, id uint) (*domain.CustomerPhoneNumber, error) {
+	return u.profileRepo.GetCustomerPhoneByID(customerID, id)
+}
+
+func (u *customerProfileUsecase) UpdateCustomerPhone(userID uint, phone *domain.CustomerPhoneNumber, input domain.CustomerPhoneInput) error {
+	number := normalizePhone(input.PhoneNumber)
+	if number != phone.PhoneNumber {
+		phone.ResetVerification()
+	}
+	phone.PhoneNumber = number
+	phone.PhoneLabel = input.PhoneLabel
+	phone.PhonePrimary = input.PhonePrimary
+
+	timeNow := time.Now()
+	phone.PhoneEditedBy = &userID
+	phone.PhoneEditedAt = &timeNow
+
+	return u.saveCustomerPhone(phone)
+}
+
+func (u *customerProfileUsecase) VerifyCustomerPhone(userID uint, phone *domain.CustomerPhoneNumber) error {
+	timeNow := time.Now()
+	phone.PhoneVerified = true
+	phone.PhoneVerifiedBy = &userID
+	phone.PhoneVerifiedAt = &timeNow
+
+	if err := u.saveCustomerPhone(phone); err != nil {
+		return err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"phone_id": phone.PhoneID,
+	}).Info("Customer phone successfully verified")
+
+	return nil
+}
+
+func (u *customerProfileUsecase) DeleteCustomerPhone(phone *domain.CustomerPhoneNumber) error {
+	return u.profileRepo.DeleteCustomerPhone(phone)
+}
+
+func (u *customerProfileUsecase) saveCustomerPhone(phone *domain.CustomerPhoneNumber) error {
+	if err := u.profileRepo.SaveCustomerPhone(phone); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": phone.PhoneCustomerID,
+			"error":       err.Error(),
+		}).Error("Failed to save customer phone")
+		if repository.IsUniqueViolation(err) {
+			return ErrCustomerPhoneExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *customerProfileUsecase) AddCustomerEmail(userID uint, customer *domain.Customer, input domain.CustomerEmailInput) (*domain.CustomerEmailAddress, error) {
+	email := &domain.CustomerEmailAddress{
+		EmailCustomerID: customer.CustomerID,
+		EmailAddress:    strings.ToLower(strings.TrimSpace(input.EmailAddress)),
+		EmailPrimary:    input.EmailPrimary,
+		EmailCreatedBy:  userID,
+		EmailCreatedAt:  time.Now(),
+	}
+
+	if err := u.saveCustomerEmail(email); err != nil {
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"customer_id": customer.CustomerID,
+		"email_id":    email.EmailID,
+	}).Info("Customer email successfully added")
+
+	return email, nil
+}
+
+func (u *customerProfileUsecase) GetCustomerEmailByID(customerID, id uint) (*domain.CustomerEmailAddress, error) {
+	return u.profileRepo.GetCustomerEmailByID(customerID, id)
+}
+
+func (u *customerProfileUsecase) UpdateCustomerEmail(userID uint, email *domain.CustomerEmailAddress, input domain.CustomerEmailInput) error {
+	address := strings.ToLower(strings.TrimSpace(input.EmailAddress))
+	if address != email.EmailAddress {
+		email.ResetVerification()
+	}
+	email.EmailAddress = address
+	email.EmailPrimary = input.EmailPrimary
+
+	timeNow := time.Now()
+	email.EmailEditedBy = &userID
+	email.EmailEditedAt = &timeNow
+
+	return u.saveCustomerEmail(email)
+}
+
+func (u *customerProfileUsecase) VerifyCustomerEmail(userID uint, email *domain.CustomerEmailAddress) error {
+	timeNow := time.Now()
+	email.EmailVerified = true
+	email.EmailVerifiedBy = &userID
+	email.EmailVerifiedAt = &timeNow
+
+	if err := u.saveCustomerEmail(email); err != nil {
+		return err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":  userID,
+		"email_id": email.EmailID,
+	}).Info("Customer email successfully verified")
+
+	return nil
+}
+
+func (u *customerProfileUsecase) DeleteCustomerEmail(email *domain.CustomerEmailAddress) error {
+	return u.profileRepo.DeleteCustomerEmail(email)
+}
+
+func (u *customerProfileUsecase) saveCustomerEmail(email *domain.CustomerEmailAddress) error {
+	if err := u.profileRepo.SaveCustomerEmail(email); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": email.EmailCustomerID,
+			"error":       err.Error(),
+		}).Error("Failed to save customer email")
+		if repository.IsUniqueViolation(err) {
+			return ErrCustomerEmailExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (u *customerProfileUsecase) GetCustomerEmployment(customerID uint) (*domain.CustomerEmployment, error) {
+	return u.profileRepo.GetCustomerEmployment(customerID)
+}
+
+func (u *customerProfileUsecase) SaveCustomerEmployment(userID uint, customer *domain.Customer, input domain.CustomerEmploymentInput) (*domain.CustomerEmployment, error) {
+	timeNow := time.Now()
+
+	employment := customer.CustomerEmployment
+	if employment == nil {
+		employment = &domain.CustomerEmployment{
+			EmploymentCustomerID: customer.CustomerID,
+			EmploymentCreatedBy:  userID,
+			EmploymentCreatedAt:  timeNow,
+		}
+	} else {
+		employment.EmploymentEditedBy = &userID
+		employment.EmploymentEditedAt = &timeNow
+	}
+
+	employment.EmploymentStatus = input.EmploymentStatus
+	employment.EmploymentEmployerName = strings.TrimSpace(utils.SanitizeString(input.EmploymentEmployerName))
+	employment.EmploymentJobTitle = strings.TrimSpace(utils.SanitizeString(input.EmploymentJobTitle))
+	employment.EmploymentIndustry = strings.TrimSpace(utils.SanitizeString(input.EmploymentIndustry))
+	employment.EmploymentEmployerPhone = normalizePhone(input.EmploymentEmployerPhone)
+	employment.EmploymentEmployerAddress = strings.TrimSpace(utils.SanitizeString(input.EmploymentEmployerAddress))
+	employment.EmploymentStartDate = nil
+
+	if employment.HasEmployer() && employment.EmploymentEmployerName == "" {
+		return nil, ErrEmployerRequired
+	}
+
+	if input.EmploymentStartDate != "" {
+		startDate, err := time.Parse("2006-01-02", input.EmploymentStartDate)
+		if err != nil || startDate.After(timeNow) {
+			return nil, ErrInvalidEmploymentStartDate
+		}
+		employment.EmploymentStartDate = &startDate
+	}
+
+	if err := u.profileRepo.SaveCustomerEmployment(employment); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": customer.CustomerID,
+			"error":       err.Error(),
+		}).Error("Failed to save customer employment")
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":           userID,
+		"customer_id":       customer.CustomerID,
+		"employment_status": employment.EmploymentStatus,
+	}).Info("Customer employment successfully saved")
+
+	return employment, nil
+}
+
+func (u *customerProfileUsecase) DeleteCustomerEmployment(employment *domain.CustomerEmployment) error {
+	return u.profileRepo.DeleteCustomerEmployment(employment)
+}
+
+func (u *customerProfileUsecase) AddCustomerEmergencyContact(userID uint, customer *domain.Customer, input domain.CustomerEmergencyContactInput) (*domain.CustomerEmergencyContact, error) {
+	contact := &domain.CustomerEmergencyContact{
+		ContactCustomerID: customer.CustomerID,
+		ContactCreatedBy:  userID,
+		ContactCreatedAt:  time.Now(),
+	}
+	if err := applyEmergencyContactInput(customer, contact, input); err != nil {
+		return nil, err
+	}
+
+	if err := u.saveCustomerEmergencyContact(contact); err != nil {
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":     userID,
+		"customer_id": customer.CustomerID,
+		"contact_id":  contact.ContactID,
+	}).Info("Customer emergency contact successfully added")
+
+	return contact, nil
+}
+
+func (u *customerProfileUsecase) GetCustomerEmergencyContactByID(customerID, id uint) (*domain.CustomerEmergencyContact, error) {
+	return u.profileRepo.GetCustomerEmergencyContactByID(customerID, id)
+}
+
+func (u *customerProfileUsecase) UpdateCustomerEmergencyContact(userID uint, customer *domain.Customer, contact *domain.CustomerEmergencyContact, input domain.CustomerEmergencyContactInput) error {
+	if err := applyEmergencyContactInput(customer, contact, input); err != nil {
+		return err
+	}
+
+	timeNow := time.Now()
+	contact.ContactEditedBy = &userID
+	contact.ContactEditedAt = &timeNow
+
+	return u.saveCustomerEmergencyContact(contact)
+}
+
+func (u *customerProfileUsecase) DeleteCustomerEmergencyContact(contact *domain.CustomerEmergencyContact) error {
+	return u.profileRepo.DeleteCustomerEmergencyContact(contact)
+}
+
+func (u *customerProfileUsecase) saveCustomerEmergencyContact(contact *domain.CustomerEmergencyContact) error {
+	if err := u.profileRepo.SaveCustomerEmergencyContact(contact); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": contact.ContactCustomerID,
+			"error":       err.Error(),
+		}).Error("Failed to save customer emergency contact")
+		return err
+	}
+	return nil
+}
+
+func applyCustomerAddressInput(address *domain.CustomerAddress, input domain.CustomerAddressInput) {
+	address.AddressType = input.AddressType
+	address.AddressLine = strings.TrimSpace(utils.SanitizeString(input.AddressLine))
+	address.AddressRT = input.AddressRT
+	address.AddressRW = input.AddressRW
+	address.AddressVillage = strings.TrimSpace(utils.SanitizeString(input.AddressVillage))
+	address.AddressDistrict = strings.TrimSpace(utils.SanitizeString(input.AddressDistrict))
+	address.AddressCity = strings.TrimSpace(utils.SanitizeString(input.AddressCity))
+	address.AddressProvince = strings.TrimSpace(utils.SanitizeString(input.AddressProvince))
+	address.AddressPostalCode = input.AddressPostalCode
+}
+
+func applyEmergencyContactInput(customer *domain.Customer, contact *domain.CustomerEmergencyContact, input domain.CustomerEmergencyContactInput) error {
+	phone := normalizePhone(input.ContactPhone)
+	if isCustomerPhone(customer, phone) {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_id": customer.CustomerID,
+		}).Warn("Emergency contact phone belongs to the customer")
+		return ErrEmergencyContactIsCustomer
+	}
+
+	contact.ContactName = strings.TrimSpace(utils.SanitizeString(input.ContactName))
+	contact.ContactRelationship = input.ContactRelationship
+	contact.ContactPhone = phone
+	contact.ContactAddress = strings.TrimSpace(utils.SanitizeString(input.ContactAddress))
+	return nil
+}
+
+func isCustomerPhone(customer *domain.Customer, phone string) bool {
+	for _, customerPhone := range customerPhoneNumbers(customer) {
+		if customerPhone == phone {
+			return true
+		}
+	}
+	return false
+}
+
+func customerPhoneNumbers(customer *domain.Customer) []string {
+	numbers := []string{normalizePhone(customer.CustomerPhone)}
+	for _, phone := range customer.CustomerPhones {
+		numbers = append(numbers, phone.PhoneNumber)
+	}
+
+	var phones []string
+	seen := map[string]bool{}
+	for _, phone := range numbers {
+		if phone != "" && !seen[phone] {
+			seen[phone] = true
+			phones = append(phones, phone)
+		}
+	}
+	return phones
+}
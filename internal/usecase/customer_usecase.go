@@ -2,29 +2,68 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"kreditplus/config"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/imagehash"
+	"kreditplus/internal/nik"
 	"kreditplus/internal/repository"
 	"kreditplus/internal/utils"
+	"math"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrInvalidKYCTransition = errors.New("KYC status cannot be changed to the requested status")
+	ErrKYCReasonRequired    = errors.New("a reason is required when rejecting or requesting resubmission")
+	ErrCustomerNotVerified  = errors.New("customer KYC has not been verified")
+	ErrNIKMismatch          = errors.New("customer data does not match the NIK")
 )
 
+type NIKValidationError struct {
+	Mismatches []nik.Mismatch
+}
+
+func (e *NIKValidationError) Error() string {
+	return ErrNIKMismatch.Error()
+}
+
+func (e *NIKValidationError) Unwrap() error {
+	return ErrNIKMismatch
+}
+
+var kycTransitions = map[string][]string{
+	domain.CustomerKYCPending:           {domain.CustomerKYCVerified, domain.CustomerKYCRejected, domain.CustomerKYCNeedsResubmission},
+	domain.CustomerKYCVerified:          {domain.CustomerKYCRejected, domain.CustomerKYCNeedsResubmission},
+	domain.CustomerKYCNeedsResubmission: {domain.CustomerKYCPending},
+}
+
 type CustomerUsecase interface {
-	CreateCustomer(input domain.Customer) error
+	CreateCustomer(input domain.Customer) ([]domain.SuspectedDuplicate, error)
 	GetAllCustomers(limit, offset int) ([]domain.Customer, error)
 	GetCustomerByID(id uint) (*domain.Customer, error)
 	UpdateCustomer(input domain.Customer) error
 	DeleteCustomer(id uint) error
+	GetKYCReviewQueue(status string, limit, offset int) ([]domain.Customer, error)
+	ReviewCustomerKYC(reviewerID uint, customer *domain.Customer, input domain.CustomerKYCReviewInput) error
+	GetSuspectedDuplicates(customer *domain.Customer) ([]domain.SuspectedDuplicate, error)
 }
 
 type customerUsecase struct {
-	repo repository.CustomerRepository
+	repo             repository.CustomerRepository
+	negativeListRepo repository.NegativeListRepository
+	duplicateConfig  config.DuplicateDetectionConfig
 }
 
-func NewCustomerUsecase(repo repository.CustomerRepository) CustomerUsecase {
-	return &customerUsecase{repo: repo}
+func NewCustomerUsecase(repo repository.CustomerRepository, negativeListRepo repository.NegativeListRepository, duplicateConfig config.DuplicateDetectionConfig) CustomerUsecase {
+	return &customerUsecase{repo: repo, negativeListRepo: negativeListRepo, duplicateConfig: duplicateConfig}
 }
 
-func (u *customerUsecase) CreateCustomer(input domain.Customer) error {
+func (u *customerUsecase) CreateCustomer(input domain.Customer) ([]domain.SuspectedDuplicate, error) {
 	input.CustomerNIK = utils.SanitizeString(input.CustomerNIK)
 	input.CustomerFullName = utils.SanitizeString(input.CustomerFullName)
 	input.CustomerLegalName = utils.SanitizeString(input.CustomerLegalName)
@@ -34,14 +73,54 @@ func (u *customerUsecase) CreateCustomer(input domain.Customer) error {
 
 	input.CustomerBirthDate = utils.SanitizeDate(input.CustomerBirthDate)
 
-	input.CustomerSalary = utils.SanitizeNumberFloat64(input.CustomerSalary)
+	input.CustomerSalary = utils.SanitizeMoney(input.CustomerSalary)
+
+	input.CustomerPhone = normalizePhone(input.CustomerPhone)
+	input.CustomerEmail = strings.ToLower(strings.TrimSpace(input.CustomerEmail))
 
 	if input.CustomerNIK == "" || len(input.CustomerNIK) != 16 {
-		return errors.New("invalid NIK")
+		return nil, errors.New("invalid NIK")
+	}
+
+	if err := validateCustomerNIK(&input); err != nil {
+		return nil, err
 	}
 
-	input.CustomerCreatedAt = time.Now()
-	return u.repo.CreateCustomer(&input)
+	screening, err := enforceScreening(u.negativeListRepo, &input, "customer_create")
+	if err != nil {
+		return nil, err
+	}
+
+	refreshPhotoHashes(&input)
+
+	duplicates, err := u.detectDuplicates(&input)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	input.CustomerCreatedAt = now
+	input.CustomerKYCStatus = domain.CustomerKYCPending
+	input.CustomerKYCSubmittedAt = &now
+
+	if screening.Decision == domain.ScreeningDecisionFlag {
+		input.CustomerKYCReason = "negative list flag: " + screeningReasons(screening)
+	} else if blocking := blockingDuplicateNIKs(duplicates); len(blocking) > 0 {
+		input.CustomerKYCReason = "suspected duplicate of customer NIK " + strings.Join(blocking, ", ")
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": input.CustomerNIK,
+			"duplicates":   blocking,
+		}).Warn("Suspected duplicate identity blocks KYC auto-approval")
+	} else if u.duplicateConfig.AutoApproveKYC {
+		input.CustomerKYCStatus = domain.CustomerKYCVerified
+		input.CustomerKYCReason = "auto-approved"
+		input.CustomerKYCReviewedAt = &now
+	}
+
+	if err := u.repo.CreateCustomer(&input); err != nil {
+		return nil, err
+	}
+	return duplicates, nil
 }
 
 func (u *customerUsecase) GetAllCustomers(limit, offset int) ([]domain.Customer, error) {
@@ -62,12 +141,23 @@ func (u *customerUsecase) UpdateCustomer(input domain.Customer) error {
 
 	input.CustomerBirthDate = utils.SanitizeDate(input.CustomerBirthDate)
 
-	input.CustomerSalary = utils.SanitizeNumberFloat64(input.CustomerSalary)
+	input.CustomerSalary = utils.SanitizeMoney(input.CustomerSalary)
+
+	input.CustomerPhone = normalizePhone(input.CustomerPhone)
+	input.CustomerEmail = strings.ToLower(strings.TrimSpace(input.CustomerEmail))
 
 	if input.CustomerNIK != "" && len(input.CustomerNIK) != 16 {
 		return errors.New("NIK must be 16 numeric characters")
 	}
 
+	if input.CustomerNIK != "" {
+		if err := validateCustomerNIK(&input); err != nil {
+			return err
+		}
+	}
+
+	refreshPhotoHashes(&input)
+
 	input.CustomerEditedAt = new(time.Time)
 	*input.CustomerEditedAt = time.Now()
 	return u.repo.UpdateCustomer(&input)
@@ -76,3 +166,278 @@ func (u *customerUsecase) UpdateCustomer(input domain.Customer) error {
 func (u *customerUsecase) DeleteCustomer(id uint) error {
 	return u.repo.DeleteCustomer(id)
 }
+
+func (u *customerUsecase) GetKYCReviewQueue(status string, limit, offset int) ([]domain.Customer, error) {
+	if status == "" {
+		status = domain.CustomerKYCPending
+	}
+	return u.repo.GetCustomersByKYCStatus(status, limit, offset)
+}
+
+func (u *customerUsecase) ReviewCustomerKYC(reviewerID uint, customer *domain.Customer, input domain.CustomerKYCReviewInput) error {
+	reason := strings.TrimSpace(utils.SanitizeString(input.CustomerKYCReason))
+	if input.CustomerKYCStatus != domain.CustomerKYCVerified && reason == "" {
+		return ErrKYCReasonRequired
+	}
+
+	if !canTransitionKYC(customer.CustomerKYCStatus, input.CustomerKYCStatus) {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"from":         customer.CustomerKYCStatus,
+			"to":           input.CustomerKYCStatus,
+		}).Warn("Invalid KYC status transition")
+		return ErrInvalidKYCTransition
+	}
+
+	now := time.Now()
+	customer.CustomerKYCStatus = input.CustomerKYCStatus
+	customer.CustomerKYCReason = reason
+	customer.CustomerKYCReviewedBy = &reviewerID
+	customer.CustomerKYCReviewedAt = &now
+
+	if err := u.repo.UpdateCustomer(customer); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to save KYC review")
+		return err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_nik": customer.CustomerNIK,
+		"kyc_status":   customer.CustomerKYCStatus,
+		"reviewed_by":  reviewerID,
+	}).Info("Customer KYC reviewed")
+	return nil
+}
+
+func (u *customerUsecase) GetSuspectedDuplicates(customer *domain.Customer) ([]domain.SuspectedDuplicate, error) {
+	return u.detectDuplicates(customer)
+}
+
+func (u *customerUsecase) detectDuplicates(customer *domain.Customer) ([]domain.SuspectedDuplicate, error) {
+	candidates, err := u.repo.FindDuplicateCandidates(customer, u.duplicateConfig.PhotoHashMaxDistance)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to retrieve duplicate candidates")
+		return nil, err
+	}
+
+	duplicates := make([]domain.SuspectedDuplicate, 0)
+	for i := range candidates {
+		candidate := &candidates[i]
+		signals := u.duplicateSignals(customer, candidate)
+		if len(signals) == 0 {
+			continue
+		}
+
+		score := 0
+		for _, signal := range signals {
+			score += signal.Score
+		}
+		score = min(score, 100)
+
+		duplicates = append(duplicates, domain.SuspectedDuplicate{
+			CustomerID:        candidate.CustomerID,
+			CustomerNIK:       candidate.CustomerNIK,
+			CustomerFullName:  candidate.CustomerFullName,
+			CustomerKYCStatus: candidate.CustomerKYCStatus,
+			Score:             score,
+			Blocking:          score >= u.duplicateConfig.BlockingScore,
+			Signals:           signals,
+		})
+	}
+
+	sort.SliceStable(duplicates, func(i, j int) bool {
+		return duplicates[i].Score > duplicates[j].Score
+	})
+	if u.duplicateConfig.MaxResults > 0 && len(duplicates) > u.duplicateConfig.MaxResults {
+		duplicates = duplicates[:u.duplicateConfig.MaxResults]
+	}
+	return duplicates, nil
+}
+
+func (u *customerUsecase) duplicateSignals(customer, candidate *domain.Customer) []domain.DuplicateSignal {
+	var signals []domain.DuplicateSignal
+
+	if sameDate(customer.CustomerBirthDate, candidate.CustomerBirthDate) {
+		similarity := nameSimilarity(customer, candidate)
+		switch {
+		case similarity == 1:
+			signals = append(signals, domain.DuplicateSignal{Signal: domain.DuplicateSignalNameBirthDate, Score: 50, Detail: "same name and birth date"})
+		case similarity >= 0.6:
+			signals = append(signals, domain.DuplicateSignal{Signal: domain.DuplicateSignalNameBirthDate, Score: 35, Detail: "similar name and same birth date"})
+		}
+	}
+
+	if signal, ok := u.photoSignal(domain.DuplicateSignalKTPPhoto, 45, customer.CustomerKTPPhash, candidate.CustomerKTPPhash); ok {
+		signals = append(signals, signal)
+	}
+	if signal, ok := u.photoSignal(domain.DuplicateSignalSelfiePhoto, 40, customer.CustomerSelfiePhash, candidate.CustomerSelfiePhash); ok {
+		signals = append(signals, signal)
+	}
+
+	if customer.CustomerPhone != "" && customer.CustomerPhone == candidate.CustomerPhone {
+		signals = append(signals, domain.DuplicateSignal{Signal: domain.DuplicateSignalPhone, Score: 30, Detail: "shared phone number"})
+	}
+	if customer.CustomerEmail != "" && customer.CustomerEmail == candidate.CustomerEmail {
+		signals = append(signals, domain.DuplicateSignal{Signal: domain.DuplicateSignalEmail, Score: 25, Detail: "shared email address"})
+	}
+
+	return signals
+}
+
+func (u *customerUsecase) photoSignal(signal string, weight int, hash, candidateHash string) (domain.DuplicateSignal, bool) {
+	if hash == "" || candidateHash == "" {
+		return domain.DuplicateSignal{}, false
+	}
+
+	distance, err := imagehash.Distance(hash, candidateHash)
+	if err != nil || distance > u.duplicateConfig.PhotoHashMaxDistance {
+		return domain.DuplicateSignal{}, false
+	}
+
+	span := 2 * (u.duplicateConfig.PhotoHashMaxDistance + 1)
+	return domain.DuplicateSignal{
+		Signal: signal,
+		Score:  weight * (span - distance) / span,
+		Detail: fmt.Sprintf("perceptual hash distance %d", distance),
+	}, true
+}
+
+func blockingDuplicateNIKs(duplicates []domain.SuspectedDuplicate) []string {
+	var niks []string
+	for _, duplicate := range duplicates {
+		if duplicate.Blocking {
+			niks = append(niks, duplicate.CustomerNIK)
+		}
+	}
+	return niks
+}
+
+func refreshPhotoHashes(customer *domain.Customer) {
+	customer.CustomerKTPPhash = photoHash(customer.CustomerKTPPhoto)
+	customer.CustomerSelfiePhash = photoHash(customer.CustomerSelfiePhoto)
+}
+
+func photoHash(path string) string {
+	if path == "" {
+		return ""
+	}
+
+	hash, err := imagehash.FromFile(path)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"path":  path,
+			"error": err.Error(),
+		}).Warn("Failed to compute perceptual hash")
+		return ""
+	}
+	return hash
+}
+
+func normalizeName(name string) []string {
+	tokens := utils.NameTokens(name)
+	sort.Strings(tokens)
+	return tokens
+}
+
+func nameSimilarity(customer, candidate *domain.Customer) float64 {
+	best := 0.0
+	for _, name := range []string{customer.CustomerFullName, customer.CustomerLegalName} {
+		for _, candidateName := range []string{candidate.CustomerFullName, candidate.CustomerLegalName} {
+			best = math.Max(best, tokenSimilarity(normalizeName(name), normalizeName(candidateName)))
+		}
+	}
+	return best
+}
+
+func tokenSimilarity(a, b []string) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	seen := make(map[string]bool, len(a))
+	for _, token := range a {
+		seen[token] = true
+	}
+	union := len(seen)
+	shared := 0
+	for _, token := range b {
+		if seen[token] {
+			shared++
+			delete(seen, token)
+		} else {
+			union++
+		}
+	}
+	return float64(shared) / float64(union)
+}
+
+func sameDate(a, b time.Time) bool {
+	return !a.IsZero() && a.Year() == b.Year() && a.Month() == b.Month() && a.Day() == b.Day()
+}
+
+func normalizePhone(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+
+	switch {
+	case strings.HasPrefix(digits, "0"):
+		return "62" + digits[1:]
+	case strings.HasPrefix(digits, "8"):
+		return "62" + digits
+	}
+	return digits
+}
+
+func validateCustomerNIK(customer *domain.Customer) error {
+	parsed, err := nik.Parse(customer.CustomerNIK)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Warn("Customer NIK is structurally invalid")
+		return &NIKValidationError{Mismatches: []nik.Mismatch{{
+			Field:   "customer_nik",
+			Actual:  customer.CustomerNIK,
+			Message: err.Error(),
+		}}}
+	}
+
+	mismatches := parsed.CrossCheck(customer.CustomerBirthDate, customer.CustomerBirthPlace)
+	if len(mismatches) > 0 {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"mismatches":   mismatches,
+		}).Warn("Customer data does not match the NIK")
+		return &NIKValidationError{Mismatches: mismatches}
+	}
+	return nil
+}
+
+func canTransitionKYC(from, to string) bool {
+	for _, allowed := range kycTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func ensureCustomerVerified(customer *domain.Customer) error {
+	if !customer.IsKYCVerified() {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"kyc_status":   customer.CustomerKYCStatus,
+		}).Warn("Customer KYC is not verified")
+		return ErrCustomerNotVerified
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var limitConsumingStatuses = []string{domain.TransactionStatusActive, domain.TransactionStatusWrittenOff}
+
+type LimitReconciliationUsecase interface {
+	ReconcileLimits(userID uint, repair bool) (*domain.LimitReconciliationResult, error)
+}
+
+type limitReconciliationUsecase struct {
+	limitRepo       repository.LimitRepository
+	transactionRepo repository.TransactionRepository
+	installmentRepo repository.InstallmentRepository
+}
+
+func NewLimitReconciliationUsecase(limitRepo repository.LimitRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository) LimitReconciliationUsecase {
+	return &limitReconciliationUsecase{limitRepo: limitRepo, transactionRepo: transactionRepo, installmentRepo: installmentRepo}
+}
+
+func (u *limitReconciliationUsecase) ReconcileLimits(userID uint, repair bool) (*domain.LimitReconciliationResult, error) {
+	startedAt := time.Now()
+
+	limitIDs, err := u.limitRepo.GetLimitIDs()
+	if err != nil {
+		utils.Logger.WithError(err).Error("Limit reconciliation failed to load limits")
+		return nil, err
+	}
+
+	result := &domain.LimitReconciliationResult{Mismatches: []domain.LimitReconciliation{}}
+	for _, limitID := range limitIDs {
+		var reconciliation *domain.LimitReconciliation
+
+		err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+			var err error
+			reconciliation, err = u.reconcileLimitWithTx(tx, userID, limitID, repair)
+			return err
+		})
+		if err != nil {
+			result.FailedLimits++
+			utils.Logger.WithFields(logrus.Fields{
+				"limit_id": limitID,
+				"error":    err.Error(),
+			}).Error("Limit reconciliation failed to check limit")
+			continue
+		}
+
+		result.CheckedLimits++
+		if reconciliation == nil {
+			continue
+		}
+
+		result.MismatchedLimits++
+		if reconciliation.Repaired {
+			result.RepairedLimits++
+		}
+		result.Mismatches = append(result.Mismatches, *reconciliation)
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"repair":            repair,
+		"checked_limits":    result.CheckedLimits,
+		"mismatched_limits": result.MismatchedLimits,
+		"repaired_limits":   result.RepairedLimits,
+		"failed_limits":     result.FailedLimits,
+		"duration":          time.Since(startedAt).String(),
+	}).Info("Limit reconciliation finished")
+
+	return result, nil
+}
+
+func (u *limitReconciliationUsecase) reconcileLimitWithTx(tx *gorm.DB, userID uint, limitID uint, repair bool) (*domain.LimitReconciliation, error) {
+	limit, err := u.limitRepo.GetLimitByIDWithTx(tx, limitID)
+	if err != nil {
+		return nil, err
+	}
+	if limit.LimitID == 0 {
+		return nil, nil
+	}
+
+	transactions, err := u.transactionRepo.GetTransactionsByLimitIDWithTx(tx, limit.LimitID, limitConsumingStatuses)
+	if err != nil {
+		return nil, err
+	}
+
+	var expectedUsed money.Money
+	for i := range transactions {
+		calculation, err := calculateFinancing(&transactions[i])
+		if err != nil {
+			return nil, err
+		}
+
+		paidPrincipal, err := u.installmentRepo.GetPaidPrincipalWithTx(tx, transactions[i].TransactionID)
+		if err != nil {
+			return nil, err
+		}
+
+		expectedUsed = expectedUsed.Add(calculation.TotalAmount.Sub(paidPrincipal))
+	}
+	expectedRemaining := limit.LimitAmount.Sub(expectedUsed)
+
+	if expectedUsed == limit.LimitUsedAmount && expectedRemaining == limit.LimitRemainingAmount {
+		return nil, nil
+	}
+
+	reconciliation := &domain.LimitReconciliation{
+		LimitID:                 limit.LimitID,
+		LimitNIK:                limit.LimitNIK,
+		LimitTenor:              limit.LimitTenor,
+		LimitAmount:             limit.LimitAmount,
+		StoredUsedAmount:        limit.LimitUsedAmount,
+		ExpectedUsedAmount:      expectedUsed,
+		StoredRemainingAmount:   limit.LimitRemainingAmount,
+		ExpectedRemainingAmount: expectedRemaining,
+		OpenTransactions:        len(transactions),
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"limit_id":                  limit.LimitID,
+		"stored_used_amount":        limit.LimitUsedAmount,
+		"expected_used_amount":      expectedUsed,
+		"stored_remaining_amount":   limit.LimitRemainingAmount,
+		"expected_remaining_amount": expectedRemaining,
+	}).Warn("Limit balance does not match its transactions")
+
+	if !repair {
+		return reconciliation, nil
+	}
+
+	movement := domain.LimitMovement{
+		MovementType:           domain.LimitMovementAdjust,
+		MovementUsedDelta:      expectedUsed.Sub(limit.LimitUsedAmount),
+		MovementRemainingDelta: expectedRemaining.Sub(limit.LimitRemainingAmount),
+		MovementReason:         "reconciliation",
+	}
+	limit.LimitUsedAmount = expectedUsed
+	limit.LimitRemainingAmount = expectedRemaining
+
+	if err := recordLimitMovementWithTx(tx, u.limitRepo, userID, limit, nil, movement); err != nil {
+		return nil, err
+	}
+
+	if err := u.limitRepo.UpdateLimitWithTx(tx, limit); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_id": limit.LimitID,
+			"error":    err.Error(),
+		}).Error("Failed to repair limit balance")
+		return nil, err
+	}
+
+	reconciliation.Repaired = true
+	return reconciliation, nil
+}
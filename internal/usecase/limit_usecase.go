@@ -3,27 +3,41 @@ package usecase
 import (
 	"errors"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
 	"kreditplus/internal/repository"
 	"kreditplus/internal/utils"
 	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
+var ErrLimitAlreadyExists = errors.New("limit already exists for this NIK and tenor")
+
 type LimitUsecase interface {
 	CreateLimit(input domain.Limit) error
 	GetAllLimits(limit, offset int) ([]domain.Limit, error)
 	GetLimitByID(id uint) (*domain.Limit, error)
 	GetCustomerByNIK(nik string) (*domain.Customer, error)
 	UpdateLimit(input domain.Limit) error
-	DeleteLimit(id uint) error
+	DeleteLimit(userID uint, id uint) error
+	GetLimitMovements(limit *domain.Limit, pageLimit, offset int) ([]domain.LimitMovement, *domain.LimitLedger, error)
+	GetCustomerLimitSummary(nik string) (*domain.CustomerLimitSummary, error)
+	SetCustomerLimit(userID uint, nik string, amount money.Money) (*domain.CustomerLimit, error)
+	UpsertLimit(userID uint, nik string, tenor int, amount money.Money) (*domain.Limit, bool, error)
+	MergeDuplicateLimits() (*domain.LimitMergeResult, error)
 }
 
 type limitUsecase struct {
-	customerRepo repository.CustomerRepository
-	limitRepo    repository.LimitRepository
+	customerRepo     repository.CustomerRepository
+	limitRepo        repository.LimitRepository
+	transactionRepo  repository.TransactionRepository
+	productRepo      repository.ProductRepository
+	negativeListRepo repository.NegativeListRepository
 }
 
-func NewLimitUsecase(limitRepo repository.LimitRepository, customerRepo repository.CustomerRepository) LimitUsecase {
-	return &limitUsecase{limitRepo: limitRepo, customerRepo: customerRepo}
+func NewLimitUsecase(limitRepo repository.LimitRepository, customerRepo repository.CustomerRepository, transactionRepo repository.TransactionRepository, productRepo repository.ProductRepository, negativeListRepo repository.NegativeListRepository) LimitUsecase {
+	return &limitUsecase{limitRepo: limitRepo, customerRepo: customerRepo, transactionRepo: transactionRepo, productRepo: productRepo, negativeListRepo: negativeListRepo}
 }
 
 func (u *limitUsecase) CreateLimit(input domain.Limit) error {
@@ -31,13 +45,37 @@ func (u *limitUsecase) CreateLimit(input domain.Limit) error {
 
 	input.LimitTenor = utils.SanitizeNumberInt(input.LimitTenor)
 
-	input.LimitAmount = utils.SanitizeNumberFloat64(input.LimitAmount)
+	input.LimitAmount = utils.SanitizeMoney(input.LimitAmount)
 
 	if input.LimitNIK == "" || len(input.LimitNIK) != 16 {
 		return errors.New("invalid NIK")
 	}
+
+	if err := ensureTenorOffered(u.productRepo, input.LimitTenor); err != nil {
+		return err
+	}
+
+	if err := u.ensureNIKEligible(input.LimitNIK); err != nil {
+		return err
+	}
 	input.LimitCreatedAt = time.Now()
-	return u.limitRepo.CreateLimit(&input)
+
+	return u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		if err := u.limitRepo.CreateLimitWithTx(tx, &input); err != nil {
+			if repository.IsUniqueViolation(err) {
+				return ErrLimitAlreadyExists
+			}
+			return err
+		}
+
+		return recordLimitMovementWithTx(tx, u.limitRepo, input.LimitCreatedBy, &input, nil, domain.LimitMovement{
+			MovementType:           domain.LimitMovementAdjust,
+			MovementLimitDelta:     input.LimitAmount,
+			MovementUsedDelta:      input.LimitUsedAmount,
+			MovementRemainingDelta: input.LimitRemainingAmount,
+			MovementReason:         "limit opened",
+		})
+	})
 }
 
 func (u *limitUsecase) GetAllLimits(limit, offset int) ([]domain.Limit, error) {
@@ -52,14 +90,31 @@ func (u *limitUsecase) GetCustomerByNIK(nik string) (*domain.Customer, error) {
 	return u.customerRepo.GetCustomerByNIK(nik)
 }
 
+func (u *limitUsecase) ensureNIKEligible(nik string) error {
+	customer, err := u.customerRepo.GetCustomerByNIK(nik)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_nik": nik,
+			"error":     err.Error(),
+		}).Warn("Customer not found for limit")
+		return err
+	}
+	if err := ensureCustomerVerified(customer); err != nil {
+		return err
+	}
+
+	_, err = enforceScreening(u.negativeListRepo, customer, "limit_create")
+	return err
+}
+
 func (u *limitUsecase) UpdateLimit(input domain.Limit) error {
 	input.LimitNIK = utils.SanitizeString(input.LimitNIK)
 
 	input.LimitTenor = utils.SanitizeNumberInt(input.LimitTenor)
 
-	input.LimitAmount = utils.SanitizeNumberFloat64(input.LimitAmount)
-	input.LimitUsedAmount = utils.SanitizeNumberFloat64(input.LimitUsedAmount)
-	input.LimitRemainingAmount = utils.SanitizeNumberFloat64(input.LimitRemainingAmount)
+	input.LimitAmount = utils.SanitizeMoney(input.LimitAmount)
+	input.LimitUsedAmount = utils.SanitizeMoney(input.LimitUsedAmount)
+	input.LimitRemainingAmount = utils.SanitizeMoney(input.LimitRemainingAmount)
 
 	if input.LimitNIK != "" && len(input.LimitNIK) != 16 {
 		return errors.New("NIK must be 16 numeric characters")
@@ -67,9 +122,466 @@ func (u *limitUsecase) UpdateLimit(input domain.Limit) error {
 
 	input.LimitEditedAt = new(time.Time)
 	*input.LimitEditedAt = time.Now()
-	return u.limitRepo.UpdateLimit(&input)
+
+	return u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		current, err := u.limitRepo.GetLimitByIDWithTx(tx, input.LimitID)
+		if err != nil {
+			return err
+		}
+
+		if input.LimitTenor != current.LimitTenor {
+			if err := ensureTenorOffered(u.productRepo, input.LimitTenor); err != nil {
+				return err
+			}
+		}
+
+		if err := u.limitRepo.UpdateLimitWithTx(tx, &input); err != nil {
+			return err
+		}
+
+		movement := domain.LimitMovement{
+			MovementType:           domain.LimitMovementAdjust,
+			MovementLimitDelta:     input.LimitAmount.Sub(current.LimitAmount),
+			MovementUsedDelta:      input.LimitUsedAmount.Sub(current.LimitUsedAmount),
+			MovementRemainingDelta: input.LimitRemainingAmount.Sub(current.LimitRemainingAmount),
+			MovementReason:         "limit edited",
+		}
+		if movement.MovementLimitDelta.IsZero() && movement.MovementUsedDelta.IsZero() && movement.MovementRemainingDelta.IsZero() {
+			return nil
+		}
+
+		var editedBy uint
+		if input.LimitEditedBy != nil {
+			editedBy = *input.LimitEditedBy
+		}
+		return recordLimitMovementWithTx(tx, u.limitRepo, editedBy, &input, nil, movement)
+	})
+}
+
+func (u *limitUsecase) DeleteLimit(userID uint, id uint) error {
+	return u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		limit, err := u.limitRepo.GetLimitByIDWithTx(tx, id)
+		if err != nil {
+			return err
+		}
+
+		if !limit.LimitRemainingAmount.IsZero() {
+			expired := limit.LimitRemainingAmount
+			limit.LimitAmount = limit.LimitAmount.Sub(expired)
+			limit.LimitRemainingAmount = money.Money{}
+
+			if err := recordLimitMovementWithTx(tx, u.limitRepo, userID, limit, nil, domain.LimitMovement{
+				MovementType:           domain.LimitMovementExpire,
+				MovementLimitDelta:     expired.Neg(),
+				MovementRemainingDelta: expired.Neg(),
+				MovementReason:         "limit deleted",
+			}); err != nil {
+				return err
+			}
+		}
+
+		return u.limitRepo.DeleteLimitWithTx(tx, id)
+	})
+}
+
+func (u *limitUsecase) GetLimitMovements(limit *domain.Limit, pageLimit, offset int) ([]domain.LimitMovement, *domain.LimitLedger, error) {
+	movements, err := u.limitRepo.GetLimitMovements(limit.LimitID, pageLimit, offset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ledger, err := u.limitRepo.GetLimitLedger(limit.LimitID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ledger.LedgerConsistent = ledger.LedgerLimitAmount == limit.LimitAmount &&
+		ledger.LedgerUsedAmount == limit.LimitUsedAmount &&
+		ledger.LedgerRemainingAmount == limit.LimitRemainingAmount
+	if !ledger.LedgerConsistent {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_id":                limit.LimitID,
+			"limit_used_amount":       limit.LimitUsedAmount,
+			"ledger_used_amount":      ledger.LedgerUsedAmount,
+			"limit_remaining_amount":  limit.LimitRemainingAmount,
+			"ledger_remaining_amount": ledger.LedgerRemainingAmount,
+		}).Warn("Limit balance does not match its movement ledger")
+	}
+
+	return movements, ledger, nil
+}
+
+func (u *limitUsecase) GetCustomerLimitSummary(nik string) (*domain.CustomerLimitSummary, error) {
+	customerLimit, err := u.limitRepo.GetCustomerLimitByNIK(nik)
+	if err != nil {
+		return nil, err
+	}
+
+	limits, err := u.limitRepo.GetLimitsByNIK(nik)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.CustomerLimitSummary{CustomerNIK: nik, SubLimits: []domain.CustomerSubLimit{}}
+	for _, limit := range limits {
+		summary.TotalUsedAmount = summary.TotalUsedAmount.Add(limit.LimitUsedAmount)
+	}
+
+	var umbrellaRemaining money.Money
+	if customerLimit.CustomerLimitID != 0 {
+		umbrellaRemaining = customerLimit.CustomerLimitAmount.Sub(summary.TotalUsedAmount)
+		summary.CustomerLimit = customerLimit
+		summary.TotalRemainingAmount = &umbrellaRemaining
+	}
+
+	for _, limit := range limits {
+		available := limit.LimitRemainingAmount
+		if summary.CustomerLimit != nil {
+			available = money.Max(money.Min(available, umbrellaRemaining), money.Money{})
+		}
+		summary.SubLimits = append(summary.SubLimits, domain.CustomerSubLimit{Limit: limit, LimitAvailableAmount: available})
+	}
+
+	return summary, nil
+}
+
+func (u *limitUsecase) SetCustomerLimit(userID uint, nik string, amount money.Money) (*domain.CustomerLimit, error) {
+	amount = utils.SanitizeMoney(amount)
+	if !amount.IsPositive() {
+		return nil, errors.New("invalid customer limit amount")
+	}
+
+	customerLimit, err := u.limitRepo.GetCustomerLimitByNIK(nik)
+	if err != nil {
+		return nil, err
+	}
+
+	timeNow := time.Now()
+	if customerLimit.CustomerLimitID == 0 {
+		customerLimit.CustomerLimitNIK = nik
+		customerLimit.CustomerLimitCreatedBy = userID
+		customerLimit.CustomerLimitCreatedAt = timeNow
+	} else {
+		customerLimit.CustomerLimitEditedBy = &userID
+		customerLimit.CustomerLimitEditedAt = &timeNow
+	}
+	customerLimit.CustomerLimitAmount = amount
+
+	if err := u.limitRepo.SaveCustomerLimit(customerLimit); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": nik,
+			"error":        err.Error(),
+		}).Error("Failed to save customer limit")
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":               userID,
+		"customer_nik":          nik,
+		"customer_limit_amount": amount,
+	}).Info("Customer limit successfully saved")
+
+	return customerLimit, nil
+}
+
+func (u *limitUsecase) UpsertLimit(userID uint, nik string, tenor int, amount money.Money) (*domain.Limit, bool, error) {
+	amount = utils.SanitizeMoney(amount)
+	if !amount.IsPositive() {
+		return nil, false, errors.New("invalid limit amount")
+	}
+
+	if err := ensureTenorOffered(u.productRepo, tenor); err != nil {
+		return nil, false, err
+	}
+
+	if err := u.ensureNIKEligible(nik); err != nil {
+		return nil, false, err
+	}
+
+	var limit *domain.Limit
+	var created bool
+	err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		timeNow := time.Now()
+
+		candidate := &domain.Limit{
+			LimitNIK:             nik,
+			LimitTenor:           tenor,
+			LimitAmount:          amount,
+			LimitRemainingAmount: amount,
+			LimitCreatedBy:       userID,
+			LimitCreatedAt:       timeNow,
+		}
+
+		var err error
+		created, err = u.limitRepo.CreateLimitIfAbsentWithTx(tx, candidate)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"limit_nik":   nik,
+				"limit_tenor": tenor,
+				"error":       err.Error(),
+			}).Error("Failed to create limit")
+			return err
+		}
+		if created {
+			limit = candidate
+			return recordLimitMovementWithTx(tx, u.limitRepo, userID, limit, nil, domain.LimitMovement{
+				MovementType:           domain.LimitMovementAdjust,
+				MovementLimitDelta:     amount,
+				MovementRemainingDelta: amount,
+				MovementReason:         "limit opened",
+			})
+		}
+
+		limit, err = u.limitRepo.GetLimitByNIKandTenorWithTx(tx, nik, float64(tenor))
+		if err != nil {
+			return err
+		}
+
+		delta := amount.Sub(limit.LimitAmount)
+		if delta.IsZero() {
+			return nil
+		}
+
+		limit.LimitAmount = amount
+		limit.LimitRemainingAmount = limit.LimitRemainingAmount.Add(delta)
+		limit.LimitEditedBy = &userID
+		limit.LimitEditedAt = &timeNow
+
+		if err := u.limitRepo.UpdateLimitWithTx(tx, limit); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"limit_id": limit.LimitID,
+				"error":    err.Error(),
+			}).Error("Failed to update limit")
+			return err
+		}
+
+		return recordLimitMovementWithTx(tx, u.limitRepo, userID, limit, nil, domain.LimitMovement{
+			MovementType:           domain.LimitMovementAdjust,
+			MovementLimitDelta:     delta,
+			MovementRemainingDelta: delta,
+			MovementReason:         "limit edited",
+		})
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":      userID,
+		"limit_id":     limit.LimitID,
+		"limit_nik":    nik,
+		"limit_tenor":  tenor,
+		"limit_amount": amount,
+		"created":      created,
+	}).Info("Limit successfully upserted")
+
+	return limit, created, nil
+}
+
+func (u *limitUsecase) MergeDuplicateLimits() (*domain.LimitMergeResult, error) {
+	keys, err := u.limitRepo.GetDuplicateLimitKeys()
+	if err != nil {
+		utils.Logger.WithError(err).Error("Failed to look up duplicate limits")
+		return nil, err
+	}
+
+	result := &domain.LimitMergeResult{DuplicateKeys: len(keys), Merges: []domain.LimitMerge{}}
+	for _, key := range keys {
+		var merge *domain.LimitMerge
+
+		err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+			var err error
+			merge, err = u.mergeLimitsWithTx(tx, key)
+			return err
+		})
+		if err != nil {
+			result.FailedKeys++
+			utils.Logger.WithFields(logrus.Fields{
+				"limit_nik":   key.LimitNIK,
+				"limit_tenor": key.LimitTenor,
+				"error":       err.Error(),
+			}).Error("Failed to merge duplicate limits")
+			continue
+		}
+		if merge == nil {
+			continue
+		}
+
+		result.MergedLimits += len(merge.MergedLimitIDs)
+		result.Merges = append(result.Merges, *merge)
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"duplicate_keys": result.DuplicateKeys,
+		"merged_limits":  result.MergedLimits,
+		"failed_keys":    result.FailedKeys,
+	}).Info("Duplicate limit merge finished")
+
+	return result, nil
+}
+
+func (u *limitUsecase) mergeLimitsWithTx(tx *gorm.DB, key domain.LimitKey) (*domain.LimitMerge, error) {
+	limits, err := u.limitRepo.GetLimitsByNIKandTenorWithTx(tx, key.LimitNIK, key.LimitTenor)
+	if err != nil {
+		return nil, err
+	}
+	if len(limits) < 2 {
+		return nil, nil
+	}
+
+	survivor := limits[0]
+	var mergedIDs []uint
+	var totalAmount, totalUsed, totalRemaining money.Money
+	amount := survivor.LimitAmount
+	for _, limit := range limits {
+		totalAmount = totalAmount.Add(limit.LimitAmount)
+		totalUsed = totalUsed.Add(limit.LimitUsedAmount)
+		totalRemaining = totalRemaining.Add(limit.LimitRemainingAmount)
+		amount = money.Max(amount, limit.LimitAmount)
+		if limit.LimitID != survivor.LimitID {
+			mergedIDs = append(mergedIDs, limit.LimitID)
+		}
+	}
+
+	if err := u.transactionRepo.ReassignTransactionsLimitWithTx(tx, mergedIDs, survivor.LimitID); err != nil {
+		return nil, err
+	}
+	if err := u.limitRepo.ReassignLimitMovementsWithTx(tx, mergedIDs, survivor.LimitID); err != nil {
+		return nil, err
+	}
+	for _, id := range mergedIDs {
+		if err := u.limitRepo.DeleteLimitWithTx(tx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	survivor.LimitAmount = amount
+	survivor.LimitUsedAmount = totalUsed
+	survivor.LimitRemainingAmount = amount.Sub(totalUsed)
+	if err := u.limitRepo.UpdateLimitWithTx(tx, &survivor); err != nil {
+		return nil, err
+	}
+
+	if err := recordLimitMovementWithTx(tx, u.limitRepo, survivor.LimitCreatedBy, &survivor, nil, domain.LimitMovement{
+		MovementType:           domain.LimitMovementAdjust,
+		MovementLimitDelta:     amount.Sub(totalAmount),
+		MovementRemainingDelta: survivor.LimitRemainingAmount.Sub(totalRemaining),
+		MovementReason:         "duplicate limits merged",
+	}); err != nil {
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"limit_nik":         key.LimitNIK,
+		"limit_tenor":       key.LimitTenor,
+		"survivor_limit_id": survivor.LimitID,
+		"merged_limit_ids":  mergedIDs,
+		"limit_amount":      survivor.LimitAmount,
+		"limit_used_amount": survivor.LimitUsedAmount,
+	}).Warn("Duplicate limits merged")
+
+	return &domain.LimitMerge{
+		LimitNIK:             key.LimitNIK,
+		LimitTenor:           key.LimitTenor,
+		SurvivorLimitID:      survivor.LimitID,
+		MergedLimitIDs:       mergedIDs,
+		LimitAmount:          survivor.LimitAmount,
+		LimitUsedAmount:      survivor.LimitUsedAmount,
+		LimitRemainingAmount: survivor.LimitRemainingAmount,
+	}, nil
 }
 
-func (u *limitUsecase) DeleteLimit(id uint) error {
-	return u.limitRepo.DeleteLimit(id)
+func checkCustomerLimitWithTx(tx *gorm.DB, limitRepo repository.LimitRepository, nik string) error {
+	customerLimit, err := limitRepo.GetCustomerLimitByNIKWithTx(tx, nik)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": nik,
+			"error":        err.Error(),
+		}).Error("Failed to retrieve customer limit")
+		return err
+	}
+	if customerLimit.CustomerLimitID == 0 {
+		return nil
+	}
+
+	used, err := limitRepo.GetUsedAmountByNIKWithTx(tx, nik)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": nik,
+			"error":        err.Error(),
+		}).Error("Failed to sum customer limit usage")
+		return err
+	}
+
+	if used.GreaterThan(customerLimit.CustomerLimitAmount) {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik":          nik,
+			"customer_limit_amount": customerLimit.CustomerLimitAmount,
+			"total_used_amount":     used,
+		}).Warn("Customer limit exceeded across tenors")
+		return ErrCustomerLimitExceeded
+	}
+	return nil
+}
+
+func consumeLimitWithTx(tx *gorm.DB, limitRepo repository.LimitRepository, userID uint, limit *domain.Limit, transactionID *uint, amount money.Money, reason string) error {
+	applied, err := limitRepo.ConsumeLimitWithTx(tx, limit, amount)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_id": limit.LimitID,
+			"error":    err.Error(),
+		}).Error("Failed to consume limit")
+		return err
+	}
+	if !applied {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_id": limit.LimitID,
+			"amount":   amount,
+		}).Warn("Insufficient limit to consume")
+		return ErrInsufficientLimit
+	}
+
+	return recordLimitMovementWithTx(tx, limitRepo, userID, limit, transactionID, domain.LimitMovement{
+		MovementType:           domain.LimitMovementConsume,
+		MovementUsedDelta:      amount,
+		MovementRemainingDelta: amount.Neg(),
+		MovementReason:         reason,
+	})
+}
+
+func releaseLimitWithTx(tx *gorm.DB, limitRepo repository.LimitRepository, userID uint, limit *domain.Limit, transactionID *uint, amount money.Money, reason string) error {
+	if err := limitRepo.ReleaseLimitWithTx(tx, limit, amount); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_id": limit.LimitID,
+			"error":    err.Error(),
+		}).Error("Failed to release limit")
+		return err
+	}
+
+	return recordLimitMovementWithTx(tx, limitRepo, userID, limit, transactionID, domain.LimitMovement{
+		MovementType:           domain.LimitMovementRelease,
+		MovementUsedDelta:      amount.Neg(),
+		MovementRemainingDelta: amount,
+		MovementReason:         reason,
+	})
+}
+
+func recordLimitMovementWithTx(tx *gorm.DB, limitRepo repository.LimitRepository, userID uint, limit *domain.Limit, transactionID *uint, movement domain.LimitMovement) error {
+	movement.MovementLimitID = limit.LimitID
+	movement.MovementTransactionID = transactionID
+	movement.MovementLimitBalance = limit.LimitAmount
+	movement.MovementUsedBalance = limit.LimitUsedAmount
+	movement.MovementRemainingBalance = limit.LimitRemainingAmount
+	movement.MovementCreatedBy = userID
+	movement.MovementCreatedAt = time.Now()
+
+	if err := limitRepo.CreateLimitMovementWithTx(tx, &movement); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"limit_id":      limit.LimitID,
+			"movement_type": movement.MovementType,
+			"error":         err.Error(),
+		}).Error("Failed to record limit movement")
+		return err
+	}
+	return nil
 }
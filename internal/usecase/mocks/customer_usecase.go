@@ -14,21 +14,33 @@ type CustomerUsecase struct {
 }
 
 // CreateCustomer provides a mock function with given fields: input
-func (_m *CustomerUsecase) CreateCustomer(input domain.Customer) error {
+func (_m *CustomerUsecase) CreateCustomer(input domain.Customer) ([]domain.SuspectedDuplicate, error) {
 	ret := _m.Called(input)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateCustomer")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(domain.Customer) error); ok {
+	var r0 []domain.SuspectedDuplicate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(domain.Customer) ([]domain.SuspectedDuplicate, error)); ok {
+		return rf(input)
+	}
+	if rf, ok := ret.Get(0).(func(domain.Customer) []domain.SuspectedDuplicate); ok {
 		r0 = rf(input)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SuspectedDuplicate)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(domain.Customer) error); ok {
+		r1 = rf(input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // DeleteCustomer provides a mock function with given fields: id
@@ -109,6 +121,84 @@ func (_m *CustomerUsecase) GetCustomerByID(id uint) (*domain.Customer, error) {
 	return r0, r1
 }
 
+// GetKYCReviewQueue provides a mock function with given fields: status, limit, offset
+func (_m *CustomerUsecase) GetKYCReviewQueue(status string, limit int, offset int) ([]domain.Customer, error) {
+	ret := _m.Called(status, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetKYCReviewQueue")
+	}
+
+	var r0 []domain.Customer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]domain.Customer, error)); ok {
+		return rf(status, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []domain.Customer); ok {
+		r0 = rf(status, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Customer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) error); ok {
+		r1 = rf(status, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSuspectedDuplicates provides a mock function with given fields: customer
+func (_m *CustomerUsecase) GetSuspectedDuplicates(customer *domain.Customer) ([]domain.SuspectedDuplicate, error) {
+	ret := _m.Called(customer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSuspectedDuplicates")
+	}
+
+	var r0 []domain.SuspectedDuplicate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.Customer) ([]domain.SuspectedDuplicate, error)); ok {
+		return rf(customer)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.Customer) []domain.SuspectedDuplicate); ok {
+		r0 = rf(customer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.SuspectedDuplicate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.Customer) error); ok {
+		r1 = rf(customer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReviewCustomerKYC provides a mock function with given fields: reviewerID, customer, input
+func (_m *CustomerUsecase) ReviewCustomerKYC(reviewerID uint, customer *domain.Customer, input domain.CustomerKYCReviewInput) error {
+	ret := _m.Called(reviewerID, customer, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReviewCustomerKYC")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, *domain.Customer, domain.CustomerKYCReviewInput) error); ok {
+		r0 = rf(reviewerID, customer, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateCustomer provides a mock function with given fields: input
 func (_m *CustomerUsecase) UpdateCustomer(input domain.Customer) error {
 	ret := _m.Called(input)
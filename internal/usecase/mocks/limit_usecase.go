@@ -6,6 +6,8 @@ import (
 	domain "kreditplus/internal/domain"
 
 	mock "github.com/stretchr/testify/mock"
+
+	money "kreditplus/internal/money"
 )
 
 // LimitUsecase is an autogenerated mock type for the LimitUsecase type
@@ -31,17 +33,17 @@ func (_m *LimitUsecase) CreateLimit(input domain.Limit) error {
 	return r0
 }
 
-// DeleteLimit provides a mock function with given fields: id
-func (_m *LimitUsecase) DeleteLimit(id uint) error {
-	ret := _m.Called(id)
+// DeleteLimit provides a mock function with given fields: userID, id
+func (_m *LimitUsecase) DeleteLimit(userID uint, id uint) error {
+	ret := _m.Called(userID, id)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteLimit")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint) error); ok {
-		r0 = rf(id)
+	if rf, ok := ret.Get(0).(func(uint, uint) error); ok {
+		r0 = rf(userID, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -109,6 +111,36 @@ func (_m *LimitUsecase) GetCustomerByNIK(nik string) (*domain.Customer, error) {
 	return r0, r1
 }
 
+// GetCustomerLimitSummary provides a mock function with given fields: nik
+func (_m *LimitUsecase) GetCustomerLimitSummary(nik string) (*domain.CustomerLimitSummary, error) {
+	ret := _m.Called(nik)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCustomerLimitSummary")
+	}
+
+	var r0 *domain.CustomerLimitSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.CustomerLimitSummary, error)); ok {
+		return rf(nik)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.CustomerLimitSummary); ok {
+		r0 = rf(nik)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CustomerLimitSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(nik)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetLimitByID provides a mock function with given fields: id
 func (_m *LimitUsecase) GetLimitByID(id uint) (*domain.Limit, error) {
 	ret := _m.Called(id)
@@ -139,6 +171,105 @@ func (_m *LimitUsecase) GetLimitByID(id uint) (*domain.Limit, error) {
 	return r0, r1
 }
 
+// GetLimitMovements provides a mock function with given fields: limit, pageLimit, offset
+func (_m *LimitUsecase) GetLimitMovements(limit *domain.Limit, pageLimit int, offset int) ([]domain.LimitMovement, *domain.LimitLedger, error) {
+	ret := _m.Called(limit, pageLimit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLimitMovements")
+	}
+
+	var r0 []domain.LimitMovement
+	var r1 *domain.LimitLedger
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*domain.Limit, int, int) ([]domain.LimitMovement, *domain.LimitLedger, error)); ok {
+		return rf(limit, pageLimit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.Limit, int, int) []domain.LimitMovement); ok {
+		r0 = rf(limit, pageLimit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.LimitMovement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.Limit, int, int) *domain.LimitLedger); ok {
+		r1 = rf(limit, pageLimit, offset)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*domain.LimitLedger)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(*domain.Limit, int, int) error); ok {
+		r2 = rf(limit, pageLimit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MergeDuplicateLimits provides a mock function with no fields
+func (_m *LimitUsecase) MergeDuplicateLimits() (*domain.LimitMergeResult, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeDuplicateLimits")
+	}
+
+	var r0 *domain.LimitMergeResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*domain.LimitMergeResult, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *domain.LimitMergeResult); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.LimitMergeResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetCustomerLimit provides a mock function with given fields: userID, nik, amount
+func (_m *LimitUsecase) SetCustomerLimit(userID uint, nik string, amount money.Money) (*domain.CustomerLimit, error) {
+	ret := _m.Called(userID, nik, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetCustomerLimit")
+	}
+
+	var r0 *domain.CustomerLimit
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, string, money.Money) (*domain.CustomerLimit, error)); ok {
+		return rf(userID, nik, amount)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string, money.Money) *domain.CustomerLimit); ok {
+		r0 = rf(userID, nik, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.CustomerLimit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, string, money.Money) error); ok {
+		r1 = rf(userID, nik, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateLimit provides a mock function with given fields: input
 func (_m *LimitUsecase) UpdateLimit(input domain.Limit) error {
 	ret := _m.Called(input)
@@ -157,6 +288,43 @@ func (_m *LimitUsecase) UpdateLimit(input domain.Limit) error {
 	return r0
 }
 
+// UpsertLimit provides a mock function with given fields: userID, nik, tenor, amount
+func (_m *LimitUsecase) UpsertLimit(userID uint, nik string, tenor int, amount money.Money) (*domain.Limit, bool, error) {
+	ret := _m.Called(userID, nik, tenor, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertLimit")
+	}
+
+	var r0 *domain.Limit
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(uint, string, int, money.Money) (*domain.Limit, bool, error)); ok {
+		return rf(userID, nik, tenor, amount)
+	}
+	if rf, ok := ret.Get(0).(func(uint, string, int, money.Money) *domain.Limit); ok {
+		r0 = rf(userID, nik, tenor, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Limit)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, string, int, money.Money) bool); ok {
+		r1 = rf(userID, nik, tenor, amount)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(uint, string, int, money.Money) error); ok {
+		r2 = rf(userID, nik, tenor, amount)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewLimitUsecase creates a new instance of LimitUsecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewLimitUsecase(t interface {
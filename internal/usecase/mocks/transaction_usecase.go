@@ -13,35 +13,17 @@ type TransactionUsecase struct {
 	mock.Mock
 }
 
-// CreateTransactionWithLimitUpdate provides a mock function with given fields: userID, customer, input
-func (_m *TransactionUsecase) CreateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, input domain.TransactionInput) error {
-	ret := _m.Called(userID, customer, input)
+// CreateTransactionWithLimitUpdate provides a mock function with given fields: userID, customer, product, input
+func (_m *TransactionUsecase) CreateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, product *domain.Product, input domain.TransactionInput) error {
+	ret := _m.Called(userID, customer, product, input)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTransactionWithLimitUpdate")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, *domain.Customer, domain.TransactionInput) error); ok {
-		r0 = rf(userID, customer, input)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// DeleteTransactionWithLimitUpdate provides a mock function with given fields: userID, transaction
-func (_m *TransactionUsecase) DeleteTransactionWithLimitUpdate(userID uint, transaction *domain.Transaction) error {
-	ret := _m.Called(userID, transaction)
-
-	if len(ret) == 0 {
-		panic("no return value specified for DeleteTransactionWithLimitUpdate")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, *domain.Transaction) error); ok {
-		r0 = rf(userID, transaction)
+	if rf, ok := ret.Get(0).(func(uint, *domain.Customer, *domain.Product, domain.TransactionInput) error); ok {
+		r0 = rf(userID, customer, product, input)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -109,6 +91,66 @@ func (_m *TransactionUsecase) GetCustomerByNIK(nik string) (*domain.Customer, er
 	return r0, r1
 }
 
+// GetDelinquentTransactions provides a mock function with given fields: collectibility, minDaysPastDue, limit, offset
+func (_m *TransactionUsecase) GetDelinquentTransactions(collectibility string, minDaysPastDue int, limit int, offset int) ([]domain.Transaction, error) {
+	ret := _m.Called(collectibility, minDaysPastDue, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelinquentTransactions")
+	}
+
+	var r0 []domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, int, int, int) ([]domain.Transaction, error)); ok {
+		return rf(collectibility, minDaysPastDue, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int, int) []domain.Transaction); ok {
+		r0 = rf(collectibility, minDaysPastDue, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int, int) error); ok {
+		r1 = rf(collectibility, minDaysPastDue, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProductByCode provides a mock function with given fields: code
+func (_m *TransactionUsecase) GetProductByCode(code string) (*domain.Product, error) {
+	ret := _m.Called(code)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetProductByCode")
+	}
+
+	var r0 *domain.Product
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*domain.Product, error)); ok {
+		return rf(code)
+	}
+	if rf, ok := ret.Get(0).(func(string) *domain.Product); ok {
+		r0 = rf(code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Product)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionByID provides a mock function with given fields: id
 func (_m *TransactionUsecase) GetTransactionByID(id uint) (*domain.Transaction, error) {
 	ret := _m.Called(id)
@@ -139,17 +181,212 @@ func (_m *TransactionUsecase) GetTransactionByID(id uint) (*domain.Transaction,
 	return r0, r1
 }
 
-// UpdateTransactionWithLimitUpdate provides a mock function with given fields: userID, customer, transaction, input
-func (_m *TransactionUsecase) UpdateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, transaction *domain.Transaction, input domain.TransactionInput) error {
-	ret := _m.Called(userID, customer, transaction, input)
+// GetTransactionRetryMetrics provides a mock function with no fields
+func (_m *TransactionUsecase) GetTransactionRetryMetrics() domain.TransactionRetryMetrics {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionRetryMetrics")
+	}
+
+	var r0 domain.TransactionRetryMetrics
+	if rf, ok := ret.Get(0).(func() domain.TransactionRetryMetrics); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(domain.TransactionRetryMetrics)
+	}
+
+	return r0
+}
+
+// GetTransactionSchedule provides a mock function with given fields: transactionID
+func (_m *TransactionUsecase) GetTransactionSchedule(transactionID uint) ([]domain.Installment, error) {
+	ret := _m.Called(transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionSchedule")
+	}
+
+	var r0 []domain.Installment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]domain.Installment, error)); ok {
+		return rf(transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []domain.Installment); ok {
+		r0 = rf(transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Installment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionStatusHistory provides a mock function with given fields: transactionID
+func (_m *TransactionUsecase) GetTransactionStatusHistory(transactionID uint) ([]domain.TransactionStatusHistory, error) {
+	ret := _m.Called(transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionStatusHistory")
+	}
+
+	var r0 []domain.TransactionStatusHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint) ([]domain.TransactionStatusHistory, error)); ok {
+		return rf(transactionID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) []domain.TransactionStatusHistory); ok {
+		r0 = rf(transactionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionStatusHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) error); ok {
+		r1 = rf(transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionVersions provides a mock function with given fields: transaction
+func (_m *TransactionUsecase) GetTransactionVersions(transaction *domain.Transaction) ([]domain.Transaction, []domain.TransactionRestructure, error) {
+	ret := _m.Called(transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionVersions")
+	}
+
+	var r0 []domain.Transaction
+	var r1 []domain.TransactionRestructure
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*domain.Transaction) ([]domain.Transaction, []domain.TransactionRestructure, error)); ok {
+		return rf(transaction)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.Transaction) []domain.Transaction); ok {
+		r0 = rf(transaction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.Transaction) []domain.TransactionRestructure); ok {
+		r1 = rf(transaction)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]domain.TransactionRestructure)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(*domain.Transaction) error); ok {
+		r2 = rf(transaction)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RestructureTransaction provides a mock function with given fields: userID, transaction, input
+func (_m *TransactionUsecase) RestructureTransaction(userID uint, transaction *domain.Transaction, input domain.TransactionRestructureInput) (*domain.Transaction, error) {
+	ret := _m.Called(userID, transaction, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestructureTransaction")
+	}
+
+	var r0 *domain.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(uint, *domain.Transaction, domain.TransactionRestructureInput) (*domain.Transaction, error)); ok {
+		return rf(userID, transaction, input)
+	}
+	if rf, ok := ret.Get(0).(func(uint, *domain.Transaction, domain.TransactionRestructureInput) *domain.Transaction); ok {
+		r0 = rf(userID, transaction, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint, *domain.Transaction, domain.TransactionRestructureInput) error); ok {
+		r1 = rf(userID, transaction, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SimulateTransaction provides a mock function with given fields: customer, product, input
+func (_m *TransactionUsecase) SimulateTransaction(customer *domain.Customer, product *domain.Product, input domain.TransactionInput) ([]domain.TransactionSimulation, error) {
+	ret := _m.Called(customer, product, input)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateTransaction")
+	}
+
+	var r0 []domain.TransactionSimulation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*domain.Customer, *domain.Product, domain.TransactionInput) ([]domain.TransactionSimulation, error)); ok {
+		return rf(customer, product, input)
+	}
+	if rf, ok := ret.Get(0).(func(*domain.Customer, *domain.Product, domain.TransactionInput) []domain.TransactionSimulation); ok {
+		r0 = rf(customer, product, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TransactionSimulation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*domain.Customer, *domain.Product, domain.TransactionInput) error); ok {
+		r1 = rf(customer, product, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransitionTransactionStatus provides a mock function with given fields: userID, transaction, toStatus, reason
+func (_m *TransactionUsecase) TransitionTransactionStatus(userID uint, transaction *domain.Transaction, toStatus string, reason string) error {
+	ret := _m.Called(userID, transaction, toStatus, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for TransitionTransactionStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint, *domain.Transaction, string, string) error); ok {
+		r0 = rf(userID, transaction, toStatus, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTransactionWithLimitUpdate provides a mock function with given fields: userID, customer, product, transaction, input
+func (_m *TransactionUsecase) UpdateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, product *domain.Product, transaction *domain.Transaction, input domain.TransactionInput) error {
+	ret := _m.Called(userID, customer, product, transaction, input)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTransactionWithLimitUpdate")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(uint, *domain.Customer, *domain.Transaction, domain.TransactionInput) error); ok {
-		r0 = rf(userID, customer, transaction, input)
+	if rf, ok := ret.Get(0).(func(uint, *domain.Customer, *domain.Product, *domain.Transaction, domain.TransactionInput) error); ok {
+		r0 = rf(userID, customer, product, transaction, input)
 	} else {
 		r0 = ret.Error(0)
 	}
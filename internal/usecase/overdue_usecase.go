@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type OverdueUsecase interface {
+	RunOverdueJob(asOf time.Time) (*domain.OverdueJobResult, error)
+}
+
+type overdueUsecase struct {
+	installmentRepo repository.InstallmentRepository
+	transactionRepo repository.TransactionRepository
+	penaltyConfig   config.PenaltyConfig
+}
+
+func NewOverdueUsecase(installmentRepo repository.InstallmentRepository, transactionRepo repository.TransactionRepository, penaltyConfig config.PenaltyConfig) OverdueUsecase {
+	return &overdueUsecase{installmentRepo: installmentRepo, transactionRepo: transactionRepo, penaltyConfig: penaltyConfig}
+}
+
+func (u *overdueUsecase) RunOverdueJob(asOf time.Time) (*domain.OverdueJobResult, error) {
+	startedAt := time.Now()
+	asOfDate := truncateToDate(asOf)
+
+	utils.Logger.WithFields(logrus.Fields{
+		"as_of":              asOfDate.Format("2006-01-02"),
+		"penalty_daily_rate": u.penaltyConfig.DailyRate,
+		"penalty_grace_days": u.penaltyConfig.GraceDays,
+	}).Info("Overdue job started")
+
+	transactionIDs, err := u.transactionRepo.GetTransactionIDsForOverdueReview()
+	if err != nil {
+		utils.Logger.WithError(err).Error("Overdue job failed to load transactions")
+		return nil, err
+	}
+
+	result := &domain.OverdueJobResult{}
+	for _, transactionID := range transactionIDs {
+		var daysPastDue int
+		var penalty money.Money
+
+		err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+			var err error
+			daysPastDue, penalty, err = u.reviewTransactionWithTx(tx, transactionID, asOfDate)
+			return err
+		})
+		if err != nil {
+			result.FailedTransactions++
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_id": transactionID,
+				"error":          err.Error(),
+			}).Error("Overdue job failed to review transaction")
+			continue
+		}
+
+		result.ProcessedTransactions++
+		result.PenaltyAccrued = result.PenaltyAccrued.Add(penalty)
+		if daysPastDue > 0 {
+			result.DelinquentTransactions++
+		}
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"as_of":                   asOfDate.Format("2006-01-02"),
+		"processed_transactions":  result.ProcessedTransactions,
+		"delinquent_transactions": result.DelinquentTransactions,
+		"failed_transactions":     result.FailedTransactions,
+		"penalty_accrued":         result.PenaltyAccrued,
+		"duration":                time.Since(startedAt).String(),
+	}).Info("Overdue job finished")
+
+	return result, nil
+}
+
+func (u *overdueUsecase) reviewTransactionWithTx(tx *gorm.DB, transactionID uint, asOfDate time.Time) (int, money.Money, error) {
+	installments, err := u.installmentRepo.GetOutstandingInstallmentsWithTx(tx, transactionID)
+	if err != nil {
+		return 0, money.Money{}, err
+	}
+
+	var daysPastDue int
+	var penaltyAccrued money.Money
+	for i := range installments {
+		installment := &installments[i]
+		dueDate := truncateToDate(installment.InstallmentDueDate)
+		if !dueDate.Before(asOfDate) {
+			continue
+		}
+
+		if overdueDays := daysBetween(dueDate, asOfDate); overdueDays > daysPastDue {
+			daysPastDue = overdueDays
+		}
+
+		accrualStart := dueDate.AddDate(0, 0, u.penaltyConfig.GraceDays)
+		if installment.InstallmentPenaltyUntil != nil && installment.InstallmentPenaltyUntil.After(accrualStart) {
+			accrualStart = truncateToDate(*installment.InstallmentPenaltyUntil)
+		}
+
+		accrualDays := daysBetween(accrualStart, asOfDate)
+		if accrualDays <= 0 {
+			continue
+		}
+
+		overdueAmount := installmentOutstanding(*installment).Sub(installment.InstallmentPenalty.Sub(installment.InstallmentPaidPenalty))
+		penalty := overdueAmount.Mul(u.penaltyConfig.DailyRate / 100 * float64(accrualDays)).RoundRupiah()
+
+		installment.InstallmentPenalty = installment.InstallmentPenalty.Add(penalty)
+		installment.InstallmentPenaltyUntil = &asOfDate
+		penaltyAccrued = penaltyAccrued.Add(penalty)
+
+		if err := u.installmentRepo.UpdateInstallmentPenaltyWithTx(tx, installment); err != nil {
+			return 0, money.Money{}, err
+		}
+	}
+
+	collectibility := domain.CollectibilityBucket(daysPastDue)
+	if err := u.transactionRepo.UpdateTransactionDelinquencyWithTx(tx, transactionID, daysPastDue, collectibility); err != nil {
+		return 0, money.Money{}, err
+	}
+
+	if penaltyAccrued.IsPositive() || daysPastDue > 0 {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_id":             transactionID,
+			"transaction_days_past_due":  daysPastDue,
+			"transaction_collectibility": collectibility,
+			"penalty_accrued":            penaltyAccrued,
+		}).Info("Overdue job updated transaction delinquency")
+	}
+
+	return daysPastDue, penaltyAccrued, nil
+}
+
+func truncateToDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(truncateToDate(to).Sub(truncateToDate(from)).Hours() / 24)
+}
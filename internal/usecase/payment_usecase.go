@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNoOutstandingInstallments = errors.New("transaction has no outstanding installments")
+	ErrPaymentExceedsOutstanding = errors.New("payment exceeds outstanding balance")
+	ErrTransactionNotActive      = errors.New("transaction is not active")
+)
+
+type PaymentUsecase interface {
+	CreatePayment(userID uint, transaction *domain.Transaction, input domain.PaymentInput) (*domain.Payment, error)
+	GetPaymentsByTransactionID(transactionID uint) ([]domain.Payment, error)
+	GetTransactionByID(id uint) (*domain.Transaction, error)
+}
+
+type paymentUsecase struct {
+	paymentRepo     repository.PaymentRepository
+	installmentRepo repository.InstallmentRepository
+	limitRepo       repository.LimitRepository
+	transactionRepo repository.TransactionRepository
+}
+
+func NewPaymentUsecase(paymentRepo repository.PaymentRepository, installmentRepo repository.InstallmentRepository, limitRepo repository.LimitRepository, transactionRepo repository.TransactionRepository) PaymentUsecase {
+	return &paymentUsecase{paymentRepo: paymentRepo, installmentRepo: installmentRepo, limitRepo: limitRepo, transactionRepo: transactionRepo}
+}
+
+func (u *paymentUsecase) CreatePayment(userID uint, transaction *domain.Transaction, input domain.PaymentInput) (*domain.Payment, error) {
+	input.PaymentChannel = utils.SanitizeString(input.PaymentChannel)
+	input.PaymentAmount = utils.SanitizeMoney(input.PaymentAmount)
+
+	if !input.PaymentAmount.IsPositive() {
+		return nil, errors.New("invalid payment amount")
+	}
+
+	paidAt := time.Now()
+	if input.PaymentPaidAt != "" {
+		paidAt = utils.SanitizeDate(input.PaymentPaidAt)
+	}
+
+	payment := domain.Payment{
+		PaymentTransactionID: transaction.TransactionID,
+		PaymentAmount:        input.PaymentAmount,
+		PaymentChannel:       input.PaymentChannel,
+		PaymentPaidAt:        paidAt,
+		PaymentType:          domain.PaymentTypeInstallment,
+		PaymentCreatedBy:     userID,
+		PaymentCreatedAt:     time.Now(),
+	}
+
+	pendingPayment := payment
+	originalTransaction := *transaction
+	err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		payment = pendingPayment
+		*transaction = originalTransaction
+		if err := lockTransactionWithTx(tx, u.transactionRepo, transaction); err != nil {
+			return err
+		}
+
+		if transaction.TransactionStatus != domain.TransactionStatusActive {
+			utils.Logger.Warnf("Payment rejected for %s transaction %s", transaction.TransactionStatus, transaction.TransactionContractNumber)
+			return ErrTransactionNotActive
+		}
+
+		installments, err := u.installmentRepo.GetOutstandingInstallmentsWithTx(tx, transaction.TransactionID)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to retrieve outstanding installments")
+			return err
+		}
+
+		if len(installments) == 0 {
+			return ErrNoOutstandingInstallments
+		}
+
+		var outstanding money.Money
+		for _, installment := range installments {
+			outstanding = outstanding.Add(installmentOutstanding(installment))
+		}
+
+		if input.PaymentAmount.GreaterThan(outstanding) {
+			utils.Logger.Warnf("Payment exceeds outstanding balance for contract %s", transaction.TransactionContractNumber)
+			return ErrPaymentExceedsOutstanding
+		}
+
+		fullyPaid := input.PaymentAmount == outstanding
+
+		remaining := input.PaymentAmount
+		for i := range installments {
+			if !remaining.IsPositive() {
+				break
+			}
+			installment := &installments[i]
+
+			payment.PaymentPenaltyAmount = payment.PaymentPenaltyAmount.Add(allocatePayment(&remaining, &installment.InstallmentPaidPenalty, installment.InstallmentPenalty))
+			payment.PaymentInterestAmount = payment.PaymentInterestAmount.Add(allocatePayment(&remaining, &installment.InstallmentPaidInterest, installment.InstallmentInterest))
+			payment.PaymentFeeAmount = payment.PaymentFeeAmount.Add(allocatePayment(&remaining, &installment.InstallmentPaidFee, installment.InstallmentFee))
+			payment.PaymentPrincipalAmount = payment.PaymentPrincipalAmount.Add(allocatePayment(&remaining, &installment.InstallmentPaidPrincipal, installment.InstallmentPrincipal))
+
+			if !installmentOutstanding(*installment).IsPositive() {
+				installment.InstallmentStatus = domain.InstallmentStatusPaid
+				installment.InstallmentPaidAt = &paidAt
+			} else {
+				installment.InstallmentStatus = domain.InstallmentStatusPartial
+			}
+
+			if err := u.installmentRepo.UpdateInstallmentWithTx(tx, installment); err != nil {
+				utils.Logger.WithFields(logrus.Fields{
+					"transaction_contract_number": transaction.TransactionContractNumber,
+					"installment_number":          installment.InstallmentNumber,
+					"error":                       err.Error(),
+				}).Error("Failed to update installment")
+				return err
+			}
+		}
+
+		if err := u.paymentRepo.CreatePaymentWithTx(tx, &payment); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to create payment")
+			return err
+		}
+
+		releaseAmount := payment.PaymentPrincipalAmount
+		releaseReason := "installment payment"
+		if fullyPaid {
+			interestAndFee, err := paidOffReleaseAmount(transaction)
+			if err != nil {
+				return err
+			}
+			releaseAmount = releaseAmount.Add(interestAndFee)
+			releaseReason = "fully repaid"
+
+			transaction.TransactionStatus = domain.TransactionStatusPaidOff
+			transaction.TransactionDaysPastDue = 0
+			transaction.TransactionCollectibility = domain.CollectibilityCurrent
+			if err := recordTransactionStatusWithTx(tx, u.transactionRepo, userID, transaction, domain.TransactionStatusActive, "fully repaid"); err != nil {
+				return err
+			}
+		}
+
+		limit := &domain.Limit{LimitID: transaction.TransactionLimit}
+		if err := releaseLimitWithTx(tx, u.limitRepo, userID, limit, &transaction.TransactionID, releaseAmount, releaseReason); err != nil {
+			return err
+		}
+
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                     userID,
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"payment_amount":              payment.PaymentAmount,
+			"payment_principal_amount":    payment.PaymentPrincipalAmount,
+			"transaction_status":          transaction.TransactionStatus,
+			"limit_remaining_amount":      limit.LimitRemainingAmount,
+		}).Info("Payment successfully recorded and limit released")
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (u *paymentUsecase) GetPaymentsByTransactionID(transactionID uint) ([]domain.Payment, error) {
+	return u.paymentRepo.GetPaymentsByTransactionID(transactionID)
+}
+
+func (u *paymentUsecase) GetTransactionByID(id uint) (*domain.Transaction, error) {
+	return u.transactionRepo.GetTransactionByID(id)
+}
+
+func installmentOutstanding(installment domain.Installment) money.Money {
+	return installment.InstallmentPenalty.Sub(installment.InstallmentPaidPenalty).
+		Add(installment.InstallmentInterest.Sub(installment.InstallmentPaidInterest)).
+		Add(installment.InstallmentFee.Sub(installment.InstallmentPaidFee)).
+		Add(installment.InstallmentPrincipal.Sub(installment.InstallmentPaidPrincipal))
+}
+
+func allocatePayment(remaining *money.Money, paid *money.Money, due money.Money) money.Money {
+	portion := money.Min(*remaining, due.Sub(*paid))
+	if !portion.IsPositive() {
+		return money.Money{}
+	}
+	*paid = paid.Add(portion)
+	*remaining = remaining.Sub(portion)
+	return portion
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrPricingOverrideNotAllowed = errors.New("admin fee and interest are priced by the system; overriding them requires the pricing override permission")
+	ErrPricingProductNotFound    = errors.New("pricing rule product not found")
+)
+
+type PricingUsecase interface {
+	CreatePricingRule(userID uint, input domain.PricingRuleInput) (*domain.PricingRule, error)
+	GetAllPricingRules(limit, offset int) ([]domain.PricingRule, error)
+	GetPricingRuleByID(id uint) (*domain.PricingRule, error)
+	UpdatePricingRule(userID uint, rule *domain.PricingRule, input domain.PricingRuleInput) error
+}
+
+type pricingUsecase struct {
+	pricingRuleRepo repository.PricingRuleRepository
+	productRepo     repository.ProductRepository
+}
+
+func NewPricingUsecase(pricingRuleRepo repository.PricingRuleRepository, productRepo repository.ProductRepository) PricingUsecase {
+	return &pricingUsecase{pricingRuleRepo: pricingRuleRepo, productRepo: productRepo}
+}
+
+func (u *pricingUsecase) CreatePricingRule(userID uint, input domain.PricingRuleInput) (*domain.PricingRule, error) {
+	rule := &domain.PricingRule{
+		PricingActive:    true,
+		PricingCreatedBy: userID,
+		PricingCreatedAt: time.Now(),
+	}
+	if err := u.applyPricingRuleInput(rule, input); err != nil {
+		return nil, err
+	}
+
+	if err := u.pricingRuleRepo.CreatePricingRule(rule); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"pricing_product_id": rule.PricingProductID,
+			"error":              err.Error(),
+		}).Error("Failed to create pricing rule")
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":            userID,
+		"pricing_rule_id":    rule.PricingRuleID,
+		"pricing_product_id": rule.PricingProductID,
+	}).Info("Pricing rule successfully created")
+
+	return rule, nil
+}
+
+func (u *pricingUsecase) GetAllPricingRules(limit, offset int) ([]domain.PricingRule, error) {
+	return u.pricingRuleRepo.GetAllPricingRules(limit, offset)
+}
+
+func (u *pricingUsecase) GetPricingRuleByID(id uint) (*domain.PricingRule, error) {
+	return u.pricingRuleRepo.GetPricingRuleByID(id)
+}
+
+func (u *pricingUsecase) UpdatePricingRule(userID uint, rule *domain.PricingRule, input domain.PricingRuleInput) error {
+	if err := u.applyPricingRuleInput(rule, input); err != nil {
+		return err
+	}
+
+	timeNow := time.Now()
+	rule.PricingEditedBy = &userID
+	rule.PricingEditedAt = &timeNow
+
+	if err := u.pricingRuleRepo.UpdatePricingRule(rule); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"pricing_rule_id": rule.PricingRuleID,
+			"error":           err.Error(),
+		}).Error("Failed to update pricing rule")
+		return err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":         userID,
+		"pricing_rule_id": rule.PricingRuleID,
+		"pricing_active":  rule.PricingActive,
+	}).Info("Pricing rule successfully updated")
+
+	return nil
+}
+
+func (u *pricingUsecase) applyPricingRuleInput(rule *domain.PricingRule, input domain.PricingRuleInput) error {
+	input.PricingMinOTR = utils.SanitizeMoney(input.PricingMinOTR)
+	input.PricingMaxOTR = utils.SanitizeMoney(input.PricingMaxOTR)
+	input.PricingAdminFee = utils.SanitizeMoney(input.PricingAdminFee)
+	input.PricingInterestRate = utils.SanitizeNumberFloat64(input.PricingInterestRate)
+
+	if !input.PricingMaxOTR.IsZero() && !input.PricingMaxOTR.GreaterThan(input.PricingMinOTR) {
+		return ErrInvalidOTRRange
+	}
+
+	product, err := u.productRepo.GetProductByID(input.PricingProductID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"pricing_product_id": input.PricingProductID,
+			"error":              err.Error(),
+		}).Warn("Pricing rule product not found")
+		return ErrPricingProductNotFound
+	}
+
+	if input.PricingTenor != 0 && product.FindTenor(float64(input.PricingTenor)) == nil {
+		utils.Logger.Warnf("Product %s does not offer tenor %d", product.ProductCode, input.PricingTenor)
+		return ErrTenorNotOffered
+	}
+
+	rule.PricingProductID = product.ProductID
+	rule.PricingTenor = input.PricingTenor
+	rule.PricingMinOTR = input.PricingMinOTR
+	rule.PricingMaxOTR = input.PricingMaxOTR
+	rule.PricingRiskGrade = input.PricingRiskGrade
+	rule.PricingAdminFee = input.PricingAdminFee
+	rule.PricingInterestRate = input.PricingInterestRate
+	rule.PricingPriority = input.PricingPriority
+	if input.PricingActive != nil {
+		rule.PricingActive = *input.PricingActive
+	}
+	return nil
+}
+
+func selectPricingRule(rules []domain.PricingRule, tenor int, otr money.Money, riskGrade string) *domain.PricingRule {
+	var selected *domain.PricingRule
+	for i := range rules {
+		rule := &rules[i]
+		if !rule.Matches(tenor, otr, riskGrade) {
+			continue
+		}
+		if selected == nil ||
+			rule.Specificity() > selected.Specificity() ||
+			(rule.Specificity() == selected.Specificity() && rule.PricingPriority > selected.PricingPriority) ||
+			(rule.Specificity() == selected.Specificity() && rule.PricingPriority == selected.PricingPriority && rule.PricingRuleID < selected.PricingRuleID) {
+			selected = rule
+		}
+	}
+	return selected
+}
+
+func applyPricing(tenor *domain.ProductTenor, rules []domain.PricingRule, riskGrade string, transaction *domain.Transaction, input domain.TransactionInput) {
+	transaction.TransactionAdminFee = tenor.TenorAdminFee
+	transaction.TransactionInterest = tenor.TenorInterestRate
+	transaction.TransactionPricingRuleID = nil
+	transaction.TransactionPricingOverride = false
+
+	if rule := selectPricingRule(rules, tenor.TenorMonths, transaction.TransactionOTR, riskGrade); rule != nil {
+		transaction.TransactionAdminFee = rule.PricingAdminFee
+		transaction.TransactionInterest = rule.PricingInterestRate
+		transaction.TransactionPricingRuleID = &rule.PricingRuleID
+	}
+
+	if input.TransactionAdminFee.IsPositive() {
+		transaction.TransactionAdminFee = input.TransactionAdminFee
+		transaction.TransactionPricingOverride = true
+	}
+	if input.TransactionInterest > 0 {
+		transaction.TransactionInterest = input.TransactionInterest
+		transaction.TransactionPricingOverride = true
+	}
+}
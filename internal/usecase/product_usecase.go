@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrProductAlreadyExists = errors.New("product code already exists")
+	ErrInvalidOTRRange      = errors.New("product maximum OTR must be greater than its minimum OTR")
+	ErrProductInactive      = errors.New("product is not active")
+	ErrTenorNotOffered      = errors.New("tenor is not offered")
+	ErrOTROutOfRange        = errors.New("OTR is outside the product range")
+)
+
+type ProductUsecase interface {
+	CreateProduct(userID uint, input domain.ProductInput) (*domain.Product, error)
+	GetAllProducts(limit, offset int) ([]domain.Product, error)
+	GetProductByID(id uint) (*domain.Product, error)
+	UpdateProduct(userID uint, product *domain.Product, input domain.ProductInput) error
+}
+
+type productUsecase struct {
+	productRepo repository.ProductRepository
+}
+
+func NewProductUsecase(productRepo repository.ProductRepository) ProductUsecase {
+	return &productUsecase{productRepo: productRepo}
+}
+
+func (u *productUsecase) CreateProduct(userID uint, input domain.ProductInput) (*domain.Product, error) {
+	product := &domain.Product{
+		ProductActive:    true,
+		ProductCreatedBy: userID,
+		ProductCreatedAt: time.Now(),
+	}
+	if err := applyProductInput(product, input); err != nil {
+		return nil, err
+	}
+
+	if err := u.productRepo.CreateProduct(product); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return nil, ErrProductAlreadyExists
+		}
+		utils.Logger.WithFields(logrus.Fields{
+			"product_code": product.ProductCode,
+			"error":        err.Error(),
+		}).Error("Failed to create product")
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":      userID,
+		"product_id":   product.ProductID,
+		"product_code": product.ProductCode,
+	}).Info("Product successfully created")
+
+	return product, nil
+}
+
+func (u *productUsecase) GetAllProducts(limit, offset int) ([]domain.Product, error) {
+	return u.productRepo.GetAllProducts(limit, offset)
+}
+
+func (u *productUsecase) GetProductByID(id uint) (*domain.Product, error) {
+	return u.productRepo.GetProductByID(id)
+}
+
+func (u *productUsecase) UpdateProduct(userID uint, product *domain.Product, input domain.ProductInput) error {
+	if err := applyProductInput(product, input); err != nil {
+		return err
+	}
+
+	timeNow := time.Now()
+	product.ProductEditedBy = &userID
+	product.ProductEditedAt = &timeNow
+
+	if err := u.productRepo.UpdateProduct(product); err != nil {
+		if repository.IsUniqueViolation(err) {
+			return ErrProductAlreadyExists
+		}
+		utils.Logger.WithFields(logrus.Fields{
+			"product_id": product.ProductID,
+			"error":      err.Error(),
+		}).Error("Failed to update product")
+		return err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":        userID,
+		"product_id":     product.ProductID,
+		"product_active": product.ProductActive,
+	}).Info("Product successfully updated")
+
+	return nil
+}
+
+func applyProductInput(product *domain.Product, input domain.ProductInput) error {
+	input.ProductMinOTR = utils.SanitizeMoney(input.ProductMinOTR)
+	input.ProductMaxOTR = utils.SanitizeMoney(input.ProductMaxOTR)
+	if !input.ProductMaxOTR.GreaterThan(input.ProductMinOTR) {
+		return ErrInvalidOTRRange
+	}
+
+	product.ProductCode = strings.ToLower(strings.TrimSpace(utils.SanitizeString(input.ProductCode)))
+	product.ProductName = utils.SanitizeString(input.ProductName)
+	product.ProductMinOTR = input.ProductMinOTR
+	product.ProductMaxOTR = input.ProductMaxOTR
+	if input.ProductActive != nil {
+		product.ProductActive = *input.ProductActive
+	}
+
+	product.ProductTenors = make([]domain.ProductTenor, 0, len(input.ProductTenors))
+	for _, tenor := range input.ProductTenors {
+		product.ProductTenors = append(product.ProductTenors, domain.ProductTenor{
+			TenorProductID:    product.ProductID,
+			TenorMonths:       utils.SanitizeNumberInt(tenor.TenorMonths),
+			TenorInterestRate: utils.SanitizeNumberFloat64(tenor.TenorInterestRate),
+			TenorAdminFee:     utils.SanitizeMoney(tenor.TenorAdminFee),
+		})
+	}
+	slices.SortFunc(product.ProductTenors, func(a, b domain.ProductTenor) int {
+		return a.TenorMonths - b.TenorMonths
+	})
+	return nil
+}
+
+func applyProductTerms(product *domain.Product, rules []domain.PricingRule, riskGrade string, transaction *domain.Transaction, input domain.TransactionInput) error {
+	if !product.ProductActive {
+		utils.Logger.Warnf("Product %s is not active", product.ProductCode)
+		return ErrProductInactive
+	}
+
+	tenor := product.FindTenor(transaction.TransactionInstallment)
+	if tenor == nil {
+		utils.Logger.Warnf("Product %s does not offer tenor %.0f", product.ProductCode, transaction.TransactionInstallment)
+		return ErrTenorNotOffered
+	}
+
+	applyPricing(tenor, rules, riskGrade, transaction, input)
+
+	if transaction.TransactionOTR.LessThan(product.ProductMinOTR) || transaction.TransactionOTR.GreaterThan(product.ProductMaxOTR) {
+		utils.Logger.WithFields(logrus.Fields{
+			"product_code":    product.ProductCode,
+			"product_min_otr": product.ProductMinOTR,
+			"product_max_otr": product.ProductMaxOTR,
+			"transaction_otr": transaction.TransactionOTR,
+		}).Warn("Transaction OTR outside product range")
+		return ErrOTROutOfRange
+	}
+
+	transaction.TransactionProductID = &product.ProductID
+	return nil
+}
+
+func ensureTenorOffered(productRepo repository.ProductRepository, tenor int) error {
+	tenors, err := productRepo.GetOfferedTenors()
+	if err != nil {
+		utils.Logger.WithError(err).Error("Failed to retrieve offered tenors")
+		return err
+	}
+
+	if !slices.Contains(tenors, tenor) {
+		utils.Logger.Warnf("Tenor %d is not offered by any active product", tenor)
+		return ErrTenorNotOffered
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrNegativeListBlocked      = errors.New("customer matches a blocking negative list entry")
+	ErrInvalidNegativeListValue = errors.New("negative list value is empty after normalization")
+	ErrNegativeListExpired      = errors.New("negative list expiry must be in the future")
+)
+
+type ScreeningError struct {
+	Result *domain.ScreeningResult
+}
+
+func (e *ScreeningError) Error() string {
+	return ErrNegativeListBlocked.Error()
+}
+
+func (e *ScreeningError) Unwrap() error {
+	return ErrNegativeListBlocked
+}
+
+type ScreeningUsecase interface {
+	CreateNegativeListEntry(userID uint, input domain.NegativeListInput) (*domain.NegativeListEntry, error)
+	GetAllNegativeListEntries(limit, offset int) ([]domain.NegativeListEntry, error)
+	GetNegativeListEntryByID(id uint) (*domain.NegativeListEntry, error)
+	UpdateNegativeListEntry(userID uint, entry *domain.NegativeListEntry, input domain.NegativeListInput) error
+	GetCustomerByID(id uint) (*domain.Customer, error)
+	ScreenCustomer(customer *domain.Customer) (*domain.ScreeningResult, error)
+}
+
+type screeningUsecase struct {
+	negativeListRepo repository.NegativeListRepository
+	customerRepo     repository.CustomerRepository
+}
+
+func NewScreeningUsecase(negativeListRepo repository.NegativeListRepository, customerRepo repository.CustomerRepository) ScreeningUsecase {
+	return &screeningUsecase{negativeListRepo: negativeListRepo, customerRepo: customerRepo}
+}
+
+func (u *screeningUsecase) CreateNegativeListEntry(userID uint, input domain.NegativeListInput) (*domain.NegativeListEntry, error) {
+	entry := &domain.NegativeListEntry{
+		NegativeListActive:    true,
+		NegativeListCreatedBy: userID,
+		NegativeListCreatedAt: time.Now(),
+	}
+	if err := applyNegativeListInput(entry, input); err != nil {
+		return nil, err
+	}
+
+	if err := u.negativeListRepo.CreateNegativeListEntry(entry); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"negative_list_type": entry.NegativeListType,
+			"error":              err.Error(),
+		}).Error("Failed to create negative list entry")
+		return nil, err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":                userID,
+		"negative_list_id":       entry.NegativeListID,
+		"negative_list_type":     entry.NegativeListType,
+		"negative_list_severity": entry.NegativeListSeverity,
+	}).Info("Negative list entry successfully created")
+
+	return entry, nil
+}
+
+func (u *screeningUsecase) GetAllNegativeListEntries(limit, offset int) ([]domain.NegativeListEntry, error) {
+	return u.negativeListRepo.GetAllNegativeListEntries(limit, offset)
+}
+
+func (u *screeningUsecase) GetNegativeListEntryByID(id uint) (*domain.NegativeListEntry, error) {
+	return u.negativeListRepo.GetNegativeListEntryByID(id)
+}
+
+func (u *screeningUsecase) UpdateNegativeListEntry(userID uint, entry *domain.NegativeListEntry, input domain.NegativeListInput) error {
+	if err := applyNegativeListInput(entry, input); err != nil {
+		return err
+	}
+
+	timeNow := time.Now()
+	entry.NegativeListEditedBy = &userID
+	entry.NegativeListEditedAt = &timeNow
+
+	if err := u.negativeListRepo.UpdateNegativeListEntry(entry); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"negative_list_id": entry.NegativeListID,
+			"error":            err.Error(),
+		}).Error("Failed to update negative list entry")
+		return err
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"user_id":              userID,
+		"negative_list_id":     entry.NegativeListID,
+		"negative_list_active": entry.NegativeListActive,
+	}).Info("Negative list entry successfully updated")
+
+	return nil
+}
+
+func (u *screeningUsecase) GetCustomerByID(id uint) (*domain.Customer, error) {
+	return u.customerRepo.GetCustomerByID(id)
+}
+
+func (u *screeningUsecase) ScreenCustomer(customer *domain.Customer) (*domain.ScreeningResult, error) {
+	return screenCustomer(u.negativeListRepo, customer)
+}
+
+func applyNegativeListInput(entry *domain.NegativeListEntry, input domain.NegativeListInput) error {
+	value := normalizeNegativeListValue(input.NegativeListType, input.NegativeListValue)
+	if value == "" {
+		return ErrInvalidNegativeListValue
+	}
+	if input.NegativeListExpiresAt != nil && !input.NegativeListExpiresAt.After(time.Now()) {
+		return ErrNegativeListExpired
+	}
+
+	entry.NegativeListType = input.NegativeListType
+	entry.NegativeListValue = value
+	entry.NegativeListReason = strings.TrimSpace(utils.SanitizeString(input.NegativeListReason))
+	entry.NegativeListSource = strings.TrimSpace(utils.SanitizeString(input.NegativeListSource))
+	entry.NegativeListSeverity = input.NegativeListSeverity
+	entry.NegativeListExpiresAt = input.NegativeListExpiresAt
+	if input.NegativeListActive != nil {
+		entry.NegativeListActive = *input.NegativeListActive
+	}
+	return nil
+}
+
+func normalizeNegativeListValue(listType, value string) string {
+	switch listType {
+	case domain.NegativeListTypeNIK:
+		return strings.TrimSpace(utils.SanitizeString(value))
+	case domain.NegativeListTypeName:
+		return strings.Join(normalizeName(value), " ")
+	case domain.NegativeListTypePhone:
+		return normalizePhone(value)
+	}
+	return ""
+}
+
+func screenCustomer(repo repository.NegativeListRepository, customer *domain.Customer) (*domain.ScreeningResult, error) {
+	now := time.Now()
+	result := &domain.ScreeningResult{
+		CustomerNIK: customer.CustomerNIK,
+		Decision:    domain.ScreeningDecisionClear,
+		Hits:        []domain.ScreeningHit{},
+		ScreenedAt:  now,
+	}
+
+	fields := map[string]string{}
+	var names, phones []string
+	for _, name := range []struct{ field, value string }{
+		{"customer_full_name", customer.CustomerFullName},
+		{"customer_legal_name", customer.CustomerLegalName},
+	} {
+		normalized := normalizeNegativeListValue(domain.NegativeListTypeName, name.value)
+		key := domain.NegativeListTypeName + ":" + normalized
+		if _, seen := fields[key]; normalized != "" && !seen {
+			fields[key] = name.field
+			names = append(names, normalized)
+		}
+	}
+	for _, phone := range customerPhoneNumbers(customer) {
+		fields[domain.NegativeListTypePhone+":"+phone] = "customer_phone"
+		phones = append(phones, phone)
+	}
+	fields[domain.NegativeListTypeNIK+":"+customer.CustomerNIK] = "customer_nik"
+
+	entries, err := repo.FindActiveMatches(customer.CustomerNIK, names, phones, now)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to screen customer against the negative list")
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		field, ok := fields[entry.NegativeListType+":"+entry.NegativeListValue]
+		if !ok || !entry.IsEffective(now) {
+			continue
+		}
+		result.Hits = append(result.Hits, domain.ScreeningHit{
+			NegativeListID: entry.NegativeListID,
+			MatchedField:   field,
+			MatchedValue:   entry.NegativeListValue,
+			Severity:       entry.NegativeListSeverity,
+			Reason:         entry.NegativeListReason,
+			Source:         entry.NegativeListSource,
+			ExpiresAt:      entry.NegativeListExpiresAt,
+		})
+
+		switch {
+		case entry.NegativeListSeverity == domain.NegativeListSeverityBlock:
+			result.Decision = domain.ScreeningDecisionBlock
+		case result.Decision == domain.ScreeningDecisionClear:
+			result.Decision = domain.ScreeningDecisionFlag
+		}
+	}
+	return result, nil
+}
+
+func enforceScreening(repo repository.NegativeListRepository, customer *domain.Customer, operation string) (*domain.ScreeningResult, error) {
+	result, err := screenCustomer(repo, customer)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := logrus.Fields{
+		"customer_nik": customer.CustomerNIK,
+		"operation":    operation,
+		"hits":         result.Hits,
+	}
+	switch result.Decision {
+	case domain.ScreeningDecisionBlock:
+		utils.Logger.WithFields(fields).Warn("Negative list screening blocked operation")
+		return result, &ScreeningError{Result: result}
+	case domain.ScreeningDecisionFlag:
+		utils.Logger.WithFields(fields).Warn("Negative list screening flagged operation")
+	}
+	return result, nil
+}
+
+func screeningReasons(result *domain.ScreeningResult) string {
+	reasons := make([]string, 0, len(result.Hits))
+	for _, hit := range result.Hits {
+		reasons = append(reasons, hit.MatchedField+": "+hit.Reason)
+	}
+	return strings.Join(reasons, "; ")
+}
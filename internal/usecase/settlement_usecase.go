@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"errors"
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository"
+	"kreditplus/internal/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidPayoffDate        = errors.New("payoff date is before the transaction date")
+	ErrSettlementAmountMismatch = errors.New("settlement amount does not match the payoff quote")
+)
+
+type SettlementUsecase interface {
+	GetPayoffQuote(transaction *domain.Transaction, asOf time.Time) (*domain.PayoffQuote, error)
+	SettleTransaction(userID uint, transaction *domain.Transaction, input domain.SettlementInput) (*domain.Payment, error)
+	GetTransactionByID(id uint) (*domain.Transaction, error)
+}
+
+type settlementUsecase struct {
+	paymentRepo      repository.PaymentRepository
+	installmentRepo  repository.InstallmentRepository
+	limitRepo        repository.LimitRepository
+	transactionRepo  repository.TransactionRepository
+	settlementConfig config.SettlementConfig
+}
+
+func NewSettlementUsecase(paymentRepo repository.PaymentRepository, installmentRepo repository.InstallmentRepository, limitRepo repository.LimitRepository, transactionRepo repository.TransactionRepository, settlementConfig config.SettlementConfig) SettlementUsecase {
+	return &settlementUsecase{paymentRepo: paymentRepo, installmentRepo: installmentRepo, limitRepo: limitRepo, transactionRepo: transactionRepo, settlementConfig: settlementConfig}
+}
+
+func (u *settlementUsecase) GetPayoffQuote(transaction *domain.Transaction, asOf time.Time) (*domain.PayoffQuote, error) {
+	if transaction.TransactionStatus != domain.TransactionStatusActive {
+		return nil, ErrTransactionNotActive
+	}
+
+	asOfDate := truncateToDate(asOf)
+	if asOfDate.Before(truncateToDate(transaction.TransactionDate)) {
+		return nil, ErrInvalidPayoffDate
+	}
+
+	installments, err := u.installmentRepo.GetInstallmentsByTransactionID(transaction.TransactionID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to retrieve installments for payoff quote")
+		return nil, err
+	}
+
+	var outstanding []domain.Installment
+	for _, installment := range installments {
+		if installment.InstallmentStatus == domain.InstallmentStatusUnpaid || installment.InstallmentStatus == domain.InstallmentStatusPartial {
+			outstanding = append(outstanding, installment)
+		}
+	}
+
+	if len(outstanding) == 0 {
+		return nil, ErrNoOutstandingInstallments
+	}
+
+	quote, _ := u.buildPayoffQuote(transaction, outstanding, installmentDueDates(installments), asOfDate)
+	return &quote, nil
+}
+
+func (u *settlementUsecase) SettleTransaction(userID uint, transaction *domain.Transaction, input domain.SettlementInput) (*domain.Payment, error) {
+	input.PaymentChannel = utils.SanitizeString(input.PaymentChannel)
+	input.PaymentAmount = utils.SanitizeMoney(input.PaymentAmount)
+
+	settledAt := time.Now()
+	payment := domain.Payment{
+		PaymentTransactionID: transaction.TransactionID,
+		PaymentAmount:        input.PaymentAmount,
+		PaymentChannel:       input.PaymentChannel,
+		PaymentPaidAt:        settledAt,
+		PaymentType:          domain.PaymentTypeSettlement,
+		PaymentCreatedBy:     userID,
+		PaymentCreatedAt:     settledAt,
+	}
+
+	pendingPayment := payment
+	originalTransaction := *transaction
+	err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		payment = pendingPayment
+		*transaction = originalTransaction
+		if err := lockTransactionWithTx(tx, u.transactionRepo, transaction); err != nil {
+			return err
+		}
+
+		if transaction.TransactionStatus != domain.TransactionStatusActive {
+			utils.Logger.Warnf("Settlement rejected for %s transaction %s", transaction.TransactionStatus, transaction.TransactionContractNumber)
+			return ErrTransactionNotActive
+		}
+
+		installments, err := u.installmentRepo.GetOutstandingInstallmentsWithTx(tx, transaction.TransactionID)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to retrieve outstanding installments")
+			return err
+		}
+
+		if len(installments) == 0 {
+			return ErrNoOutstandingInstallments
+		}
+
+		schedule, err := u.installmentRepo.GetInstallmentsByTransactionIDWithTx(tx, transaction.TransactionID)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to retrieve installment schedule")
+			return err
+		}
+
+		quote, chargedInterest := u.buildPayoffQuote(transaction, installments, installmentDueDates(schedule), truncateToDate(settledAt))
+		if input.PaymentAmount != quote.PayoffTotalAmount {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"payment_amount":              input.PaymentAmount,
+				"payoff_total_amount":         quote.PayoffTotalAmount,
+			}).Warn("Settlement amount does not match payoff quote")
+			return ErrSettlementAmountMismatch
+		}
+
+		for i := range installments {
+			installment := &installments[i]
+			installment.InstallmentPaidPrincipal = installment.InstallmentPrincipal
+			installment.InstallmentPaidInterest = installment.InstallmentPaidInterest.Add(chargedInterest[i])
+			installment.InstallmentPaidFee = installment.InstallmentFee
+			installment.InstallmentPaidPenalty = installment.InstallmentPenalty
+			installment.InstallmentStatus = domain.InstallmentStatusSettled
+			installment.InstallmentPaidAt = &settledAt
+
+			if err := u.installmentRepo.UpdateInstallmentWithTx(tx, installment); err != nil {
+				utils.Logger.WithFields(logrus.Fields{
+					"transaction_contract_number": transaction.TransactionContractNumber,
+					"installment_number":          installment.InstallmentNumber,
+					"error":                       err.Error(),
+				}).Error("Failed to settle installment")
+				return err
+			}
+		}
+
+		payment.PaymentPrincipalAmount = quote.PayoffOutstandingPrincipal
+		payment.PaymentInterestAmount = quote.PayoffAccruedInterest
+		payment.PaymentFeeAmount = quote.PayoffOutstandingFee
+		payment.PaymentPenaltyAmount = quote.PayoffOutstandingPenalty
+		payment.PaymentTerminationFee = quote.PayoffTerminationFee
+
+		if err := u.paymentRepo.CreatePaymentWithTx(tx, &payment); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to create settlement payment")
+			return err
+		}
+
+		interestAndFee, err := paidOffReleaseAmount(transaction)
+		if err != nil {
+			return err
+		}
+		releaseAmount := quote.PayoffOutstandingPrincipal.Add(interestAndFee)
+
+		transaction.TransactionStatus = domain.TransactionStatusPaidOff
+		transaction.TransactionDaysPastDue = 0
+		transaction.TransactionCollectibility = domain.CollectibilityCurrent
+		if err := recordTransactionStatusWithTx(tx, u.transactionRepo, userID, transaction, domain.TransactionStatusActive, "early settlement"); err != nil {
+			return err
+		}
+
+		limit := &domain.Limit{LimitID: transaction.TransactionLimit}
+		if err := releaseLimitWithTx(tx, u.limitRepo, userID, limit, &transaction.TransactionID, releaseAmount, "early settlement"); err != nil {
+			return err
+		}
+
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                     userID,
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"payment_amount":              payment.PaymentAmount,
+			"payment_termination_fee":     payment.PaymentTerminationFee,
+			"interest_waived":             quote.PayoffInterestWaived,
+			"limit_remaining_amount":      limit.LimitRemainingAmount,
+		}).Info("Transaction settled early and limit released")
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payment, nil
+}
+
+func (u *settlementUsecase) GetTransactionByID(id uint) (*domain.Transaction, error) {
+	return u.transactionRepo.GetTransactionByID(id)
+}
+
+func (u *settlementUsecase) buildPayoffQuote(transaction *domain.Transaction, installments []domain.Installment, dueDates map[int]time.Time, asOfDate time.Time) (domain.PayoffQuote, []money.Money) {
+	quote := domain.PayoffQuote{
+		PayoffTransactionID:  transaction.TransactionID,
+		PayoffContractNumber: transaction.TransactionContractNumber,
+		PayoffAsOf:           asOfDate,
+	}
+
+	chargedInterest := make([]money.Money, len(installments))
+	currentPeriodFound := false
+	for i, installment := range installments {
+		quote.PayoffOutstandingPrincipal = quote.PayoffOutstandingPrincipal.Add(installment.InstallmentPrincipal.Sub(installment.InstallmentPaidPrincipal))
+		quote.PayoffOutstandingFee = quote.PayoffOutstandingFee.Add(installment.InstallmentFee.Sub(installment.InstallmentPaidFee))
+		quote.PayoffOutstandingPenalty = quote.PayoffOutstandingPenalty.Add(installment.InstallmentPenalty.Sub(installment.InstallmentPaidPenalty))
+
+		unpaidInterest := installment.InstallmentInterest.Sub(installment.InstallmentPaidInterest)
+		dueDate := truncateToDate(installment.InstallmentDueDate)
+		if !dueDate.After(asOfDate) {
+			chargedInterest[i] = unpaidInterest
+			continue
+		}
+
+		quote.PayoffRemainingInstallments++
+		if !currentPeriodFound {
+			currentPeriodFound = true
+			periodStart := truncateToDate(transaction.TransactionDate)
+			if previousDueDate, ok := dueDates[installment.InstallmentNumber-1]; ok {
+				periodStart = truncateToDate(previousDueDate)
+			}
+			if periodDays := daysBetween(periodStart, dueDate); periodDays > 0 && asOfDate.After(periodStart) {
+				accrued := installment.InstallmentInterest.Mul(float64(daysBetween(periodStart, asOfDate)) / float64(periodDays)).RoundRupiah()
+				chargedInterest[i] = money.Max(money.Money{}, accrued.Sub(installment.InstallmentPaidInterest))
+			}
+		}
+		quote.PayoffInterestWaived = quote.PayoffInterestWaived.Add(unpaidInterest.Sub(chargedInterest[i]))
+	}
+
+	for _, interest := range chargedInterest {
+		quote.PayoffAccruedInterest = quote.PayoffAccruedInterest.Add(interest)
+	}
+
+	if quote.PayoffRemainingInstallments > u.settlementConfig.FeeWaivedWithinRemaining {
+		fee := quote.PayoffOutstandingPrincipal.Percent(u.settlementConfig.TerminationFeeRate).RoundRupiah()
+		quote.PayoffTerminationFee = money.Max(fee, u.settlementConfig.MinimumTerminationFee)
+	}
+
+	quote.PayoffTotalAmount = quote.PayoffOutstandingPrincipal.Add(quote.PayoffAccruedInterest).
+		Add(quote.PayoffOutstandingFee).Add(quote.PayoffOutstandingPenalty).Add(quote.PayoffTerminationFee)
+
+	return quote, chargedInterest
+}
+
+func installmentDueDates(installments []domain.Installment) map[int]time.Time {
+	dueDates := make(map[int]time.Time, len(installments))
+	for _, installment := range installments {
+		dueDates[installment.InstallmentNumber] = installment.InstallmentDueDate
+	}
+	return dueDates
+}
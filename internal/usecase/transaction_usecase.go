@@ -2,117 +2,380 @@ package usecase
 
 import (
 	"errors"
+	"fmt"
+	"kreditplus/config"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/financing"
+	"kreditplus/internal/money"
 	"kreditplus/internal/repository"
 	"kreditplus/internal/utils"
+	"math"
+	"slices"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/gorm"
 )
 
+var (
+	ErrTransactionHasPayments           = errors.New("transaction already has payments")
+	ErrTransactionNotEditable           = errors.New("transaction can no longer be edited")
+	ErrInvalidStatusTransition          = errors.New("invalid transaction status transition")
+	ErrTransactionHasOutstandingBalance = errors.New("transaction still has outstanding installments")
+	ErrInsufficientLimit                = errors.New("insufficient limit")
+	ErrCustomerLimitExceeded            = errors.New("customer limit exceeded")
+	ErrRestructureHasArrears            = errors.New("transaction has arrears that must be capitalised or paid before restructuring")
+	ErrInvalidRestructureTenor          = errors.New("restructured tenor must be longer than the remaining installments")
+	ErrAffordabilityExceeded            = errors.New("monthly installments exceed the customer's affordability limit")
+	ErrTransactionNIKMismatch           = errors.New("transaction NIK cannot be changed")
+)
+
+type AffordabilityError struct {
+	Rejection domain.AffordabilityRejection
+}
+
+func (e *AffordabilityError) Error() string {
+	return ErrAffordabilityExceeded.Error()
+}
+
+func (e *AffordabilityError) Unwrap() error {
+	return ErrAffordabilityExceeded
+}
+
+var transactionStatusTransitions = map[string][]string{
+	domain.TransactionStatusDraft: {
+		domain.TransactionStatusActive,
+		domain.TransactionStatusCancelled,
+	},
+	domain.TransactionStatusActive: {
+		domain.TransactionStatusPaidOff,
+		domain.TransactionStatusCancelled,
+		domain.TransactionStatusRestructured,
+		domain.TransactionStatusWrittenOff,
+	},
+}
+
 type TransactionUsecase interface {
-	CreateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, input domain.TransactionInput) error
+	CreateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, product *domain.Product, input domain.TransactionInput) error
+	SimulateTransaction(customer *domain.Customer, product *domain.Product, input domain.TransactionInput) ([]domain.TransactionSimulation, error)
 	GetAllTransactions(limit, offset int) ([]domain.Transaction, error)
 	GetTransactionByID(id uint) (*domain.Transaction, error)
+	GetTransactionSchedule(transactionID uint) ([]domain.Installment, error)
+	GetDelinquentTransactions(collectibility string, minDaysPastDue, limit, offset int) ([]domain.Transaction, error)
 	GetCustomerByNIK(nik string) (*domain.Customer, error)
-	UpdateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, transaction *domain.Transaction, input domain.TransactionInput) error
-	DeleteTransactionWithLimitUpdate(userID uint, transaction *domain.Transaction) error
+	GetProductByCode(code string) (*domain.Product, error)
+	GetTransactionStatusHistory(transactionID uint) ([]domain.TransactionStatusHistory, error)
+	UpdateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, product *domain.Product, transaction *domain.Transaction, input domain.TransactionInput) error
+	TransitionTransactionStatus(userID uint, transaction *domain.Transaction, toStatus string, reason string) error
+	RestructureTransaction(userID uint, transaction *domain.Transaction, input domain.TransactionRestructureInput) (*domain.Transaction, error)
+	GetTransactionVersions(transaction *domain.Transaction) ([]domain.Transaction, []domain.TransactionRestructure, error)
+	GetTransactionRetryMetrics() domain.TransactionRetryMetrics
 }
 
 type transactionUsecase struct {
-	customerRepo    repository.CustomerRepository
-	limitRepo       repository.LimitRepository
-	transactionRepo repository.TransactionRepository
+	customerRepo        repository.CustomerRepository
+	limitRepo           repository.LimitRepository
+	transactionRepo     repository.TransactionRepository
+	installmentRepo     repository.InstallmentRepository
+	productRepo         repository.ProductRepository
+	pricingRuleRepo     repository.PricingRuleRepository
+	negativeListRepo    repository.NegativeListRepository
+	affordabilityConfig config.AffordabilityConfig
 }
 
-func NewTransactionUsecase(customerRepo repository.CustomerRepository, limitRepo repository.LimitRepository, transactionRepo repository.TransactionRepository) TransactionUsecase {
-	return &transactionUsecase{customerRepo: customerRepo, limitRepo: limitRepo, transactionRepo: transactionRepo}
+func NewTransactionUsecase(customerRepo repository.CustomerRepository, limitRepo repository.LimitRepository, transactionRepo repository.TransactionRepository, installmentRepo repository.InstallmentRepository, productRepo repository.ProductRepository, pricingRuleRepo repository.PricingRuleRepository, negativeListRepo repository.NegativeListRepository, affordabilityConfig config.AffordabilityConfig) TransactionUsecase {
+	return &transactionUsecase{customerRepo: customerRepo, limitRepo: limitRepo, transactionRepo: transactionRepo, installmentRepo: installmentRepo, productRepo: productRepo, pricingRuleRepo: pricingRuleRepo, negativeListRepo: negativeListRepo, affordabilityConfig: affordabilityConfig}
 }
 
-func (u *transactionUsecase) CreateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, input domain.TransactionInput) error {
+func (u *transactionUsecase) CreateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, product *domain.Product, input domain.TransactionInput) error {
 	input.TransactionNIK = utils.SanitizeString(input.TransactionNIK)
 	input.TransactionAssetName = utils.SanitizeString(input.TransactionAssetName)
+	input.TransactionStatus = utils.SanitizeString(input.TransactionStatus)
+	input.TransactionScheme = utils.SanitizeString(input.TransactionScheme)
 
-	input.TransactionOTR = utils.SanitizeNumberFloat64(input.TransactionOTR)
-	input.TransactionAdminFee = utils.SanitizeNumberFloat64(input.TransactionAdminFee)
+	input.TransactionOTR = utils.SanitizeMoney(input.TransactionOTR)
+	input.TransactionAdminFee = utils.SanitizeMoney(input.TransactionAdminFee)
 	input.TransactionInstallment = utils.SanitizeNumberFloat64(input.TransactionInstallment)
 	input.TransactionInterest = utils.SanitizeNumberFloat64(input.TransactionInterest)
 
-	const maxRetries = 3
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
-			limit, err := u.limitRepo.GetLimitByNIKandTenorWithTx(tx, input.TransactionNIK, input.TransactionInstallment)
-			if err != nil {
-				utils.Logger.WithFields(logrus.Fields{
-					"transaction_nik": input.TransactionNIK,
-					"error":           err.Error(),
-				}).Warn("Failed to retrieve limit")
-				return err
-			}
+	if input.TransactionStatus == "" {
+		input.TransactionStatus = domain.TransactionStatusActive
+	}
+	if input.TransactionScheme == "" {
+		input.TransactionScheme = financing.SchemeFlat
+	}
 
-			totalAmount := input.TransactionOTR + input.TransactionAdminFee +
-				(input.TransactionInterest * input.TransactionOTR * float64(input.TransactionInstallment) / 100)
+	if err := ensureCustomerVerified(customer); err != nil {
+		return err
+	}
 
-			if totalAmount > limit.LimitRemainingAmount {
-				utils.Logger.Warnf("Insufficient limit for NIK %s", input.TransactionNIK)
-				return errors.New("insufficient limit")
-			}
+	if _, err := enforceScreening(u.negativeListRepo, customer, "transaction_create"); err != nil {
+		return err
+	}
 
-			transaction := domain.Transaction{
-				TransactionContractNumber: utils.GenerateContractNumber(),
-				TransactionNIK:            customer.CustomerNIK,
-				TransactionLimit:          limit.LimitID,
-				TransactionOTR:            input.TransactionOTR,
-				TransactionAdminFee:       input.TransactionAdminFee,
-				TransactionInstallment:    input.TransactionInstallment,
-				TransactionInterest:       input.TransactionInterest,
-				TransactionAssetName:      input.TransactionAssetName,
-				TransactionDate:           time.Now(),
-				TransactionCreatedBy:      userID,
-				TransactionCreatedAt:      time.Now(),
-			}
+	rules, err := u.getPricingRules(product)
+	if err != nil {
+		return err
+	}
 
-			if err := u.transactionRepo.CreateTransactionWithTx(tx, &transaction); err != nil {
-				utils.Logger.WithFields(logrus.Fields{
-					"transaction_nik": transaction.TransactionNIK,
-					"error":           err.Error(),
-				}).Error("Failed to create transaction")
-				return err
-			}
+	return u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		limit, err := u.limitRepo.GetLimitByNIKandTenor(input.TransactionNIK, input.TransactionInstallment)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_nik": input.TransactionNIK,
+				"error":           err.Error(),
+			}).Warn("Failed to retrieve limit")
+			return err
+		}
 
-			limit.LimitUsedAmount += totalAmount
-			limit.LimitRemainingAmount -= totalAmount
+		transaction := domain.Transaction{
+			TransactionContractNumber: utils.GenerateContractNumber(),
+			TransactionNIK:            customer.CustomerNIK,
+			TransactionLimit:          limit.LimitID,
+			TransactionOTR:            input.TransactionOTR,
+			TransactionAdminFee:       input.TransactionAdminFee,
+			TransactionInstallment:    input.TransactionInstallment,
+			TransactionInterest:       input.TransactionInterest,
+			TransactionAssetName:      input.TransactionAssetName,
+			TransactionCollectibility: domain.CollectibilityCurrent,
+			TransactionStatus:         input.TransactionStatus,
+			TransactionScheme:         input.TransactionScheme,
+			TransactionVersion:        1,
+			TransactionDate:           time.Now(),
+			TransactionCreatedBy:      userID,
+			TransactionCreatedAt:      time.Now(),
+		}
 
-			if err := u.limitRepo.UpdateLimitWithTx(tx, limit); err != nil {
-				utils.Logger.WithFields(logrus.Fields{
-					"transaction_nik": transaction.TransactionNIK,
-					"error":           err.Error(),
-				}).Error("Failed to update limit")
+		transaction.TransactionOriginalNumber = transaction.TransactionContractNumber
+
+		if err := applyProductTerms(product, rules, customer.CustomerRiskGrade, &transaction, input); err != nil {
+			return err
+		}
+
+		calculation, err := calculateFinancing(&transaction)
+		if err != nil {
+			return err
+		}
+		totalAmount := calculation.TotalAmount
+
+		if transaction.TransactionStatus == domain.TransactionStatusActive && totalAmount.GreaterThan(limit.LimitRemainingAmount) {
+			utils.Logger.Warnf("Insufficient limit for NIK %s", input.TransactionNIK)
+			return ErrInsufficientLimit
+		}
+
+		if transaction.TransactionStatus == domain.TransactionStatusActive {
+			if err := u.checkAffordabilityWithTx(tx, customer, 0, calculation.MonthlyPayment); err != nil {
 				return err
 			}
+		}
 
+		if err := u.transactionRepo.CreateTransactionWithTx(tx, &transaction); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_nik": transaction.TransactionNIK,
+				"error":           err.Error(),
+			}).Error("Failed to create transaction")
+			return err
+		}
+
+		if err := u.transactionRepo.CreateTransactionStatusHistoryWithTx(tx, &domain.TransactionStatusHistory{
+			HistoryTransactionID: transaction.TransactionID,
+			HistoryToStatus:      transaction.TransactionStatus,
+			HistoryChangedBy:     userID,
+			HistoryChangedAt:     transaction.TransactionCreatedAt,
+		}); err != nil {
 			utils.Logger.WithFields(logrus.Fields{
-				"user_id":                     userID,
 				"transaction_contract_number": transaction.TransactionContractNumber,
-				"limit_remaining_amount":      limit.LimitRemainingAmount,
-				"updated_at":                  time.Now(),
-			}).Info("Transaction successfully created and limit updated")
+				"error":                       err.Error(),
+			}).Error("Failed to record transaction status history")
+			return err
+		}
 
+		if transaction.TransactionStatus == domain.TransactionStatusDraft {
+			utils.Logger.WithFields(logrus.Fields{
+				"user_id":                     userID,
+				"transaction_contract_number": transaction.TransactionContractNumber,
+			}).Info("Draft transaction successfully created")
 			return nil
+		}
+
+		if err := u.installmentRepo.CreateInstallmentsWithTx(tx, generateInstallmentSchedule(&transaction, calculation)); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to create installment schedule")
+			return err
+		}
+
+		if err := consumeLimitWithTx(tx, u.limitRepo, userID, limit, &transaction.TransactionID, totalAmount, "transaction created"); err != nil {
+			return err
+		}
+
+		if err := checkCustomerLimitWithTx(tx, u.limitRepo, transaction.TransactionNIK); err != nil {
+			return err
+		}
+
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                     userID,
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"limit_remaining_amount":      limit.LimitRemainingAmount,
+			"updated_at":                  time.Now(),
+		}).Info("Transaction successfully created and limit updated")
+
+		return nil
+	})
+}
+
+func (u *transactionUsecase) SimulateTransaction(customer *domain.Customer, product *domain.Product, input domain.TransactionInput) ([]domain.TransactionSimulation, error) {
+	input.TransactionNIK = utils.SanitizeString(input.TransactionNIK)
+	input.TransactionScheme = utils.SanitizeString(input.TransactionScheme)
+
+	input.TransactionOTR = utils.SanitizeMoney(input.TransactionOTR)
+	input.TransactionAdminFee = utils.SanitizeMoney(input.TransactionAdminFee)
+	input.TransactionInstallment = utils.SanitizeNumberFloat64(input.TransactionInstallment)
+	input.TransactionInterest = utils.SanitizeNumberFloat64(input.TransactionInterest)
+
+	if input.TransactionScheme == "" {
+		input.TransactionScheme = financing.SchemeFlat
+	}
+
+	if !product.ProductActive {
+		utils.Logger.Warnf("Product %s is not active", product.ProductCode)
+		return nil, ErrProductInactive
+	}
+
+	rules, err := u.getPricingRules(product)
+	if err != nil {
+		return nil, err
+	}
+
+	umbrellaRemaining, hasUmbrella, err := u.customerLimitRemaining(input.TransactionNIK)
+	if err != nil {
+		return nil, err
+	}
+
+	tenors := make([]int, 0, len(product.ProductTenors)+1)
+	for _, productTenor := range product.ProductTenors {
+		tenors = append(tenors, productTenor.TenorMonths)
+	}
+	if !slices.Contains(tenors, int(input.TransactionInstallment)) {
+		tenors = append(tenors, int(input.TransactionInstallment))
+		slices.Sort(tenors)
+	}
+
+	simulations := make([]domain.TransactionSimulation, 0, len(tenors))
+	for _, tenor := range tenors {
+		transaction := domain.Transaction{
+			TransactionOTR:         input.TransactionOTR,
+			TransactionAdminFee:    input.TransactionAdminFee,
+			TransactionInstallment: float64(tenor),
+			TransactionInterest:    input.TransactionInterest,
+		}
+		termsErr := applyProductTerms(product, rules, customer.CustomerRiskGrade, &transaction, input)
+		if errors.Is(termsErr, ErrTenorNotOffered) {
+			simulations = append(simulations, domain.TransactionSimulation{
+				SimulationTenor:     tenor,
+				SimulationScheme:    input.TransactionScheme,
+				SimulationRequested: tenor == int(input.TransactionInstallment),
+				SimulationReason:    termsErr.Error(),
+			})
+			continue
+		}
+
+		calculation, err := financing.Calculate(input.TransactionScheme, financing.Input{
+			Principal:    transaction.TransactionOTR,
+			AdminFee:     transaction.TransactionAdminFee,
+			InterestRate: transaction.TransactionInterest,
+			Tenor:        tenor,
+			StartDate:    time.Now(),
 		})
+		if err != nil {
+			return nil, err
+		}
+
+		simulation := domain.TransactionSimulation{
+			SimulationTenor:              tenor,
+			SimulationScheme:             calculation.Scheme,
+			SimulationRequested:          tenor == int(input.TransactionInstallment),
+			SimulationPrincipal:          calculation.Principal,
+			SimulationTotalInterest:      calculation.TotalInterest,
+			SimulationTotalFee:           calculation.TotalFee,
+			SimulationTotalPayable:       calculation.TotalAmount,
+			SimulationMonthlyInstallment: calculation.MonthlyPayment,
+		}
 
+		limit, err := u.limitRepo.GetLimitByNIKandTenor(input.TransactionNIK, float64(tenor))
 		if err != nil {
-			if err.Error() == "database is locked" || err.Error() == "deadlock detected" {
-				utils.Logger.Warnf("Deadlock detected. Retrying transaction %d/%d", attempt+1, maxRetries)
-				time.Sleep(time.Millisecond * 100)
-				continue
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_nik": input.TransactionNIK,
+				"tenor":           tenor,
+				"error":           err.Error(),
+			}).Warn("Failed to retrieve limit")
+			return nil, err
+		}
+
+		if termsErr != nil {
+			simulation.SimulationReason = termsErr.Error()
+		} else if limit.LimitID == 0 {
+			simulation.SimulationReason = "no limit for this tenor"
+		} else {
+			available := limit.LimitRemainingAmount
+			if hasUmbrella {
+				available = money.Max(money.Min(available, umbrellaRemaining), money.Money{})
+			}
+
+			simulation.SimulationLimitID = limit.LimitID
+			simulation.SimulationLimitRemainingAmount = available
+			simulation.SimulationRemainingAfter = available.Sub(calculation.TotalAmount)
+			simulation.SimulationEligible = !calculation.TotalAmount.GreaterThan(available)
+			switch {
+			case simulation.SimulationEligible:
+			case calculation.TotalAmount.GreaterThan(limit.LimitRemainingAmount):
+				simulation.SimulationReason = "insufficient limit"
+			default:
+				simulation.SimulationReason = "exceeds customer limit"
 			}
-			return err
 		}
-		return nil
+
+		simulations = append(simulations, simulation)
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"transaction_nik": input.TransactionNIK,
+		"product_code":    product.ProductCode,
+		"transaction_otr": input.TransactionOTR,
+		"tenors":          tenors,
+	}).Info("Transaction simulated")
+
+	return simulations, nil
+}
+
+func (u *transactionUsecase) customerLimitRemaining(nik string) (money.Money, bool, error) {
+	customerLimit, err := u.limitRepo.GetCustomerLimitByNIK(nik)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": nik,
+			"error":        err.Error(),
+		}).Warn("Failed to retrieve customer limit")
+		return money.Money{}, false, err
+	}
+	if customerLimit.CustomerLimitID == 0 {
+		return money.Money{}, false, nil
+	}
+
+	limits, err := u.limitRepo.GetLimitsByNIK(nik)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": nik,
+			"error":        err.Error(),
+		}).Warn("Failed to retrieve customer sub-limits")
+		return money.Money{}, false, err
 	}
-	return errors.New("failed to process transaction after multiple retries")
+
+	remaining := customerLimit.CustomerLimitAmount
+	for _, limit := range limits {
+		remaining = remaining.Sub(limit.LimitUsedAmount)
+	}
+	return remaining, true, nil
 }
 
 func (u *transactionUsecase) GetAllTransactions(limit, offset int) ([]domain.Transaction, error) {
@@ -123,63 +386,91 @@ func (u *transactionUsecase) GetTransactionByID(id uint) (*domain.Transaction, e
 	return u.transactionRepo.GetTransactionByID(id)
 }
 
+func (u *transactionUsecase) GetTransactionSchedule(transactionID uint) ([]domain.Installment, error) {
+	return u.installmentRepo.GetInstallmentsByTransactionID(transactionID)
+}
+
+func (u *transactionUsecase) GetDelinquentTransactions(collectibility string, minDaysPastDue, limit, offset int) ([]domain.Transaction, error) {
+	return u.transactionRepo.GetDelinquentTransactions(collectibility, minDaysPastDue, limit, offset)
+}
+
 func (u *transactionUsecase) GetCustomerByNIK(nik string) (*domain.Customer, error) {
 	return u.customerRepo.GetCustomerByNIK(nik)
 }
 
-func (u *transactionUsecase) UpdateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, transaction *domain.Transaction, input domain.TransactionInput) error {
+func (u *transactionUsecase) GetProductByCode(code string) (*domain.Product, error) {
+	return u.productRepo.GetProductByCode(code)
+}
+
+func (u *transactionUsecase) GetTransactionRetryMetrics() domain.TransactionRetryMetrics {
+	return u.transactionRepo.GetRetryMetrics()
+}
+
+func (u *transactionUsecase) GetTransactionStatusHistory(transactionID uint) ([]domain.TransactionStatusHistory, error) {
+	return u.transactionRepo.GetTransactionStatusHistory(transactionID)
+}
+
+func (u *transactionUsecase) UpdateTransactionWithLimitUpdate(userID uint, customer *domain.Customer, product *domain.Product, transaction *domain.Transaction, input domain.TransactionInput) error {
 	input.TransactionNIK = utils.SanitizeString(input.TransactionNIK)
 	input.TransactionAssetName = utils.SanitizeString(input.TransactionAssetName)
 
-	input.TransactionOTR = utils.SanitizeNumberFloat64(input.TransactionOTR)
-	input.TransactionAdminFee = utils.SanitizeNumberFloat64(input.TransactionAdminFee)
+	input.TransactionOTR = utils.SanitizeMoney(input.TransactionOTR)
+	input.TransactionAdminFee = utils.SanitizeMoney(input.TransactionAdminFee)
 	input.TransactionInstallment = utils.SanitizeNumberFloat64(input.TransactionInstallment)
 	input.TransactionInterest = utils.SanitizeNumberFloat64(input.TransactionInterest)
 
-	const maxRetries = 3
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
-			timeNow := time.Now()
+	rules, err := u.getPricingRules(product)
+	if err != nil {
+		return err
+	}
+
+	original := *transaction
+	return u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		*transaction = original
+		if err := lockTransactionWithTx(tx, u.transactionRepo, transaction); err != nil {
+			return err
+		}
+		timeNow := time.Now()
 
-			limit, err := u.limitRepo.GetLimitByNIKandTenorWithTx(tx, input.TransactionNIK, input.TransactionInstallment)
-			if err != nil {
-				utils.Logger.WithFields(logrus.Fields{
-					"transaction_nik": input.TransactionNIK,
-					"error":           err.Error(),
-				}).Warn("Failed to retrieve limit")
-				return err
-			}
+		if transaction.TransactionStatus != domain.TransactionStatusActive && transaction.TransactionStatus != domain.TransactionStatusDraft {
+			utils.Logger.Warnf("Transaction %s is %s and can no longer be edited", transaction.TransactionContractNumber, transaction.TransactionStatus)
+			return ErrTransactionNotEditable
+		}
 
-			originalAmount := transaction.TransactionOTR + transaction.TransactionAdminFee +
-				(transaction.TransactionInterest * transaction.TransactionOTR * float64(transaction.TransactionInstallment) / 100)
+		if input.TransactionNIK != transaction.TransactionNIK {
+			utils.Logger.Warnf("Transaction %s amendment carries a different NIK", transaction.TransactionContractNumber)
+			return ErrTransactionNIKMismatch
+		}
 
-			newAmount := input.TransactionOTR + input.TransactionAdminFee +
-				(input.TransactionInterest * input.TransactionOTR * float64(input.TransactionInstallment) / 100)
+		if err := u.ensureNoPaymentsWithTx(tx, transaction); err != nil {
+			return err
+		}
 
-			limit.LimitUsedAmount -= originalAmount
-			limit.LimitRemainingAmount += originalAmount
+		tenor := input.TransactionInstallment
+		if tenor <= 0 {
+			tenor = transaction.TransactionInstallment
+		}
 
-			if newAmount > limit.LimitRemainingAmount {
-				utils.Logger.Warnf("Insufficient limit for NIK %s", input.TransactionNIK)
-				return errors.New("insufficient limit")
-			}
+		limit, err := u.limitRepo.GetLimitByNIKandTenor(transaction.TransactionNIK, tenor)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_nik": transaction.TransactionNIK,
+				"error":           err.Error(),
+			}).Warn("Failed to retrieve limit")
+			return err
+		}
 
-			if input.TransactionOTR > 0 {
-				transaction.TransactionOTR = input.TransactionOTR
-			}
-			if input.TransactionAdminFee > 0 {
-				transaction.TransactionAdminFee = input.TransactionAdminFee
-			}
-			if input.TransactionInstallment > 0 {
-				transaction.TransactionInstallment = input.TransactionInstallment
-			}
-			if input.TransactionInterest > 0 {
-				transaction.TransactionInterest = input.TransactionInterest
-			}
-			if input.TransactionAssetName != "" {
-				transaction.TransactionAssetName = input.TransactionAssetName
-			}
+		if limit.LimitID == 0 {
+			utils.Logger.Warnf("No limit for NIK %s on tenor %.0f", transaction.TransactionNIK, tenor)
+			return ErrInsufficientLimit
+		}
 
+		if transaction.TransactionStatus == domain.TransactionStatusDraft {
+			applyTransactionInput(transaction, input)
+			if err := applyProductTerms(product, rules, customer.CustomerRiskGrade, transaction, input); err != nil {
+				return err
+			}
+			transaction.TransactionLimit = limit.LimitID
 			transaction.TransactionEditedBy = &userID
 			transaction.TransactionEditedAt = &timeNow
 
@@ -187,92 +478,672 @@ func (u *transactionUsecase) UpdateTransactionWithLimitUpdate(userID uint, custo
 				utils.Logger.WithFields(logrus.Fields{
 					"transaction_nik": transaction.TransactionNIK,
 					"error":           err.Error(),
-				}).Error("Failed to update transaction")
+				}).Error("Failed to update draft transaction")
 				return err
 			}
+			return nil
+		}
 
-			limit.LimitUsedAmount += newAmount
-			limit.LimitRemainingAmount -= newAmount
+		originalCalculation, err := calculateFinancing(transaction)
+		if err != nil {
+			return err
+		}
 
-			if err := u.limitRepo.UpdateLimitWithTx(tx, limit); err != nil {
-				utils.Logger.WithFields(logrus.Fields{
-					"transaction_nik": transaction.TransactionNIK,
-					"error":           err.Error(),
-				}).Error("Failed to update limit")
-				return err
-			}
+		updated := *transaction
+		applyTransactionInput(&updated, input)
+		if err := applyProductTerms(product, rules, customer.CustomerRiskGrade, &updated, input); err != nil {
+			return err
+		}
+
+		calculation, err := calculateFinancing(&updated)
+		if err != nil {
+			return err
+		}
+		newAmount := calculation.TotalAmount
+
+		if err := u.checkAffordabilityWithTx(tx, customer, transaction.TransactionID, calculation.MonthlyPayment); err != nil {
+			return err
+		}
+
+		oldLimit := &domain.Limit{LimitID: transaction.TransactionLimit}
+		if err := releaseLimitWithTx(tx, u.limitRepo, userID, oldLimit, &transaction.TransactionID, originalCalculation.TotalAmount, "transaction amended"); err != nil {
+			return err
+		}
+
+		if err := consumeLimitWithTx(tx, u.limitRepo, userID, limit, &transaction.TransactionID, newAmount, "transaction amended"); err != nil {
+			return err
+		}
+
+		if err := checkCustomerLimitWithTx(tx, u.limitRepo, transaction.TransactionNIK); err != nil {
+			return err
+		}
 
+		*transaction = updated
+		transaction.TransactionLimit = limit.LimitID
+		transaction.TransactionEditedBy = &userID
+		transaction.TransactionEditedAt = &timeNow
+
+		if err := u.transactionRepo.UpdateTransactionWithTx(tx, transaction); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_nik": transaction.TransactionNIK,
+				"error":           err.Error(),
+			}).Error("Failed to update transaction")
+			return err
+		}
+
+		if err := u.installmentRepo.VoidInstallmentsByTransactionIDWithTx(tx, transaction.TransactionID); err != nil {
 			utils.Logger.WithFields(logrus.Fields{
-				"user_id":                     userID,
 				"transaction_contract_number": transaction.TransactionContractNumber,
-				"limit_remaining_amount":      limit.LimitRemainingAmount,
-				"updated_at":                  time.Now(),
-			}).Info("Transaction successfully updated and limit updated")
+				"error":                       err.Error(),
+			}).Error("Failed to void installment schedule")
+			return err
+		}
 
-			return nil
-		})
+		if err := u.installmentRepo.CreateInstallmentsWithTx(tx, generateInstallmentSchedule(transaction, calculation)); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to regenerate installment schedule")
+			return err
+		}
 
-		if err != nil && (err.Error() == "database is locked" || err.Error() == "deadlock detected") {
-			utils.Logger.Warnf("Deadlock detected. Retrying update transaction %d/%d", attempt+1, maxRetries)
-			time.Sleep(time.Millisecond * 100)
-			continue
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                     userID,
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"previous_limit_id":           oldLimit.LimitID,
+			"limit_id":                    limit.LimitID,
+			"limit_remaining_amount":      limit.LimitRemainingAmount,
+			"updated_at":                  time.Now(),
+		}).Info("Transaction successfully updated and limit updated")
+
+		return nil
+	})
+}
+
+func (u *transactionUsecase) TransitionTransactionStatus(userID uint, transaction *domain.Transaction, toStatus string, reason string) error {
+	reason = utils.SanitizeString(reason)
+
+	original := *transaction
+	return u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		*transaction = original
+		if err := lockTransactionWithTx(tx, u.transactionRepo, transaction); err != nil {
+			return err
 		}
-		return err
-	}
-	return errors.New("failed to update transaction after multiple retries")
+
+		fromStatus := transaction.TransactionStatus
+		if !slices.Contains(transactionStatusTransitions[fromStatus], toStatus) {
+			utils.Logger.Warnf("Transaction %s cannot move from %s to %s", transaction.TransactionContractNumber, fromStatus, toStatus)
+			return ErrInvalidStatusTransition
+		}
+
+		var err error
+		switch toStatus {
+		case domain.TransactionStatusActive:
+			err = u.activateTransactionWithTx(tx, userID, transaction)
+		case domain.TransactionStatusCancelled:
+			if fromStatus == domain.TransactionStatusActive {
+				err = u.cancelActiveTransactionWithTx(tx, userID, transaction)
+			}
+		case domain.TransactionStatusPaidOff:
+			err = u.payOffTransactionWithTx(tx, userID, transaction)
+		case domain.TransactionStatusWrittenOff:
+			utils.Logger.Warnf("Transaction %s written off, limit stays consumed", transaction.TransactionContractNumber)
+		default:
+			utils.Logger.Warnf("Transaction %s cannot be moved to %s directly", transaction.TransactionContractNumber, toStatus)
+			return ErrInvalidStatusTransition
+		}
+		if err != nil {
+			return err
+		}
+
+		transaction.TransactionStatus = toStatus
+		if err := recordTransactionStatusWithTx(tx, u.transactionRepo, userID, transaction, fromStatus, reason); err != nil {
+			return err
+		}
+
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                     userID,
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"from_status":                 fromStatus,
+			"to_status":                   toStatus,
+			"reason":                      reason,
+		}).Info("Transaction status successfully changed")
+
+		return nil
+	})
 }
 
-func (u *transactionUsecase) DeleteTransactionWithLimitUpdate(userID uint, transaction *domain.Transaction) error {
-	const maxRetries = 3
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
-			limit, err := u.limitRepo.GetLimitByIDWithTx(tx, transaction.TransactionLimit)
+func (u *transactionUsecase) RestructureTransaction(userID uint, transaction *domain.Transaction, input domain.TransactionRestructureInput) (*domain.Transaction, error) {
+	input.RestructureType = utils.SanitizeString(input.RestructureType)
+	input.RestructureReason = utils.SanitizeString(input.RestructureReason)
+	input.RestructureTenor = utils.SanitizeNumberFloat64(input.RestructureTenor)
+	input.RestructureInterest = utils.SanitizeNumberFloat64(input.RestructureInterest)
+
+	if input.RestructureType != domain.RestructureTypePaymentHoliday {
+		input.RestructureHolidayMonths = 0
+	}
+
+	var restructured domain.Transaction
+	original := *transaction
+	err := u.transactionRepo.WithTransaction(func(tx *gorm.DB) error {
+		*transaction = original
+		if err := lockTransactionWithTx(tx, u.transactionRepo, transaction); err != nil {
+			return err
+		}
+
+		if transaction.TransactionStatus != domain.TransactionStatusActive {
+			utils.Logger.Warnf("Restructure rejected for %s transaction %s", transaction.TransactionStatus, transaction.TransactionContractNumber)
+			return ErrTransactionNotActive
+		}
+
+		originalNumber := transaction.TransactionOriginalNumber
+		if originalNumber == "" {
+			originalNumber = transaction.TransactionContractNumber
+		}
+		version := max(transaction.TransactionVersion, 1) + 1
+
+		installments, err := u.installmentRepo.GetOutstandingInstallmentsWithTx(tx, transaction.TransactionID)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to retrieve outstanding installments")
+			return err
+		}
+
+		if len(installments) == 0 {
+			return ErrNoOutstandingInstallments
+		}
+
+		today := truncateToDate(time.Now())
+		var outstandingPrincipal, arrears money.Money
+		for _, installment := range installments {
+			principal := installment.InstallmentPrincipal.Sub(installment.InstallmentPaidPrincipal)
+			outstandingPrincipal = outstandingPrincipal.Add(principal)
+			if !truncateToDate(installment.InstallmentDueDate).After(today) {
+				arrears = arrears.Add(installmentOutstanding(installment).Sub(principal))
+			}
+		}
+
+		if arrears.IsPositive() && input.RestructureType != domain.RestructureTypeCapitaliseArrears {
+			utils.Logger.Warnf("Transaction %s has %s in arrears", transaction.TransactionContractNumber, arrears)
+			return ErrRestructureHasArrears
+		}
+
+		if input.RestructureType == domain.RestructureTypeExtendTenor && int(input.RestructureTenor) <= len(installments) {
+			return ErrInvalidRestructureTenor
+		}
+
+		interestAndFee, err := paidOffReleaseAmount(transaction)
+		if err != nil {
+			return err
+		}
+
+		oldLimit := &domain.Limit{LimitID: transaction.TransactionLimit}
+		if err := releaseLimitWithTx(tx, u.limitRepo, userID, oldLimit, &transaction.TransactionID, outstandingPrincipal.Add(interestAndFee), "transaction restructured"); err != nil {
+			return err
+		}
+
+		newLimit := oldLimit
+		if int(input.RestructureTenor) != oldLimit.LimitTenor {
+			newLimit, err = u.limitRepo.GetLimitByNIKandTenor(transaction.TransactionNIK, input.RestructureTenor)
 			if err != nil {
 				utils.Logger.WithFields(logrus.Fields{
-					"transaction_limit_id": transaction.TransactionLimit,
-					"error":                err.Error(),
-				}).Warn("Failed to retrieve associated limit record")
+					"transaction_nik": transaction.TransactionNIK,
+					"error":           err.Error(),
+				}).Warn("Failed to retrieve limit")
 				return err
 			}
+		}
+
+		var capitalised money.Money
+		if input.RestructureType == domain.RestructureTypeCapitaliseArrears {
+			capitalised = arrears
+		}
 
-			originalAmount := transaction.TransactionOTR + transaction.TransactionAdminFee +
-				(transaction.TransactionInterest * transaction.TransactionOTR * float64(transaction.TransactionInstallment) / 100)
+		timeNow := time.Now()
+		restructured = domain.Transaction{
+			TransactionContractNumber:  fmt.Sprintf("%s-V%d", originalNumber, version),
+			TransactionNIK:             transaction.TransactionNIK,
+			TransactionLimit:           newLimit.LimitID,
+			TransactionOTR:             outstandingPrincipal.Add(capitalised),
+			TransactionInstallment:     input.RestructureTenor,
+			TransactionInterest:        transaction.TransactionInterest,
+			TransactionAssetName:       transaction.TransactionAssetName,
+			TransactionCollectibility:  domain.CollectibilityCurrent,
+			TransactionStatus:          domain.TransactionStatusActive,
+			TransactionScheme:          transaction.TransactionScheme,
+			TransactionParentID:        &transaction.TransactionID,
+			TransactionVersion:         version,
+			TransactionOriginalNumber:  originalNumber,
+			TransactionDate:            timeNow,
+			TransactionCreatedBy:       userID,
+			TransactionCreatedAt:       timeNow,
+			TransactionProductID:       transaction.TransactionProductID,
+			TransactionPricingRuleID:   transaction.TransactionPricingRuleID,
+			TransactionPricingOverride: transaction.TransactionPricingOverride,
+		}
+		if input.RestructureInterest > 0 {
+			restructured.TransactionInterest = input.RestructureInterest
+			restructured.TransactionPricingRuleID = nil
+			restructured.TransactionPricingOverride = true
+		}
 
-			limit.LimitUsedAmount -= originalAmount
-			limit.LimitRemainingAmount += originalAmount
+		calculation, err := calculateFinancing(&restructured)
+		if err != nil {
+			return err
+		}
 
-			if err := u.transactionRepo.DeleteTransactionWithTx(tx, transaction); err != nil {
+		if newLimit.LimitID == 0 || calculation.TotalAmount.GreaterThan(newLimit.LimitRemainingAmount) {
+			utils.Logger.Warnf("Insufficient limit to restructure %s into tenor %.0f", transaction.TransactionContractNumber, input.RestructureTenor)
+			return ErrInsufficientLimit
+		}
+
+		if u.affordabilityConfig.MaxInstallmentRatio > 0 {
+			customer, err := u.customerRepo.GetCustomerByNIK(transaction.TransactionNIK)
+			if err != nil {
 				utils.Logger.WithFields(logrus.Fields{
-					"transaction_contract_number": transaction.TransactionContractNumber,
-					"error":                       err.Error(),
-				}).Error("Failed to delete transaction")
+					"transaction_nik": transaction.TransactionNIK,
+					"error":           err.Error(),
+				}).Error("Failed to retrieve customer for affordability check")
 				return err
 			}
-
-			if err := u.limitRepo.UpdateLimitWithTx(tx, limit); err != nil {
-				utils.Logger.WithFields(logrus.Fields{
-					"transaction_contract_number": transaction.TransactionContractNumber,
-					"error":                       err.Error(),
-				}).Error("Failed to update limit")
+			if err := u.checkAffordabilityWithTx(tx, customer, transaction.TransactionID, calculation.MonthlyPayment); err != nil {
 				return err
 			}
+		}
 
+		if err := u.transactionRepo.CreateTransactionWithTx(tx, &restructured); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": restructured.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to create restructured transaction")
+			return err
+		}
+
+		if err := u.transactionRepo.CreateTransactionStatusHistoryWithTx(tx, &domain.TransactionStatusHistory{
+			HistoryTransactionID: restructured.TransactionID,
+			HistoryToStatus:      restructured.TransactionStatus,
+			HistoryReason:        "restructured from " + transaction.TransactionContractNumber,
+			HistoryChangedBy:     userID,
+			HistoryChangedAt:     timeNow,
+		}); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": restructured.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to record transaction status history")
+			return err
+		}
+
+		schedule := generateInstallmentSchedule(&restructured, calculation)
+		for i := range schedule {
+			schedule[i].InstallmentDueDate = financing.AddMonths(restructured.TransactionDate, schedule[i].InstallmentNumber+input.RestructureHolidayMonths)
+		}
+
+		if err := u.installmentRepo.CreateInstallmentsWithTx(tx, schedule); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": restructured.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to create installment schedule")
+			return err
+		}
+
+		if err := consumeLimitWithTx(tx, u.limitRepo, userID, newLimit, &restructured.TransactionID, calculation.TotalAmount, "restructured from "+transaction.TransactionContractNumber); err != nil {
+			return err
+		}
+
+		if err := checkCustomerLimitWithTx(tx, u.limitRepo, transaction.TransactionNIK); err != nil {
+			return err
+		}
+
+		if err := u.installmentRepo.CloseOutstandingInstallmentsWithTx(tx, transaction.TransactionID, domain.InstallmentStatusRestructured); err != nil {
 			utils.Logger.WithFields(logrus.Fields{
-				"user_id":                     userID,
 				"transaction_contract_number": transaction.TransactionContractNumber,
-				"limit_remaining_amount":      limit.LimitRemainingAmount,
-				"updated_at":                  time.Now(),
-			}).Info("Transaction successfully deleted and limit updated")
+				"error":                       err.Error(),
+			}).Error("Failed to close restructured installments")
+			return err
+		}
 
-			return nil
-		})
+		transaction.TransactionStatus = domain.TransactionStatusRestructured
+		if err := recordTransactionStatusWithTx(tx, u.transactionRepo, userID, transaction, domain.TransactionStatusActive, input.RestructureReason); err != nil {
+			return err
+		}
 
-		if err != nil && (err.Error() == "database is locked" || err.Error() == "deadlock detected") {
-			utils.Logger.Warnf("Deadlock detected. Retrying delete transaction %d/%d", attempt+1, maxRetries)
-			time.Sleep(time.Millisecond * 100)
-			continue
+		if err := u.transactionRepo.CreateTransactionRestructureWithTx(tx, &domain.TransactionRestructure{
+			RestructureFromTransactionID: transaction.TransactionID,
+			RestructureToTransactionID:   restructured.TransactionID,
+			RestructureType:              input.RestructureType,
+			RestructureOldTenor:          transaction.TransactionInstallment,
+			RestructureNewTenor:          input.RestructureTenor,
+			RestructurePrincipal:         restructured.TransactionOTR,
+			RestructureCapitalised:       capitalised,
+			RestructureHolidayMonths:     input.RestructureHolidayMonths,
+			RestructureReason:            input.RestructureReason,
+			RestructureCreatedBy:         userID,
+			RestructureCreatedAt:         timeNow,
+		}); err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_contract_number": transaction.TransactionContractNumber,
+				"error":                       err.Error(),
+			}).Error("Failed to record transaction restructure")
+			return err
 		}
+
+		utils.Logger.WithFields(logrus.Fields{
+			"user_id":                      userID,
+			"transaction_contract_number":  transaction.TransactionContractNumber,
+			"restructured_contract_number": restructured.TransactionContractNumber,
+			"restructure_type":             input.RestructureType,
+			"restructure_capitalised":      capitalised,
+			"old_limit_remaining_amount":   oldLimit.LimitRemainingAmount,
+			"new_limit_remaining_amount":   newLimit.LimitRemainingAmount,
+		}).Info("Transaction successfully restructured")
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &restructured, nil
+}
+
+func (u *transactionUsecase) GetTransactionVersions(transaction *domain.Transaction) ([]domain.Transaction, []domain.TransactionRestructure, error) {
+	originalNumber := transaction.TransactionOriginalNumber
+	if originalNumber == "" {
+		originalNumber = transaction.TransactionContractNumber
+	}
+
+	versions, err := u.transactionRepo.GetTransactionVersions(originalNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	restructures, err := u.transactionRepo.GetTransactionRestructures(originalNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	return versions, restructures, nil
+}
+
+func (u *transactionUsecase) getPricingRules(product *domain.Product) ([]domain.PricingRule, error) {
+	rules, err := u.pricingRuleRepo.GetActivePricingRulesByProductID(product.ProductID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"product_code": product.ProductCode,
+			"error":        err.Error(),
+		}).Error("Failed to retrieve pricing rules")
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (u *transactionUsecase) activateTransactionWithTx(tx *gorm.DB, userID uint, transaction *domain.Transaction) error {
+	transaction.TransactionDate = time.Now()
+
+	calculation, err := calculateFinancing(transaction)
+	if err != nil {
+		return err
+	}
+
+	if u.affordabilityConfig.MaxInstallmentRatio > 0 {
+		customer, err := u.customerRepo.GetCustomerByNIK(transaction.TransactionNIK)
+		if err != nil {
+			utils.Logger.WithFields(logrus.Fields{
+				"transaction_nik": transaction.TransactionNIK,
+				"error":           err.Error(),
+			}).Error("Failed to retrieve customer for affordability check")
+			return err
+		}
+		if err := u.checkAffordabilityWithTx(tx, customer, transaction.TransactionID, calculation.MonthlyPayment); err != nil {
+			return err
+		}
+	}
+
+	limit := &domain.Limit{LimitID: transaction.TransactionLimit}
+	if err := consumeLimitWithTx(tx, u.limitRepo, userID, limit, &transaction.TransactionID, calculation.TotalAmount, "transaction activated"); err != nil {
+		return err
+	}
+
+	if err := checkCustomerLimitWithTx(tx, u.limitRepo, transaction.TransactionNIK); err != nil {
+		return err
+	}
+
+	if err := u.installmentRepo.CreateInstallmentsWithTx(tx, generateInstallmentSchedule(transaction, calculation)); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to create installment schedule")
+		return err
+	}
+	return nil
+}
+
+func (u *transactionUsecase) checkAffordabilityWithTx(tx *gorm.DB, customer *domain.Customer, transactionID uint, newInstallment money.Money) error {
+	maxRatio := u.affordabilityConfig.MaxInstallmentRatio
+	if maxRatio <= 0 {
+		return nil
+	}
+
+	existing, err := u.installmentRepo.GetMonthlyObligationByNIKWithTx(tx, customer.CustomerNIK, transactionID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"customer_nik": customer.CustomerNIK,
+			"error":        err.Error(),
+		}).Error("Failed to sum monthly installment obligations")
+		return err
+	}
+
+	rejection := domain.AffordabilityRejection{
+		CustomerNIK:              customer.CustomerNIK,
+		CustomerSalary:           customer.CustomerSalary,
+		ExistingInstallments:     existing,
+		NewInstallment:           newInstallment,
+		TotalInstallments:        existing.Add(newInstallment),
+		MaxAffordableInstallment: customer.CustomerSalary.Percent(maxRatio),
+		MaxInstallmentRatio:      maxRatio,
+	}
+
+	if !customer.CustomerSalary.IsPositive() {
+		rejection.Reason = domain.AffordabilityReasonNoSalary
+	} else {
+		rejection.InstallmentRatio = math.Round(rejection.TotalInstallments.Float64()/customer.CustomerSalary.Float64()*10000) / 100
+		if !rejection.TotalInstallments.GreaterThan(rejection.MaxAffordableInstallment) {
+			return nil
+		}
+		rejection.Reason = domain.AffordabilityReasonRatioExceeded
+	}
+
+	utils.Logger.WithFields(logrus.Fields{
+		"customer_nik":          customer.CustomerNIK,
+		"reason":                rejection.Reason,
+		"total_installments":    rejection.TotalInstallments,
+		"installment_ratio":     rejection.InstallmentRatio,
+		"max_installment_ratio": maxRatio,
+	}).Warn("Transaction rejected by affordability check")
+	return &AffordabilityError{Rejection: rejection}
+}
+
+func (u *transactionUsecase) cancelActiveTransactionWithTx(tx *gorm.DB, userID uint, transaction *domain.Transaction) error {
+	if err := u.ensureNoPaymentsWithTx(tx, transaction); err != nil {
 		return err
 	}
-	return errors.New("failed to delete transaction after multiple retries")
+
+	if err := u.installmentRepo.VoidInstallmentsByTransactionIDWithTx(tx, transaction.TransactionID); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to void installment schedule")
+		return err
+	}
+
+	calculation, err := calculateFinancing(transaction)
+	if err != nil {
+		return err
+	}
+
+	limit := &domain.Limit{LimitID: transaction.TransactionLimit}
+	return releaseLimitWithTx(tx, u.limitRepo, userID, limit, &transaction.TransactionID, calculation.TotalAmount, "transaction cancelled")
+}
+
+func (u *transactionUsecase) payOffTransactionWithTx(tx *gorm.DB, userID uint, transaction *domain.Transaction) error {
+	installments, err := u.installmentRepo.GetOutstandingInstallmentsWithTx(tx, transaction.TransactionID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to retrieve outstanding installments")
+		return err
+	}
+
+	if len(installments) > 0 {
+		utils.Logger.Warnf("Transaction %s still has %d outstanding installments", transaction.TransactionContractNumber, len(installments))
+		return ErrTransactionHasOutstandingBalance
+	}
+
+	amount, err := paidOffReleaseAmount(transaction)
+	if err != nil {
+		return err
+	}
+
+	limit := &domain.Limit{LimitID: transaction.TransactionLimit}
+	if err := releaseLimitWithTx(tx, u.limitRepo, userID, limit, &transaction.TransactionID, amount, "transaction paid off"); err != nil {
+		return err
+	}
+
+	transaction.TransactionDaysPastDue = 0
+	transaction.TransactionCollectibility = domain.CollectibilityCurrent
+	return nil
+}
+
+func (u *transactionUsecase) ensureNoPaymentsWithTx(tx *gorm.DB, transaction *domain.Transaction) error {
+	hasPayments, err := u.installmentRepo.HasPaidInstallmentsWithTx(tx, transaction.TransactionID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to check installment payments")
+		return err
+	}
+
+	if hasPayments {
+		utils.Logger.Warnf("Transaction %s already has payments", transaction.TransactionContractNumber)
+		return ErrTransactionHasPayments
+	}
+	return nil
+}
+
+func lockTransactionWithTx(tx *gorm.DB, transactionRepo repository.TransactionRepository, transaction *domain.Transaction) error {
+	locked, err := transactionRepo.GetTransactionByIDWithTx(tx, transaction.TransactionID)
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to lock transaction")
+		return err
+	}
+	if locked.TransactionID == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	locked.NIKCustomer = transaction.NIKCustomer
+	locked.IDLimit = transaction.IDLimit
+	locked.CreatedByUser = transaction.CreatedByUser
+	locked.EditedByUser = transaction.EditedByUser
+	*transaction = *locked
+	return nil
+}
+
+func recordTransactionStatusWithTx(tx *gorm.DB, transactionRepo repository.TransactionRepository, userID uint, transaction *domain.Transaction, fromStatus string, reason string) error {
+	timeNow := time.Now()
+	transaction.TransactionEditedBy = &userID
+	transaction.TransactionEditedAt = &timeNow
+
+	if err := transactionRepo.UpdateTransactionStatusWithTx(tx, transaction); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to update transaction status")
+		return err
+	}
+
+	if err := transactionRepo.CreateTransactionStatusHistoryWithTx(tx, &domain.TransactionStatusHistory{
+		HistoryTransactionID: transaction.TransactionID,
+		HistoryFromStatus:    fromStatus,
+		HistoryToStatus:      transaction.TransactionStatus,
+		HistoryReason:        reason,
+		HistoryChangedBy:     userID,
+		HistoryChangedAt:     timeNow,
+	}); err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"error":                       err.Error(),
+		}).Error("Failed to record transaction status history")
+		return err
+	}
+	return nil
+}
+
+func applyTransactionInput(transaction *domain.Transaction, input domain.TransactionInput) {
+	if input.TransactionOTR.IsPositive() {
+		transaction.TransactionOTR = input.TransactionOTR
+	}
+	if input.TransactionAdminFee.IsPositive() {
+		transaction.TransactionAdminFee = input.TransactionAdminFee
+	}
+	if input.TransactionInstallment > 0 {
+		transaction.TransactionInstallment = input.TransactionInstallment
+	}
+	if input.TransactionInterest > 0 {
+		transaction.TransactionInterest = input.TransactionInterest
+	}
+	if input.TransactionAssetName != "" {
+		transaction.TransactionAssetName = input.TransactionAssetName
+	}
+	if input.TransactionScheme != "" {
+		transaction.TransactionScheme = input.TransactionScheme
+	}
+}
+
+func calculateFinancing(transaction *domain.Transaction) (financing.Result, error) {
+	calculation, err := financing.Calculate(transaction.TransactionScheme, financing.Input{
+		Principal:    transaction.TransactionOTR,
+		AdminFee:     transaction.TransactionAdminFee,
+		InterestRate: transaction.TransactionInterest,
+		Tenor:        int(transaction.TransactionInstallment),
+		StartDate:    transaction.TransactionDate,
+	})
+	if err != nil {
+		utils.Logger.WithFields(logrus.Fields{
+			"transaction_contract_number": transaction.TransactionContractNumber,
+			"transaction_scheme":          transaction.TransactionScheme,
+			"error":                       err.Error(),
+		}).Error("Failed to calculate financing")
+		return financing.Result{}, err
+	}
+	return calculation, nil
+}
+
+func paidOffReleaseAmount(transaction *domain.Transaction) (money.Money, error) {
+	calculation, err := calculateFinancing(transaction)
+	if err != nil {
+		return money.Money{}, err
+	}
+	return calculation.TotalAmount.Sub(calculation.Principal), nil
+}
+
+func generateInstallmentSchedule(transaction *domain.Transaction, calculation financing.Result) []domain.Installment {
+	installments := make([]domain.Installment, 0, len(calculation.Periods))
+	for _, period := range calculation.Periods {
+		installments = append(installments, domain.Installment{
+			InstallmentTransactionID: transaction.TransactionID,
+			InstallmentNumber:        period.Number,
+			InstallmentDueDate:       period.DueDate,
+			InstallmentPrincipal:     period.Principal,
+			InstallmentInterest:      period.Interest,
+			InstallmentFee:           period.Fee,
+			InstallmentAmount:        period.Amount,
+			InstallmentStatus:        domain.InstallmentStatusUnpaid,
+		})
+	}
+	return installments
 }
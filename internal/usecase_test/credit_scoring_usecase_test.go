@@ -0,0 +1,154 @@
+package usecase_test
+
+import (
+	"errors"
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func scoringConfig() config.CreditScoringConfig {
+	return config.CreditScoringConfig{MinAge: 21, MaxAgeAtMaturity: 65, MaxInstallmentRatio: 30, LimitRounding: 100000}
+}
+
+func scoredCustomer(age int, salary int64) *domain.Customer {
+	return &domain.Customer{
+		CustomerID:        1,
+		CustomerNIK:       "1234567890123456",
+		CustomerBirthDate: time.Now().AddDate(-age, 0, -1),
+		CustomerSalary:    money.New(salary),
+	}
+}
+
+func TestRecommendLimit_PrimeCustomer(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	scoringUsecase := usecase.NewCreditScoringUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockProductRepo, scoringConfig())
+
+	mockLimitRepo.On("GetLimitsByNIK", "1234567890123456").Return([]domain.Limit{}, nil)
+	mockTransactionRepo.On("GetRepaymentHistoryByNIK", "1234567890123456").Return(&domain.RepaymentHistory{TotalContracts: 2, PaidOffContracts: 2}, nil)
+	mockProductRepo.On("GetOfferedTenors").Return([]int{3, 6}, nil)
+
+	recommendation, err := scoringUsecase.RecommendLimit(scoredCustomer(35, 20000000))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 100, recommendation.Score)
+	assert.Equal(t, domain.RiskGradeA, recommendation.RiskGrade)
+	assert.Equal(t, 35, recommendation.CustomerAge)
+	assert.Equal(t, money.New(18000000), recommendation.AmountForTenor(3), "30% of salary for 3 months")
+	assert.Equal(t, money.New(36000000), recommendation.AmountForTenor(6))
+}
+
+func TestRecommendLimit_SubtractsExposureAndRoundsDown(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	scoringUsecase := usecase.NewCreditScoringUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockProductRepo, scoringConfig())
+
+	mockLimitRepo.On("GetLimitsByNIK", "1234567890123456").Return([]domain.Limit{
+		{LimitTenor: 3, LimitUsedAmount: money.New(1500000)},
+		{LimitTenor: 6, LimitUsedAmount: money.New(1000000)},
+	}, nil)
+	mockTransactionRepo.On("GetRepaymentHistoryByNIK", "1234567890123456").Return(&domain.RepaymentHistory{TotalContracts: 2, LateInstallments: 1}, nil)
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 12}, nil)
+
+	recommendation, err := scoringUsecase.RecommendLimit(scoredCustomer(23, 5000000))
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(2500000), recommendation.ExistingExposure)
+	assert.Equal(t, 18+12+15+20, recommendation.Score)
+	assert.Equal(t, domain.RiskGradeB, recommendation.RiskGrade)
+	assert.True(t, recommendation.AmountForTenor(1).IsZero(), "Exposure exceeds one month of capacity")
+	assert.NotEmpty(t, recommendation.Recommendations[0].Reason)
+	assert.Equal(t, money.New(11900000), recommendation.AmountForTenor(12), "1.2M x 12 - 2.5M rounded down to 100k")
+}
+
+func TestRecommendLimit_WrittenOffHistoryIsIneligible(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	scoringUsecase := usecase.NewCreditScoringUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockProductRepo, scoringConfig())
+
+	mockLimitRepo.On("GetLimitsByNIK", "1234567890123456").Return([]domain.Limit{}, nil)
+	mockTransactionRepo.On("GetRepaymentHistoryByNIK", "1234567890123456").Return(&domain.RepaymentHistory{TotalContracts: 1, WrittenOffContracts: 1, MaxDaysPastDue: 120}, nil)
+	mockProductRepo.On("GetOfferedTenors").Return([]int{6}, nil)
+
+	recommendation, err := scoringUsecase.RecommendLimit(scoredCustomer(40, 30000000))
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.RiskGradeE, recommendation.RiskGrade)
+	assert.True(t, recommendation.AmountForTenor(6).IsZero())
+}
+
+func TestRecommendLimit_TenorBeyondMaximumAge(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	scoringUsecase := usecase.NewCreditScoringUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockProductRepo, scoringConfig())
+
+	mockLimitRepo.On("GetLimitsByNIK", "1234567890123456").Return([]domain.Limit{}, nil)
+	mockTransactionRepo.On("GetRepaymentHistoryByNIK", "1234567890123456").Return(&domain.RepaymentHistory{}, nil)
+	mockProductRepo.On("GetOfferedTenors").Return([]int{6, 24}, nil)
+
+	recommendation, err := scoringUsecase.RecommendLimit(scoredCustomer(64, 10000000))
+
+	assert.Nil(t, err)
+	assert.True(t, recommendation.AmountForTenor(6).IsPositive())
+	assert.True(t, recommendation.AmountForTenor(24).IsZero(), "Customer would be 65+ at maturity")
+}
+
+func TestRecommendLimit_HistoryError(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	scoringUsecase := usecase.NewCreditScoringUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockProductRepo, scoringConfig())
+
+	mockLimitRepo.On("GetLimitsByNIK", "1234567890123456").Return([]domain.Limit{}, nil)
+	mockTransactionRepo.On("GetRepaymentHistoryByNIK", "1234567890123456").Return(nil, errors.New("database error"))
+
+	recommendation, err := scoringUsecase.RecommendLimit(scoredCustomer(30, 10000000))
+
+	assert.Nil(t, recommendation)
+	assert.EqualError(t, err, "database error")
+}
+
+func TestAssignRiskGrade_KeepsExistingGrade(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	scoringUsecase := usecase.NewCreditScoringUsecase(mockCustomerRepo, new(mocks.LimitRepository), new(mocks.TransactionRepository), new(mocks.ProductRepository), scoringConfig())
+
+	customer := scoredCustomer(30, 10000000)
+	customer.CustomerRiskGrade = domain.RiskGradeC
+
+	err := scoringUsecase.AssignRiskGrade(customer, domain.RiskGradeA)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.RiskGradeC, customer.CustomerRiskGrade)
+	mockCustomerRepo.AssertNotCalled(t, "AssignCustomerRiskGradeIfUngraded", mock.Anything, mock.Anything)
+}
+
+func TestAssignRiskGrade_UngradedCustomer(t *testing.T) {
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	scoringUsecase := usecase.NewCreditScoringUsecase(mockCustomerRepo, new(mocks.LimitRepository), new(mocks.TransactionRepository), new(mocks.ProductRepository), scoringConfig())
+
+	mockCustomerRepo.On("AssignCustomerRiskGradeIfUngraded", uint(1), domain.RiskGradeB).Return(true, nil)
+
+	customer := scoredCustomer(30, 10000000)
+	err := scoringUsecase.AssignRiskGrade(customer, domain.RiskGradeB)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.RiskGradeB, customer.CustomerRiskGrade)
+	mockCustomerRepo.AssertExpectations(t)
+}
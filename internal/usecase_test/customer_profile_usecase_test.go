@@ -0,0 +1,229 @@
+package usecase_test
+
+import (
+	"kreditplus/internal/domain"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func profileCustomer() *domain.Customer {
+	return &domain.Customer{
+		CustomerID:    3,
+		CustomerNIK:   "3171014509900001",
+		CustomerPhone: "6281234567890",
+		CustomerPhones: []domain.CustomerPhoneNumber{
+			{PhoneID: 1, PhoneCustomerID: 3, PhoneNumber: "6281111111111", PhonePrimary: true},
+		},
+	}
+}
+
+func TestAddCustomerAddress_Success(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	profileRepo.On("SaveCustomerAddress", mock.MatchedBy(func(address *domain.CustomerAddress) bool {
+		return address.AddressCustomerID == 3 && address.AddressType == domain.CustomerAddressTypeDomicile &&
+			address.AddressCity == "Bandung" && address.AddressCreatedBy == 1
+	})).Return(nil)
+
+	address, err := profileUsecase.AddCustomerAddress(1, profileCustomer(), domain.CustomerAddressInput{
+		AddressType:     domain.CustomerAddressTypeDomicile,
+		AddressLine:     " Jl. Asia Afrika No. 8 ",
+		AddressCity:     "Bandung",
+		AddressProvince: "Jawa Barat",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Jl. Asia Afrika No. 8", address.AddressLine)
+	profileRepo.AssertExpectations(t)
+}
+
+func TestAddCustomerAddress_TypeExists(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	profileRepo.On("SaveCustomerAddress", mock.Anything).Return(&pgconn.PgError{Code: "23505", Message: "duplicate key value"})
+
+	address, err := profileUsecase.AddCustomerAddress(1, profileCustomer(), domain.CustomerAddressInput{
+		AddressType:     domain.CustomerAddressTypeKTP,
+		AddressLine:     "Jl. Sudirman No. 1",
+		AddressCity:     "Jakarta Pusat",
+		AddressProvince: "DKI Jakarta",
+	})
+
+	assert.Nil(t, address)
+	assert.ErrorIs(t, err, usecase.ErrCustomerAddressTypeExists)
+}
+
+func TestAddCustomerPhone_NormalizesNumber(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	profileRepo.On("SaveCustomerPhone", mock.MatchedBy(func(phone *domain.CustomerPhoneNumber) bool {
+		return phone.PhoneNumber == "6285712345678" && !phone.PhoneVerified
+	})).Return(nil)
+
+	phone, err := profileUsecase.AddCustomerPhone(1, profileCustomer(), domain.CustomerPhoneInput{PhoneNumber: "0857-1234-5678", PhoneLabel: "mobile"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "6285712345678", phone.PhoneNumber)
+	profileRepo.AssertExpectations(t)
+}
+
+func TestUpdateCustomerPhone_NewNumberResetsVerification(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	verifiedBy := uint(9)
+	verifiedAt := time.Now()
+	phone := &domain.CustomerPhoneNumber{PhoneID: 1, PhoneNumber: "6281111111111", PhoneVerified: true, PhoneVerifiedBy: &verifiedBy, PhoneVerifiedAt: &verifiedAt}
+	profileRepo.On("SaveCustomerPhone", phone).Return(nil)
+
+	err := profileUsecase.UpdateCustomerPhone(2, phone, domain.CustomerPhoneInput{PhoneNumber: "081222222222"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "6281222222222", phone.PhoneNumber)
+	assert.False(t, phone.PhoneVerified)
+	assert.Nil(t, phone.PhoneVerifiedAt)
+}
+
+func TestUpdateCustomerPhone_SameNumberKeepsVerification(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	phone := &domain.CustomerPhoneNumber{PhoneID: 1, PhoneNumber: "6281111111111", PhoneVerified: true}
+	profileRepo.On("SaveCustomerPhone", phone).Return(nil)
+
+	err := profileUsecase.UpdateCustomerPhone(2, phone, domain.CustomerPhoneInput{PhoneNumber: "0811-1111-1111", PhoneLabel: "home"})
+
+	assert.Nil(t, err)
+	assert.True(t, phone.PhoneVerified)
+	assert.Equal(t, "home", phone.PhoneLabel)
+}
+
+func TestVerifyCustomerEmail_Success(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	email := &domain.CustomerEmailAddress{EmailID: 1, EmailAddress: "siti@example.com"}
+	profileRepo.On("SaveCustomerEmail", email).Return(nil)
+
+	err := profileUsecase.VerifyCustomerEmail(7, email)
+
+	assert.Nil(t, err)
+	assert.True(t, email.EmailVerified)
+	assert.Equal(t, uint(7), *email.EmailVerifiedBy)
+	assert.NotNil(t, email.EmailVerifiedAt)
+}
+
+func TestAddCustomerEmail_Duplicate(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	profileRepo.On("SaveCustomerEmail", mock.MatchedBy(func(email *domain.CustomerEmailAddress) bool {
+		return email.EmailAddress == "siti@example.com"
+	})).Return(&pgconn.PgError{Code: "23505", Message: "duplicate key value"})
+
+	_, err := profileUsecase.AddCustomerEmail(1, profileCustomer(), domain.CustomerEmailInput{EmailAddress: " Siti@Example.com "})
+
+	assert.ErrorIs(t, err, usecase.ErrCustomerEmailExists)
+}
+
+func TestSaveCustomerEmployment_EmployerRequired(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	_, err := profileUsecase.SaveCustomerEmployment(1, profileCustomer(), domain.CustomerEmploymentInput{
+		EmploymentStatus: domain.EmploymentStatusEmployed,
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrEmployerRequired)
+	profileRepo.AssertNotCalled(t, "SaveCustomerEmployment", mock.Anything)
+}
+
+func TestSaveCustomerEmployment_UpdatesExisting(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	customer := profileCustomer()
+	customer.CustomerEmployment = &domain.CustomerEmployment{EmploymentID: 4, EmploymentCustomerID: 3, EmploymentStatus: domain.EmploymentStatusStudent, EmploymentCreatedBy: 1}
+	profileRepo.On("SaveCustomerEmployment", customer.CustomerEmployment).Return(nil)
+
+	employment, err := profileUsecase.SaveCustomerEmployment(2, customer, domain.CustomerEmploymentInput{
+		EmploymentStatus:       domain.EmploymentStatusEmployed,
+		EmploymentEmployerName: "PT Maju Jaya",
+		EmploymentJobTitle:     "Staff",
+		EmploymentStartDate:    "2020-01-15",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(4), employment.EmploymentID)
+	assert.Equal(t, "PT Maju Jaya", employment.EmploymentEmployerName)
+	assert.Equal(t, time.Date(2020, time.January, 15, 0, 0, 0, 0, time.UTC), *employment.EmploymentStartDate)
+	assert.Equal(t, uint(2), *employment.EmploymentEditedBy)
+}
+
+func TestSaveCustomerEmployment_FutureStartDate(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	_, err := profileUsecase.SaveCustomerEmployment(1, profileCustomer(), domain.CustomerEmploymentInput{
+		EmploymentStatus:       domain.EmploymentStatusSelfEmployed,
+		EmploymentEmployerName: "Warung Siti",
+		EmploymentStartDate:    time.Now().AddDate(1, 0, 0).Format("2006-01-02"),
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidEmploymentStartDate)
+}
+
+func TestAddCustomerEmergencyContact_RejectsCustomerPhone(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	_, err := profileUsecase.AddCustomerEmergencyContact(1, profileCustomer(), domain.CustomerEmergencyContactInput{
+		ContactName:         "Budi",
+		ContactRelationship: "sibling",
+		ContactPhone:        "0811-1111-1111",
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrEmergencyContactIsCustomer)
+	profileRepo.AssertNotCalled(t, "SaveCustomerEmergencyContact", mock.Anything)
+}
+
+func TestAddCustomerEmergencyContact_Success(t *testing.T) {
+	profileRepo := new(mocks.CustomerProfileRepository)
+	profileUsecase := usecase.NewCustomerProfileUsecase(new(mocks.CustomerRepository), profileRepo)
+
+	profileRepo.On("SaveCustomerEmergencyContact", mock.MatchedBy(func(contact *domain.CustomerEmergencyContact) bool {
+		return contact.ContactCustomerID == 3 && contact.ContactPhone == "6281333333333" && contact.ContactRelationship == "spouse"
+	})).Return(nil)
+
+	contact, err := profileUsecase.AddCustomerEmergencyContact(1, profileCustomer(), domain.CustomerEmergencyContactInput{
+		ContactName:         "Budi",
+		ContactRelationship: "spouse",
+		ContactPhone:        "081333333333",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "Budi", contact.ContactName)
+	profileRepo.AssertExpectations(t)
+}
+
+func TestScreenCustomer_IncludesProfilePhones(t *testing.T) {
+	negativeListRepo := negativeList(
+		negativeListEntry(1, domain.NegativeListTypePhone, "6281111111111", domain.NegativeListSeverityFlag),
+	)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, new(mocks.CustomerRepository))
+
+	result, err := screeningUsecase.ScreenCustomer(profileCustomer())
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.ScreeningDecisionFlag, result.Decision)
+	negativeListRepo.AssertCalled(t, "FindActiveMatches", "3171014509900001", mock.Anything, []string{"6281234567890", "6281111111111"}, mock.Anything)
+}
@@ -2,10 +2,19 @@ package usecase_test
 
 import (
 	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"kreditplus/config"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/imagehash"
+	"kreditplus/internal/money"
 	"kreditplus/internal/repository/mocks"
 	"kreditplus/internal/usecase"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -14,19 +23,21 @@ import (
 
 func TestCreateCustomer_Success(t *testing.T) {
 	customerRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(customerRepo)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	input := domain.Customer{
-		CustomerNIK:        "1234567890123456",
+		CustomerNIK:        "3171014509900001",
 		CustomerFullName:   "Test User",
 		CustomerLegalName:  "Test Legal",
 		CustomerBirthPlace: "Jakarta",
-		CustomerSalary:     5000000,
+		CustomerBirthDate:  time.Date(1990, time.September, 5, 0, 0, 0, 0, time.UTC),
+		CustomerSalary:     money.New(5000000),
 	}
 
+	customerRepo.On("FindDuplicateCandidates", mock.Anything, 0).Return([]domain.Customer{}, nil)
 	customerRepo.On("CreateCustomer", mock.Anything).Return(nil)
 
-	err := customerUsecase.CreateCustomer(input)
+	_, err := customerUsecase.CreateCustomer(input)
 
 	assert.Nil(t, err)
 	customerRepo.AssertExpectations(t)
@@ -34,17 +45,17 @@ func TestCreateCustomer_Success(t *testing.T) {
 
 func TestCreateCustomer_InvalidNIK(t *testing.T) {
 	customerRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(customerRepo)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	input := domain.Customer{
 		CustomerNIK:        "123",
 		CustomerFullName:   "Test User",
 		CustomerLegalName:  "Test Legal",
 		CustomerBirthPlace: "Jakarta",
-		CustomerSalary:     5000000,
+		CustomerSalary:     money.New(5000000),
 	}
 
-	err := customerUsecase.CreateCustomer(input)
+	_, err := customerUsecase.CreateCustomer(input)
 
 	assert.NotNil(t, err)
 	assert.Equal(t, "invalid NIK", err.Error())
@@ -52,17 +63,18 @@ func TestCreateCustomer_InvalidNIK(t *testing.T) {
 
 func TestCreateCustomer_DBError(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	customer := domain.Customer{
-		CustomerNIK:      "1234567890123456",
+		CustomerNIK:      "3171014509900001",
 		CustomerFullName: "John Doe",
-		CustomerSalary:   5000000,
+		CustomerSalary:   money.New(5000000),
 	}
 
+	mockRepo.On("FindDuplicateCandidates", mock.Anything, 0).Return([]domain.Customer{}, nil)
 	mockRepo.On("CreateCustomer", mock.Anything).Return(errors.New("database error"))
 
-	err := customerUsecase.CreateCustomer(customer)
+	_, err := customerUsecase.CreateCustomer(customer)
 
 	assert.Error(t, err)
 	assert.Equal(t, "database error", err.Error(), "Should return database error")
@@ -70,7 +82,7 @@ func TestCreateCustomer_DBError(t *testing.T) {
 
 func TestGetAllCustomers_Success(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	mockCustomers := []domain.Customer{
 		{CustomerNIK: "1234567890123456", CustomerFullName: "John Doe"},
@@ -92,7 +104,7 @@ func TestGetAllCustomers_Success(t *testing.T) {
 
 func TestGetAllCustomers_DBError(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	mockRepo.On("GetAllCustomers", 10, 0).Return(nil, errors.New("database error"))
 
@@ -107,7 +119,7 @@ func TestGetAllCustomers_DBError(t *testing.T) {
 
 func TestGetCustomerByID_Success(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	mockCustomer := &domain.Customer{
 		CustomerID:       1,
@@ -130,7 +142,7 @@ func TestGetCustomerByID_Success(t *testing.T) {
 
 func TestGetCustomerByID_NotFound(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	mockRepo.On("GetCustomerByID", uint(99)).Return(nil, gorm.ErrRecordNotFound)
 
@@ -145,7 +157,7 @@ func TestGetCustomerByID_NotFound(t *testing.T) {
 
 func TestGetCustomerByID_DBError(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	mockRepo.On("GetCustomerByID", uint(1)).Return(nil, errors.New("database error"))
 
@@ -160,12 +172,12 @@ func TestGetCustomerByID_DBError(t *testing.T) {
 
 func TestUpdateCustomer_Success(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	updatedCustomer := domain.Customer{
-		CustomerNIK:      "1234567890123456",
+		CustomerNIK:      "3171014509900001",
 		CustomerFullName: "John Doe Updated",
-		CustomerSalary:   6000000,
+		CustomerSalary:   money.New(6000000),
 	}
 
 	mockRepo.On("UpdateCustomer", mock.Anything).Return(nil)
@@ -178,7 +190,7 @@ func TestUpdateCustomer_Success(t *testing.T) {
 
 func TestUpdateCustomer_InvalidNIK(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	customer := domain.Customer{
 		CustomerNIK: "12345",
@@ -192,10 +204,10 @@ func TestUpdateCustomer_InvalidNIK(t *testing.T) {
 
 func TestUpdateCustomer_DBError(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	customer := domain.Customer{
-		CustomerNIK:      "1234567890123456",
+		CustomerNIK:      "3171014509900001",
 		CustomerFullName: "John Doe Updated",
 	}
 
@@ -209,7 +221,7 @@ func TestUpdateCustomer_DBError(t *testing.T) {
 
 func TestDeleteCustomer_Success(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	mockRepo.On("DeleteCustomer", uint(1)).Return(nil)
 
@@ -221,7 +233,7 @@ func TestDeleteCustomer_Success(t *testing.T) {
 
 func TestDeleteCustomer_NotFound(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	mockRepo.On("DeleteCustomer", uint(99)).Return(gorm.ErrRecordNotFound)
 
@@ -234,7 +246,7 @@ func TestDeleteCustomer_NotFound(t *testing.T) {
 
 func TestDeleteCustomer_DBError(t *testing.T) {
 	mockRepo := new(mocks.CustomerRepository)
-	customerUsecase := usecase.NewCustomerUsecase(mockRepo)
+	customerUsecase := usecase.NewCustomerUsecase(mockRepo, negativeList(), config.DuplicateDetectionConfig{})
 
 	mockRepo.On("DeleteCustomer", uint(1)).Return(errors.New("database error"))
 
@@ -244,3 +256,234 @@ func TestDeleteCustomer_DBError(t *testing.T) {
 	assert.Equal(t, "database error", err.Error(), "Expected database error")
 	mockRepo.AssertExpectations(t)
 }
+
+func TestCreateCustomer_StartsPendingKYC(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
+
+	customerRepo.On("FindDuplicateCandidates", mock.Anything, 0).Return([]domain.Customer{}, nil)
+	customerRepo.On("CreateCustomer", mock.MatchedBy(func(customer *domain.Customer) bool {
+		return customer.CustomerKYCStatus == domain.CustomerKYCPending && customer.CustomerKYCSubmittedAt != nil
+	})).Return(nil)
+
+	_, err := customerUsecase.CreateCustomer(domain.Customer{CustomerNIK: "3171014509900001", CustomerKYCStatus: domain.CustomerKYCVerified})
+
+	assert.Nil(t, err)
+	customerRepo.AssertExpectations(t)
+}
+
+func TestReviewCustomerKYC_Verify(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
+
+	customer := &domain.Customer{CustomerID: 1, CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCPending}
+	customerRepo.On("UpdateCustomer", customer).Return(nil)
+
+	err := customerUsecase.ReviewCustomerKYC(7, customer, domain.CustomerKYCReviewInput{CustomerKYCStatus: domain.CustomerKYCVerified})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.CustomerKYCVerified, customer.CustomerKYCStatus)
+	assert.Equal(t, uint(7), *customer.CustomerKYCReviewedBy)
+	assert.NotNil(t, customer.CustomerKYCReviewedAt)
+	customerRepo.AssertExpectations(t)
+}
+
+func TestReviewCustomerKYC_RejectRequiresReason(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
+
+	customer := &domain.Customer{CustomerID: 1, CustomerKYCStatus: domain.CustomerKYCPending}
+
+	err := customerUsecase.ReviewCustomerKYC(7, customer, domain.CustomerKYCReviewInput{CustomerKYCStatus: domain.CustomerKYCRejected, CustomerKYCReason: "  "})
+
+	assert.ErrorIs(t, err, usecase.ErrKYCReasonRequired)
+	assert.Equal(t, domain.CustomerKYCPending, customer.CustomerKYCStatus)
+	customerRepo.AssertNotCalled(t, "UpdateCustomer", mock.Anything)
+}
+
+func TestReviewCustomerKYC_RejectedIsFinal(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
+
+	customer := &domain.Customer{CustomerID: 1, CustomerKYCStatus: domain.CustomerKYCRejected}
+
+	err := customerUsecase.ReviewCustomerKYC(7, customer, domain.CustomerKYCReviewInput{CustomerKYCStatus: domain.CustomerKYCVerified})
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidKYCTransition)
+	customerRepo.AssertNotCalled(t, "UpdateCustomer", mock.Anything)
+}
+
+func TestGetKYCReviewQueue_DefaultsToPending(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
+
+	customerRepo.On("GetCustomersByKYCStatus", domain.CustomerKYCPending, 10, 0).Return([]domain.Customer{{CustomerID: 1}}, nil)
+
+	customers, err := customerUsecase.GetKYCReviewQueue("", 10, 0)
+
+	assert.Nil(t, err)
+	assert.Len(t, customers, 1)
+}
+
+func TestResubmitKYC_ReturnsToPending(t *testing.T) {
+	customer := &domain.Customer{CustomerKYCStatus: domain.CustomerKYCNeedsResubmission}
+
+	customer.ResubmitKYC(time.Now())
+
+	assert.Equal(t, domain.CustomerKYCPending, customer.CustomerKYCStatus)
+	assert.NotNil(t, customer.CustomerKYCSubmittedAt)
+}
+
+func TestResubmitKYC_KeepsRejected(t *testing.T) {
+	customer := &domain.Customer{CustomerKYCStatus: domain.CustomerKYCRejected, CustomerKYCReason: "forged KTP"}
+
+	customer.ResubmitKYC(time.Now())
+
+	assert.Equal(t, domain.CustomerKYCRejected, customer.CustomerKYCStatus)
+	assert.Equal(t, "forged KTP", customer.CustomerKYCReason)
+}
+
+func TestCreateCustomer_NIKMismatch(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
+
+	_, err := customerUsecase.CreateCustomer(domain.Customer{
+		CustomerNIK:        "3171014509900001",
+		CustomerBirthPlace: "Surabaya",
+		CustomerBirthDate:  time.Date(1990, time.September, 6, 0, 0, 0, 0, time.UTC),
+	})
+
+	var nikErr *usecase.NIKValidationError
+	assert.ErrorAs(t, err, &nikErr)
+	assert.ErrorIs(t, err, usecase.ErrNIKMismatch)
+	assert.Len(t, nikErr.Mismatches, 2)
+	assert.Equal(t, "customer_birth_date", nikErr.Mismatches[0].Field)
+	assert.Equal(t, "customer_birth_place", nikErr.Mismatches[1].Field)
+	customerRepo.AssertNotCalled(t, "CreateCustomer", mock.Anything)
+}
+
+func TestUpdateCustomer_ImpossibleNIK(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), config.DuplicateDetectionConfig{})
+
+	err := customerUsecase.UpdateCustomer(domain.Customer{CustomerNIK: "3171017313900001"})
+
+	var nikErr *usecase.NIKValidationError
+	assert.ErrorAs(t, err, &nikErr)
+	assert.Equal(t, "customer_nik", nikErr.Mismatches[0].Field)
+	customerRepo.AssertNotCalled(t, "UpdateCustomer", mock.Anything)
+}
+
+func duplicateConfig() config.DuplicateDetectionConfig {
+	return config.DuplicateDetectionConfig{PhotoHashMaxDistance: 10, BlockingScore: 40, MaxResults: 10, AutoApproveKYC: true}
+}
+
+func onboardingCustomer() domain.Customer {
+	return domain.Customer{
+		CustomerNIK:        "3171014509900001",
+		CustomerFullName:   "Siti Rahayu",
+		CustomerLegalName:  "Siti Rahayu",
+		CustomerBirthPlace: "Jakarta",
+		CustomerBirthDate:  time.Date(1990, time.September, 5, 0, 0, 0, 0, time.UTC),
+		CustomerPhone:      "0812-3456-7890",
+	}
+}
+
+func TestCreateCustomer_RanksSuspectedDuplicatesAndBlocksAutoApproval(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), duplicateConfig())
+
+	customerRepo.On("FindDuplicateCandidates", mock.MatchedBy(func(customer *domain.Customer) bool {
+		return customer.CustomerPhone == "6281234567890"
+	}), 10).Return([]domain.Customer{
+		{CustomerID: 4, CustomerNIK: "3273014509900002", CustomerFullName: "Other Person", CustomerPhone: "6281234567890"},
+		{CustomerID: 5, CustomerNIK: "3273014509900003", CustomerFullName: "Hj. SITI  rahayu", CustomerBirthDate: time.Date(1990, time.September, 5, 0, 0, 0, 0, time.UTC), CustomerPhone: "6281234567890"},
+		{CustomerID: 6, CustomerNIK: "3273014509900004", CustomerFullName: "Budi Santoso", CustomerBirthDate: time.Date(1990, time.September, 5, 0, 0, 0, 0, time.UTC)},
+	}, nil)
+
+	var created *domain.Customer
+	customerRepo.On("CreateCustomer", mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*domain.Customer)
+	}).Return(nil)
+
+	duplicates, err := customerUsecase.CreateCustomer(onboardingCustomer())
+
+	assert.Nil(t, err)
+	assert.Len(t, duplicates, 2, "Shared birth date alone is not a signal")
+	assert.Equal(t, uint(5), duplicates[0].CustomerID)
+	assert.Equal(t, 80, duplicates[0].Score)
+	assert.True(t, duplicates[0].Blocking)
+	assert.Equal(t, uint(4), duplicates[1].CustomerID)
+	assert.Equal(t, 30, duplicates[1].Score)
+	assert.False(t, duplicates[1].Blocking)
+
+	assert.Equal(t, domain.CustomerKYCPending, created.CustomerKYCStatus)
+	assert.Contains(t, created.CustomerKYCReason, "3273014509900003")
+}
+
+func TestCreateCustomer_AutoApprovesWithoutDuplicates(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), duplicateConfig())
+
+	customerRepo.On("FindDuplicateCandidates", mock.Anything, 10).Return([]domain.Customer{
+		{CustomerID: 4, CustomerNIK: "3273014509900002", CustomerFullName: "Other Person", CustomerPhone: "6281234567890"},
+	}, nil)
+	customerRepo.On("CreateCustomer", mock.MatchedBy(func(customer *domain.Customer) bool {
+		return customer.CustomerKYCStatus == domain.CustomerKYCVerified && customer.CustomerKYCReviewedAt != nil
+	})).Return(nil)
+
+	duplicates, err := customerUsecase.CreateCustomer(onboardingCustomer())
+
+	assert.Nil(t, err)
+	assert.Len(t, duplicates, 1, "Non-blocking matches are still reported")
+	customerRepo.AssertExpectations(t)
+}
+
+func TestCreateCustomer_MatchesSimilarKTPPhoto(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), duplicateConfig())
+
+	img := image.NewGray(image.Rect(0, 0, 90, 80))
+	for x := 0; x < 90; x++ {
+		for y := 0; y < 80; y++ {
+			img.SetGray(x, y, color.Gray{Y: uint8((x * 255 / 90) ^ (y * 255 / 80))})
+		}
+	}
+	path := filepath.Join(t.TempDir(), "ktp.png")
+	file, _ := os.Create(path)
+	_ = png.Encode(file, img)
+	file.Close()
+
+	input := onboardingCustomer()
+	input.CustomerPhone = ""
+	input.CustomerKTPPhoto = path
+	ktpHash := imagehash.Format(imagehash.DifferenceHash(img))
+
+	customerRepo.On("FindDuplicateCandidates", mock.MatchedBy(func(customer *domain.Customer) bool {
+		return customer.CustomerKTPPhash == ktpHash
+	}), 10).Return([]domain.Customer{
+		{CustomerID: 9, CustomerNIK: "3273014509900009", CustomerFullName: "Different Name", CustomerKTPPhash: ktpHash},
+	}, nil)
+	customerRepo.On("CreateCustomer", mock.Anything).Return(nil)
+
+	duplicates, err := customerUsecase.CreateCustomer(input)
+
+	assert.Nil(t, err)
+	assert.Len(t, duplicates, 1)
+	assert.Equal(t, domain.DuplicateSignalKTPPhoto, duplicates[0].Signals[0].Signal)
+	assert.Equal(t, 45, duplicates[0].Score)
+	assert.True(t, duplicates[0].Blocking)
+}
+
+func TestCreateCustomer_DuplicateLookupError(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(), duplicateConfig())
+
+	customerRepo.On("FindDuplicateCandidates", mock.Anything, 10).Return(nil, errors.New("database error"))
+
+	duplicates, err := customerUsecase.CreateCustomer(onboardingCustomer())
+
+	assert.Nil(t, duplicates)
+	assert.EqualError(t, err, "database error")
+	customerRepo.AssertNotCalled(t, "CreateCustomer", mock.Anything)
+}
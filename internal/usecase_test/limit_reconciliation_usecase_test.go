@@ -0,0 +1,152 @@
+package usecase_test
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func reconciliationTransaction() domain.Transaction {
+	return domain.Transaction{
+		TransactionID:          10,
+		TransactionLimit:       1,
+		TransactionOTR:         money.New(1000000),
+		TransactionAdminFee:    money.New(50000),
+		TransactionInstallment: 3,
+		TransactionInterest:    2.0,
+		TransactionStatus:      domain.TransactionStatusActive,
+	}
+}
+
+func TestReconcileLimits_ReportsDriftWithoutRepair(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	reconciliationUsecase := usecase.NewLimitReconciliationUsecase(mockLimitRepo, mockTransactionRepo, mockInstallmentRepo)
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(1500000),
+		LimitRemainingAmount: money.New(3500000),
+	}
+
+	mockLimitRepo.On("GetLimitIDs").Return([]uint{1}, nil)
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(1)).Return(limit, nil)
+	mockTransactionRepo.On("GetTransactionsByLimitIDWithTx", mock.Anything, uint(1), []string{domain.TransactionStatusActive, domain.TransactionStatusWrittenOff}).
+		Return([]domain.Transaction{reconciliationTransaction()}, nil)
+	mockInstallmentRepo.On("GetPaidPrincipalWithTx", mock.Anything, uint(10)).Return(money.New(300000), nil)
+
+	result, err := reconciliationUsecase.ReconcileLimits(1, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.CheckedLimits)
+	assert.Equal(t, 1, result.MismatchedLimits)
+	assert.Equal(t, 0, result.RepairedLimits)
+	assert.Equal(t, money.New(810000), result.Mismatches[0].ExpectedUsedAmount, "Total 1110000 less 300000 principal repaid")
+	assert.Equal(t, money.New(4190000), result.Mismatches[0].ExpectedRemainingAmount)
+	assert.Equal(t, money.New(1500000), limit.LimitUsedAmount, "Report mode should not touch the limit")
+	mockLimitRepo.AssertNotCalled(t, "UpdateLimitWithTx", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "CreateLimitMovementWithTx", mock.Anything, mock.Anything)
+}
+
+func TestReconcileLimits_RepairsWithAdjustment(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	reconciliationUsecase := usecase.NewLimitReconciliationUsecase(mockLimitRepo, mockTransactionRepo, mockInstallmentRepo)
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(1500000),
+		LimitRemainingAmount: money.New(3500000),
+	}
+
+	mockLimitRepo.On("GetLimitIDs").Return([]uint{1}, nil)
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(1)).Return(limit, nil)
+	mockTransactionRepo.On("GetTransactionsByLimitIDWithTx", mock.Anything, uint(1), mock.Anything).
+		Return([]domain.Transaction{reconciliationTransaction()}, nil)
+	mockInstallmentRepo.On("GetPaidPrincipalWithTx", mock.Anything, uint(10)).Return(money.New(300000), nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.MatchedBy(func(movement *domain.LimitMovement) bool {
+		return movement.MovementType == domain.LimitMovementAdjust &&
+			movement.MovementUsedDelta == money.New(-690000) &&
+			movement.MovementRemainingDelta == money.New(690000) &&
+			movement.MovementCreatedBy == 7 &&
+			movement.MovementReason == "reconciliation"
+	})).Return(nil)
+	mockLimitRepo.On("UpdateLimitWithTx", mock.Anything, limit).Return(nil)
+
+	result, err := reconciliationUsecase.ReconcileLimits(7, true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.RepairedLimits)
+	assert.True(t, result.Mismatches[0].Repaired)
+	assert.Equal(t, money.New(810000), limit.LimitUsedAmount)
+	assert.Equal(t, money.New(4190000), limit.LimitRemainingAmount)
+	mockLimitRepo.AssertExpectations(t)
+}
+
+func TestReconcileLimits_SkipsBalancedLimitAndCountsFailures(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	reconciliationUsecase := usecase.NewLimitReconciliationUsecase(mockLimitRepo, mockTransactionRepo, mockInstallmentRepo)
+
+	mockLimitRepo.On("GetLimitIDs").Return([]uint{1, 2}, nil)
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(1)).Return(&domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(810000),
+		LimitRemainingAmount: money.New(4190000),
+	}, nil)
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(2)).Return(nil, errors.New("database error"))
+	mockTransactionRepo.On("GetTransactionsByLimitIDWithTx", mock.Anything, uint(1), mock.Anything).
+		Return([]domain.Transaction{reconciliationTransaction()}, nil)
+	mockInstallmentRepo.On("GetPaidPrincipalWithTx", mock.Anything, uint(10)).Return(money.New(300000), nil)
+
+	result, err := reconciliationUsecase.ReconcileLimits(1, true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.CheckedLimits)
+	assert.Equal(t, 0, result.MismatchedLimits)
+	assert.Equal(t, 1, result.FailedLimits)
+	assert.Empty(t, result.Mismatches)
+}
+
+func TestReconcileLimits_LoadError(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	reconciliationUsecase := usecase.NewLimitReconciliationUsecase(mockLimitRepo, mockTransactionRepo, mockInstallmentRepo)
+
+	mockLimitRepo.On("GetLimitIDs").Return(nil, gorm.ErrInvalidDB)
+
+	result, err := reconciliationUsecase.ReconcileLimits(1, false)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, gorm.ErrInvalidDB)
+}
@@ -3,10 +3,12 @@ package usecase_test
 import (
 	"errors"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
 	"kreditplus/internal/repository/mocks"
 	"kreditplus/internal/usecase"
 	"testing"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -15,15 +17,24 @@ import (
 func TestCreateLimit_Success(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6, 12}, nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, nil)
 
 	limit := domain.Limit{
 		LimitNIK:    "1234567890123456",
 		LimitTenor:  12,
-		LimitAmount: 5000000,
+		LimitAmount: money.New(5000000),
 	}
 
-	mockLimitRepo.On("CreateLimit", mock.Anything).Return(nil)
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("CreateLimitWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.MatchedBy(func(movement *domain.LimitMovement) bool {
+		return movement.MovementType == domain.LimitMovementAdjust && movement.MovementLimitDelta == money.New(5000000)
+	})).Return(nil)
 
 	err := limitUsecase.CreateLimit(limit)
 
@@ -31,15 +42,56 @@ func TestCreateLimit_Success(t *testing.T) {
 	mockLimitRepo.AssertExpectations(t)
 }
 
+func TestCreateLimit_TenorNotOffered(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6}, nil)
+
+	err := limitUsecase.CreateLimit(domain.Limit{
+		LimitNIK:    "1234567890123456",
+		LimitTenor:  12,
+		LimitAmount: money.New(5000000),
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrTenorNotOffered)
+	mockTransactionRepo.AssertNotCalled(t, "WithTransaction", mock.Anything)
+}
+
+func TestCreateLimit_CustomerNotVerified(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6, 12}, nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCPending}, nil)
+
+	err := limitUsecase.CreateLimit(domain.Limit{
+		LimitNIK:    "1234567890123456",
+		LimitTenor:  12,
+		LimitAmount: money.New(5000000),
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrCustomerNotVerified)
+	mockTransactionRepo.AssertNotCalled(t, "WithTransaction", mock.Anything)
+}
+
 func TestCreateLimit_InvalidNIK(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	limit := domain.Limit{
 		LimitNIK:    "12345",
 		LimitTenor:  12,
-		LimitAmount: 5000000,
+		LimitAmount: money.New(5000000),
 	}
 
 	err := limitUsecase.CreateLimit(limit)
@@ -47,21 +99,27 @@ func TestCreateLimit_InvalidNIK(t *testing.T) {
 	assert.Error(t, err, "Limit creation should fail due to invalid NIK")
 	assert.Equal(t, "invalid NIK", err.Error(), "Expected 'invalid NIK' error message")
 
-	mockLimitRepo.AssertNotCalled(t, "CreateLimit", mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "CreateLimitWithTx", mock.Anything, mock.Anything)
 }
 
 func TestCreateLimit_DBError(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6, 12}, nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, nil)
 
 	limit := domain.Limit{
 		LimitNIK:    "1234567890123456",
 		LimitTenor:  12,
-		LimitAmount: 5000000,
+		LimitAmount: money.New(5000000),
 	}
 
-	mockLimitRepo.On("CreateLimit", mock.Anything).Return(errors.New("database error"))
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("CreateLimitWithTx", mock.Anything, mock.Anything).Return(errors.New("database error"))
 
 	err := limitUsecase.CreateLimit(limit)
 
@@ -72,18 +130,20 @@ func TestCreateLimit_DBError(t *testing.T) {
 func TestGetAllLimits_Success(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	mockLimits := []domain.Limit{
 		{
 			LimitNIK:    "1234567890123456",
 			LimitTenor:  12,
-			LimitAmount: 5000000,
+			LimitAmount: money.New(5000000),
 		},
 		{
 			LimitNIK:    "9876543210987654",
 			LimitTenor:  24,
-			LimitAmount: 10000000,
+			LimitAmount: money.New(10000000),
 		},
 	}
 
@@ -103,7 +163,9 @@ func TestGetAllLimits_Success(t *testing.T) {
 func TestGetAllLimits_DBError(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	mockLimitRepo.On("GetAllLimits", 10, 0).Return(nil, errors.New("database error"))
 
@@ -119,13 +181,15 @@ func TestGetAllLimits_DBError(t *testing.T) {
 func TestGetLimitByID_Success(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	mockLimit := &domain.Limit{
 		LimitID:     1,
 		LimitNIK:    "1234567890123456",
 		LimitTenor:  12,
-		LimitAmount: 5000000,
+		LimitAmount: money.New(5000000),
 	}
 
 	mockLimitRepo.On("GetLimitByID", uint(1)).Return(mockLimit, nil)
@@ -142,7 +206,9 @@ func TestGetLimitByID_Success(t *testing.T) {
 func TestGetLimitByID_NotFound(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	mockLimitRepo.On("GetLimitByID", uint(99)).Return(nil, gorm.ErrRecordNotFound)
 
@@ -157,7 +223,9 @@ func TestGetLimitByID_NotFound(t *testing.T) {
 func TestGetLimitByID_DBError(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	mockLimitRepo.On("GetLimitByID", uint(1)).Return(nil, errors.New("database error"))
 
@@ -172,12 +240,14 @@ func TestGetLimitByID_DBError(t *testing.T) {
 func TestGetCustomerByNIKForLimit_Success(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	mockCustomer := &domain.Customer{
 		CustomerNIK:      "1234567890123456",
 		CustomerFullName: "John Doe",
-		CustomerSalary:   5000000,
+		CustomerSalary:   money.New(5000000),
 	}
 
 	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(mockCustomer, nil)
@@ -194,7 +264,9 @@ func TestGetCustomerByNIKForLimit_Success(t *testing.T) {
 func TestGetCustomerByNIKForLimit_NotFound(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	mockCustomerRepo.On("GetCustomerByNIK", "9999999999999999").Return(nil, gorm.ErrRecordNotFound)
 
@@ -209,7 +281,9 @@ func TestGetCustomerByNIKForLimit_NotFound(t *testing.T) {
 func TestGetCustomerByNIKForLimit_DBError(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(nil, errors.New("database error"))
 
@@ -224,17 +298,32 @@ func TestGetCustomerByNIKForLimit_DBError(t *testing.T) {
 func TestUpdateLimit_Success(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	limit := domain.Limit{
 		LimitNIK:             "1234567890123456",
 		LimitTenor:           12,
-		LimitAmount:          6000000,
-		LimitUsedAmount:      1000000,
-		LimitRemainingAmount: 5000000,
+		LimitAmount:          money.New(6000000),
+		LimitUsedAmount:      money.New(1000000),
+		LimitRemainingAmount: money.New(5000000),
 	}
 
-	mockLimitRepo.On("UpdateLimit", mock.Anything).Return(nil)
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(0)).Return(&domain.Limit{
+		LimitTenor:           12,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(1000000),
+		LimitRemainingAmount: money.New(4000000),
+	}, nil)
+	mockLimitRepo.On("UpdateLimitWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.MatchedBy(func(movement *domain.LimitMovement) bool {
+		return movement.MovementLimitDelta == money.New(1000000) &&
+			movement.MovementUsedDelta.IsZero() &&
+			movement.MovementRemainingDelta == money.New(1000000) &&
+			movement.MovementRemainingBalance == money.New(5000000)
+	})).Return(nil)
 
 	err := limitUsecase.UpdateLimit(limit)
 
@@ -245,12 +334,14 @@ func TestUpdateLimit_Success(t *testing.T) {
 func TestUpdateLimit_InvalidNIK(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	limit := domain.Limit{
 		LimitNIK: "12345", // Invalid NIK
 		LimitTenor: 12,
-		LimitAmount: 6000000,
+		LimitAmount: money.New(6000000),
 	}
 
 	err := limitUsecase.UpdateLimit(limit)
@@ -262,17 +353,21 @@ func TestUpdateLimit_InvalidNIK(t *testing.T) {
 func TestUpdateLimit_DBError(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
 	limit := domain.Limit{
 		LimitNIK:             "1234567890123456",
 		LimitTenor:           12,
-		LimitAmount:          6000000,
-		LimitUsedAmount:      1000000,
-		LimitRemainingAmount: 5000000,
+		LimitAmount:          money.New(6000000),
+		LimitUsedAmount:      money.New(1000000),
+		LimitRemainingAmount: money.New(5000000),
 	}
 
-	mockLimitRepo.On("UpdateLimit", mock.Anything).Return(errors.New("database error"))
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(0)).Return(&domain.Limit{LimitTenor: 12}, nil)
+	mockLimitRepo.On("UpdateLimitWithTx", mock.Anything, mock.Anything).Return(errors.New("database error"))
 
 	err := limitUsecase.UpdateLimit(limit)
 
@@ -280,14 +375,51 @@ func TestUpdateLimit_DBError(t *testing.T) {
 	assert.Equal(t, "database error", err.Error(), "Should return database error")
 }
 
-func TestDeleteLimit_Success(t *testing.T) {
+func TestUpdateLimit_TenorNotOffered(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(1)).Return(&domain.Limit{LimitID: 1, LimitTenor: 3}, nil)
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6}, nil)
+
+	err := limitUsecase.UpdateLimit(domain.Limit{
+		LimitID:     1,
+		LimitNIK:    "1234567890123456",
+		LimitTenor:  24,
+		LimitAmount: money.New(6000000),
+	})
 
-	mockLimitRepo.On("DeleteLimit", uint(1)).Return(nil)
+	assert.ErrorIs(t, err, usecase.ErrTenorNotOffered)
+	mockLimitRepo.AssertNotCalled(t, "UpdateLimitWithTx", mock.Anything, mock.Anything)
+}
 
-	err := limitUsecase.DeleteLimit(1)
+func TestDeleteLimit_Success(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(1)).Return(&domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(1000000),
+		LimitRemainingAmount: money.New(4000000),
+	}, nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.MatchedBy(func(movement *domain.LimitMovement) bool {
+		return movement.MovementType == domain.LimitMovementExpire &&
+			movement.MovementRemainingDelta == money.New(-4000000) &&
+			movement.MovementRemainingBalance.IsZero() &&
+			movement.MovementCreatedBy == 7
+	})).Return(nil)
+	mockLimitRepo.On("DeleteLimitWithTx", mock.Anything, uint(1)).Return(nil)
+
+	err := limitUsecase.DeleteLimit(7, 1)
 
 	assert.Nil(t, err, "Limit deletion should be successful")
 	mockLimitRepo.AssertExpectations(t)
@@ -296,11 +428,15 @@ func TestDeleteLimit_Success(t *testing.T) {
 func TestDeleteLimit_NotFound(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
-	mockLimitRepo.On("DeleteLimit", uint(99)).Return(gorm.ErrRecordNotFound)
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(99)).Return(&domain.Limit{}, nil)
+	mockLimitRepo.On("DeleteLimitWithTx", mock.Anything, uint(99)).Return(gorm.ErrRecordNotFound)
 
-	err := limitUsecase.DeleteLimit(99)
+	err := limitUsecase.DeleteLimit(7, 99)
 
 	assert.Error(t, err, "Error should not be nil when deleting non-existent record")
 	assert.Equal(t, gorm.ErrRecordNotFound, err, "Should return a record not found error")
@@ -309,12 +445,292 @@ func TestDeleteLimit_NotFound(t *testing.T) {
 func TestDeleteLimit_DBError(t *testing.T) {
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
 
-	mockLimitRepo.On("DeleteLimit", uint(1)).Return(errors.New("database error"))
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, uint(1)).Return(&domain.Limit{LimitID: 1}, nil)
+	mockLimitRepo.On("DeleteLimitWithTx", mock.Anything, uint(1)).Return(errors.New("database error"))
 
-	err := limitUsecase.DeleteLimit(1)
+	err := limitUsecase.DeleteLimit(7, 1)
 
 	assert.Error(t, err, "Error should not be nil on DB error")
 	assert.Equal(t, "database error", err.Error(), "Expected database error")
 }
+
+
+func TestGetLimitMovements_LedgerConsistent(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(1000000),
+		LimitRemainingAmount: money.New(4000000),
+	}
+
+	mockLimitRepo.On("GetLimitMovements", uint(1), 50, 0).Return([]domain.LimitMovement{
+		{MovementID: 1, MovementType: domain.LimitMovementAdjust},
+		{MovementID: 2, MovementType: domain.LimitMovementConsume},
+	}, nil)
+	mockLimitRepo.On("GetLimitLedger", uint(1)).Return(&domain.LimitLedger{
+		LedgerLimitID:         1,
+		LedgerMovementCount:   2,
+		LedgerLimitAmount:     money.New(5000000),
+		LedgerUsedAmount:      money.New(1000000),
+		LedgerRemainingAmount: money.New(4000000),
+	}, nil)
+
+	movements, ledger, err := limitUsecase.GetLimitMovements(limit, 50, 0)
+
+	assert.Nil(t, err)
+	assert.Len(t, movements, 2)
+	assert.True(t, ledger.LedgerConsistent, "Ledger sums should match the stored balance")
+	mockLimitRepo.AssertExpectations(t)
+}
+
+func TestGetLimitMovements_LedgerDrift(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(5000000),
+		LimitUsedAmount:      money.New(1500000),
+		LimitRemainingAmount: money.New(3500000),
+	}
+
+	mockLimitRepo.On("GetLimitMovements", uint(1), 50, 0).Return([]domain.LimitMovement{}, nil)
+	mockLimitRepo.On("GetLimitLedger", uint(1)).Return(&domain.LimitLedger{
+		LedgerLimitID:         1,
+		LedgerLimitAmount:     money.New(5000000),
+		LedgerUsedAmount:      money.New(1000000),
+		LedgerRemainingAmount: money.New(4000000),
+	}, nil)
+
+	_, ledger, err := limitUsecase.GetLimitMovements(limit, 50, 0)
+
+	assert.Nil(t, err)
+	assert.False(t, ledger.LedgerConsistent, "Balance changed outside the ledger should be flagged")
+}
+
+func TestGetCustomerLimitSummary_CapsSubLimitsByUmbrella(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockLimitRepo.On("GetCustomerLimitByNIK", "1234567890123456").
+		Return(&domain.CustomerLimit{CustomerLimitID: 1, CustomerLimitAmount: money.New(5000000)}, nil)
+	mockLimitRepo.On("GetLimitsByNIK", "1234567890123456").Return([]domain.Limit{
+		{LimitID: 1, LimitTenor: 1, LimitAmount: money.New(3000000), LimitUsedAmount: money.New(2000000), LimitRemainingAmount: money.New(1000000)},
+		{LimitID: 2, LimitTenor: 6, LimitAmount: money.New(4000000), LimitUsedAmount: money.New(2500000), LimitRemainingAmount: money.New(1500000)},
+	}, nil)
+
+	summary, err := limitUsecase.GetCustomerLimitSummary("1234567890123456")
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(4500000), summary.TotalUsedAmount)
+	assert.Equal(t, money.New(500000), *summary.TotalRemainingAmount)
+	assert.Equal(t, money.New(500000), summary.SubLimits[0].LimitAvailableAmount, "Sub-limit should be capped by the umbrella remainder")
+	assert.Equal(t, money.New(500000), summary.SubLimits[1].LimitAvailableAmount)
+}
+
+func TestGetCustomerLimitSummary_WithoutUmbrella(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockLimitRepo.On("GetCustomerLimitByNIK", "1234567890123456").Return(&domain.CustomerLimit{}, nil)
+	mockLimitRepo.On("GetLimitsByNIK", "1234567890123456").Return([]domain.Limit{
+		{LimitID: 1, LimitTenor: 3, LimitAmount: money.New(3000000), LimitUsedAmount: money.New(1000000), LimitRemainingAmount: money.New(2000000)},
+	}, nil)
+
+	summary, err := limitUsecase.GetCustomerLimitSummary("1234567890123456")
+
+	assert.Nil(t, err)
+	assert.Nil(t, summary.CustomerLimit)
+	assert.Nil(t, summary.TotalRemainingAmount)
+	assert.Equal(t, money.New(2000000), summary.SubLimits[0].LimitAvailableAmount)
+}
+
+func TestSetCustomerLimit_CreatesUmbrella(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockLimitRepo.On("GetCustomerLimitByNIK", "1234567890123456").Return(&domain.CustomerLimit{}, nil)
+	mockLimitRepo.On("SaveCustomerLimit", mock.MatchedBy(func(customerLimit *domain.CustomerLimit) bool {
+		return customerLimit.CustomerLimitNIK == "1234567890123456" &&
+			customerLimit.CustomerLimitAmount == money.New(8000000) &&
+			customerLimit.CustomerLimitCreatedBy == 1
+	})).Return(nil)
+
+	customerLimit, err := limitUsecase.SetCustomerLimit(1, "1234567890123456", money.New(8000000))
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(8000000), customerLimit.CustomerLimitAmount)
+	mockLimitRepo.AssertExpectations(t)
+}
+
+func TestSetCustomerLimit_InvalidAmount(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	_, err := limitUsecase.SetCustomerLimit(1, "1234567890123456", money.Money{})
+
+	assert.Error(t, err)
+	mockLimitRepo.AssertNotCalled(t, "SaveCustomerLimit", mock.Anything)
+}
+
+func TestCreateLimit_Duplicate(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6, 12}, nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, nil)
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("CreateLimitWithTx", mock.Anything, mock.Anything).Return(&pgconn.PgError{Code: "23505"})
+
+	err := limitUsecase.CreateLimit(domain.Limit{LimitNIK: "1234567890123456", LimitTenor: 3, LimitAmount: money.New(5000000)})
+
+	assert.ErrorIs(t, err, usecase.ErrLimitAlreadyExists)
+}
+
+func TestUpsertLimit_CreatesMissingLimit(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6, 12}, nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, nil)
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("CreateLimitIfAbsentWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitNIK == "1234567890123456" && limit.LimitTenor == 3 && limit.LimitRemainingAmount == money.New(5000000)
+	})).Return(true, nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.MatchedBy(func(movement *domain.LimitMovement) bool {
+		return movement.MovementLimitDelta == money.New(5000000) && movement.MovementReason == "limit opened"
+	})).Return(nil)
+
+	limit, created, err := limitUsecase.UpsertLimit(1, "1234567890123456", 3, money.New(5000000))
+
+	assert.Nil(t, err)
+	assert.True(t, created)
+	assert.Equal(t, money.New(5000000), limit.LimitAmount)
+	mockLimitRepo.AssertNotCalled(t, "GetLimitByNIKandTenorWithTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpsertLimit_AdjustsExistingLimit(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6, 12}, nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, nil)
+
+	existing := &domain.Limit{LimitID: 4, LimitNIK: "1234567890123456", LimitTenor: 3, LimitAmount: money.New(5000000), LimitUsedAmount: money.New(2000000), LimitRemainingAmount: money.New(3000000)}
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("CreateLimitIfAbsentWithTx", mock.Anything, mock.Anything).Return(false, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenorWithTx", mock.Anything, "1234567890123456", 3.0).Return(existing, nil)
+	mockLimitRepo.On("UpdateLimitWithTx", mock.Anything, existing).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.MatchedBy(func(movement *domain.LimitMovement) bool {
+		return movement.MovementLimitID == 4 && movement.MovementLimitDelta == money.New(2000000) && movement.MovementRemainingDelta == money.New(2000000)
+	})).Return(nil)
+
+	limit, created, err := limitUsecase.UpsertLimit(1, "1234567890123456", 3, money.New(7000000))
+
+	assert.Nil(t, err)
+	assert.False(t, created)
+	assert.Equal(t, money.New(7000000), limit.LimitAmount)
+	assert.Equal(t, money.New(2000000), limit.LimitUsedAmount, "Usage should be preserved")
+	assert.Equal(t, money.New(5000000), limit.LimitRemainingAmount)
+	mockLimitRepo.AssertExpectations(t)
+}
+
+func TestUpsertLimit_SameAmountIsNoop(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6, 12}, nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, nil)
+
+	existing := &domain.Limit{LimitID: 4, LimitNIK: "1234567890123456", LimitTenor: 3, LimitAmount: money.New(5000000), LimitRemainingAmount: money.New(5000000)}
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("CreateLimitIfAbsentWithTx", mock.Anything, mock.Anything).Return(false, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenorWithTx", mock.Anything, "1234567890123456", 3.0).Return(existing, nil)
+
+	_, created, err := limitUsecase.UpsertLimit(1, "1234567890123456", 3, money.New(5000000))
+
+	assert.Nil(t, err)
+	assert.False(t, created)
+	mockLimitRepo.AssertNotCalled(t, "UpdateLimitWithTx", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "CreateLimitMovementWithTx", mock.Anything, mock.Anything)
+}
+
+func TestMergeDuplicateLimits_KeepsOldestAndSumsUsage(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList())
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetDuplicateLimitKeys").Return([]domain.LimitKey{{LimitNIK: "1234567890123456", LimitTenor: 3}}, nil)
+	mockLimitRepo.On("GetLimitsByNIKandTenorWithTx", mock.Anything, "1234567890123456", 3).Return([]domain.Limit{
+		{LimitID: 2, LimitNIK: "1234567890123456", LimitTenor: 3, LimitAmount: money.New(5000000), LimitUsedAmount: money.New(1000000), LimitRemainingAmount: money.New(4000000), LimitCreatedBy: 1},
+		{LimitID: 7, LimitNIK: "1234567890123456", LimitTenor: 3, LimitAmount: money.New(6000000), LimitUsedAmount: money.New(2000000), LimitRemainingAmount: money.New(4000000), LimitCreatedBy: 1},
+	}, nil)
+	mockTransactionRepo.On("ReassignTransactionsLimitWithTx", mock.Anything, []uint{7}, uint(2)).Return(nil)
+	mockLimitRepo.On("ReassignLimitMovementsWithTx", mock.Anything, []uint{7}, uint(2)).Return(nil)
+	mockLimitRepo.On("DeleteLimitWithTx", mock.Anything, uint(7)).Return(nil)
+	mockLimitRepo.On("UpdateLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 2 &&
+			limit.LimitAmount == money.New(6000000) &&
+			limit.LimitUsedAmount == money.New(3000000) &&
+			limit.LimitRemainingAmount == money.New(3000000)
+	})).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.MatchedBy(func(movement *domain.LimitMovement) bool {
+		return movement.MovementLimitID == 2 &&
+			movement.MovementLimitDelta == money.New(-5000000) &&
+			movement.MovementRemainingDelta == money.New(-5000000)
+	})).Return(nil)
+
+	result, err := limitUsecase.MergeDuplicateLimits()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.DuplicateKeys)
+	assert.Equal(t, 1, result.MergedLimits)
+	assert.Equal(t, uint(2), result.Merges[0].SurvivorLimitID)
+	mockLimitRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
@@ -0,0 +1,137 @@
+package usecase_test
+
+import (
+	"errors"
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestRunOverdueJob_AccruesPenaltyAndClassifies(t *testing.T) {
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	overdueUsecase := usecase.NewOverdueUsecase(mockInstallmentRepo, mockTransactionRepo, config.PenaltyConfig{DailyRate: 0.1})
+
+	installments := []domain.Installment{
+		{InstallmentID: 1, InstallmentNumber: 1, InstallmentDueDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(20000), InstallmentFee: money.New(5000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentID: 2, InstallmentNumber: 2, InstallmentDueDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(20000), InstallmentFee: money.New(5000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}
+
+	mockTransactionRepo.On("GetTransactionIDsForOverdueReview").Return([]uint{1}, nil)
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return(installments, nil)
+
+	var updated []domain.Installment
+	mockInstallmentRepo.On("UpdateInstallmentPenaltyWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			updated = append(updated, *args.Get(1).(*domain.Installment))
+		}).
+		Return(nil)
+	mockTransactionRepo.On("UpdateTransactionDelinquencyWithTx", mock.Anything, uint(1), 10, domain.Collectibility1To30).Return(nil)
+
+	result, err := overdueUsecase.RunOverdueJob(time.Date(2025, 1, 11, 9, 30, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.ProcessedTransactions)
+	assert.Equal(t, 1, result.DelinquentTransactions)
+	assert.Equal(t, money.New(1250), result.PenaltyAccrued, "0.1% per day of 125000 for 10 days")
+
+	assert.Len(t, updated, 1, "Installments not yet due should not be touched")
+	assert.Equal(t, money.New(1250), updated[0].InstallmentPenalty)
+	assert.Equal(t, time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC), *updated[0].InstallmentPenaltyUntil)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestRunOverdueJob_DoesNotAccrueTwiceForSameDay(t *testing.T) {
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	overdueUsecase := usecase.NewOverdueUsecase(mockInstallmentRepo, mockTransactionRepo, config.PenaltyConfig{DailyRate: 0.1})
+
+	accruedUntil := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+	installments := []domain.Installment{
+		{InstallmentID: 1, InstallmentNumber: 1, InstallmentDueDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentPenalty: money.New(1250), InstallmentPenaltyUntil: &accruedUntil, InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}
+
+	mockTransactionRepo.On("GetTransactionIDsForOverdueReview").Return([]uint{1}, nil)
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return(installments, nil)
+	mockTransactionRepo.On("UpdateTransactionDelinquencyWithTx", mock.Anything, uint(1), 10, domain.Collectibility1To30).Return(nil)
+
+	result, err := overdueUsecase.RunOverdueJob(time.Date(2025, 1, 11, 18, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(0), result.PenaltyAccrued)
+	mockInstallmentRepo.AssertNotCalled(t, "UpdateInstallmentPenaltyWithTx", mock.Anything, mock.Anything)
+}
+
+func TestRunOverdueJob_GraceDaysAndFailures(t *testing.T) {
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	overdueUsecase := usecase.NewOverdueUsecase(mockInstallmentRepo, mockTransactionRepo, config.PenaltyConfig{DailyRate: 0.1, GraceDays: 5})
+
+	mockTransactionRepo.On("GetTransactionIDsForOverdueReview").Return([]uint{1, 2}, nil)
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentID: 1, InstallmentNumber: 1, InstallmentDueDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentStatus: domain.InstallmentStatusPartial},
+	}, nil)
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(2)).Return(nil, errors.New("database error"))
+	mockInstallmentRepo.On("UpdateInstallmentPenaltyWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("UpdateTransactionDelinquencyWithTx", mock.Anything, uint(1), 10, domain.Collectibility1To30).Return(nil)
+
+	result, err := overdueUsecase.RunOverdueJob(time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.ProcessedTransactions)
+	assert.Equal(t, 1, result.FailedTransactions)
+	assert.Equal(t, money.New(500), result.PenaltyAccrued, "Penalty should only accrue after the grace period")
+}
+
+func TestRunOverdueJob_ClearsPaidTransaction(t *testing.T) {
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	overdueUsecase := usecase.NewOverdueUsecase(mockInstallmentRepo, mockTransactionRepo, config.PenaltyConfig{DailyRate: 0.1})
+
+	mockTransactionRepo.On("GetTransactionIDsForOverdueReview").Return([]uint{1}, nil)
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{}, nil)
+	mockTransactionRepo.On("UpdateTransactionDelinquencyWithTx", mock.Anything, uint(1), 0, domain.CollectibilityCurrent).Return(nil)
+
+	result, err := overdueUsecase.RunOverdueJob(time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, result.DelinquentTransactions)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestCollectibilityBucket(t *testing.T) {
+	assert.Equal(t, domain.CollectibilityCurrent, domain.CollectibilityBucket(0))
+	assert.Equal(t, domain.Collectibility1To30, domain.CollectibilityBucket(1))
+	assert.Equal(t, domain.Collectibility1To30, domain.CollectibilityBucket(30))
+	assert.Equal(t, domain.Collectibility31To60, domain.CollectibilityBucket(31))
+	assert.Equal(t, domain.Collectibility61To90, domain.CollectibilityBucket(90))
+	assert.Equal(t, domain.CollectibilityOver90, domain.CollectibilityBucket(91))
+}
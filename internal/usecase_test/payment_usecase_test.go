@@ -0,0 +1,260 @@
+package usecase_test
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreatePayment_AllocatesPenaltyInterestThenPrincipal(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	paymentUsecase := usecase.NewPaymentUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo)
+
+	transaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX12345",
+		TransactionLimit:          1,
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(1000000),
+		LimitUsedAmount:      money.New(500000),
+		LimitRemainingAmount: money.New(500000),
+	}
+
+	installments := []domain.Installment{
+		{InstallmentID: 1, InstallmentNumber: 1, InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(20000), InstallmentFee: money.New(5000), InstallmentPenalty: money.New(10000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentID: 2, InstallmentNumber: 2, InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(20000), InstallmentFee: money.New(5000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(transaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return(installments, nil)
+
+	var updated []domain.Installment
+	mockInstallmentRepo.On("UpdateInstallmentWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			updated = append(updated, *args.Get(1).(*domain.Installment))
+		}).
+		Return(nil)
+	mockPaymentRepo.On("CreatePaymentWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(l *domain.Limit) bool { return l.LimitID == 1 }), mock.Anything).Return(releaseTo(limit))
+
+	payment, err := paymentUsecase.CreatePayment(1, transaction, domain.PaymentInput{
+		PaymentAmount:  money.New(150000),
+		PaymentChannel: "bank_transfer",
+		PaymentPaidAt:  "2025-01-10",
+	})
+
+	assert.Nil(t, err, "Payment should be recorded successfully")
+	assert.Equal(t, money.New(10000), payment.PaymentPenaltyAmount)
+	assert.Equal(t, money.New(35000), payment.PaymentInterestAmount)
+	assert.Equal(t, money.New(5000), payment.PaymentFeeAmount)
+	assert.Equal(t, money.New(100000), payment.PaymentPrincipalAmount)
+
+	assert.Len(t, updated, 2)
+	assert.Equal(t, domain.InstallmentStatusPaid, updated[0].InstallmentStatus)
+	assert.NotNil(t, updated[0].InstallmentPaidAt)
+	assert.Equal(t, domain.InstallmentStatusPartial, updated[1].InstallmentStatus)
+	assert.Equal(t, money.New(15000), updated[1].InstallmentPaidInterest)
+	assert.Equal(t, money.New(0), updated[1].InstallmentPaidPrincipal)
+
+	assert.Equal(t, money.New(400000), limit.LimitUsedAmount, "Only the principal portion should be released")
+	assert.Equal(t, money.New(600000), limit.LimitRemainingAmount)
+}
+
+func TestCreatePayment_ExceedsOutstanding(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	paymentUsecase := usecase.NewPaymentUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo)
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusActive}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(transaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentID: 1, InstallmentNumber: 1, InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(20000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+
+	payment, err := paymentUsecase.CreatePayment(1, transaction, domain.PaymentInput{
+		PaymentAmount:  money.New(200000),
+		PaymentChannel: "cash",
+	})
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, usecase.ErrPaymentExceedsOutstanding)
+	mockPaymentRepo.AssertNotCalled(t, "CreatePaymentWithTx", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreatePayment_NoOutstandingInstallments(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	paymentUsecase := usecase.NewPaymentUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo)
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusActive}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(transaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{}, nil)
+
+	payment, err := paymentUsecase.CreatePayment(1, transaction, domain.PaymentInput{
+		PaymentAmount:  money.New(1000),
+		PaymentChannel: "cash",
+	})
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, usecase.ErrNoOutstandingInstallments)
+}
+
+func TestCreatePayment_FinalPaymentPaysOffTransaction(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	paymentUsecase := usecase.NewPaymentUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo)
+
+	transaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX12345",
+		TransactionLimit:          1,
+		TransactionOTR:            money.New(200000),
+		TransactionAdminFee:       money.New(10000),
+		TransactionInstallment:    2,
+		TransactionInterest:       10,
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(1000000),
+		LimitUsedAmount:      money.New(150000),
+		LimitRemainingAmount: money.New(850000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(transaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentID: 2, InstallmentNumber: 2, InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(20000), InstallmentFee: money.New(5000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+	mockInstallmentRepo.On("UpdateInstallmentWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockPaymentRepo.On("CreatePaymentWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, transaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.MatchedBy(func(history *domain.TransactionStatusHistory) bool {
+		return history.HistoryFromStatus == domain.TransactionStatusActive && history.HistoryToStatus == domain.TransactionStatusPaidOff
+	})).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(l *domain.Limit) bool { return l.LimitID == 1 }), mock.Anything).Return(releaseTo(limit))
+
+	_, err := paymentUsecase.CreatePayment(1, transaction, domain.PaymentInput{
+		PaymentAmount:  money.New(125000),
+		PaymentChannel: "bank_transfer",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.TransactionStatusPaidOff, transaction.TransactionStatus)
+	assert.Equal(t, money.New(0), limit.LimitUsedAmount, "Interest and fee consumption should be released on pay off")
+	assert.Equal(t, money.New(1000000), limit.LimitRemainingAmount)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestCreatePayment_RejectsInactiveTransaction(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	paymentUsecase := usecase.NewPaymentUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo)
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusCancelled}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(transaction))
+
+	payment, err := paymentUsecase.CreatePayment(1, transaction, domain.PaymentInput{
+		PaymentAmount:  money.New(1000),
+		PaymentChannel: "cash",
+	})
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, usecase.ErrTransactionNotActive)
+	mockInstallmentRepo.AssertNotCalled(t, "GetOutstandingInstallmentsWithTx", mock.Anything, mock.Anything)
+}
+
+func TestCreatePayment_StaleStatusRechecksLockedRow(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	paymentUsecase := usecase.NewPaymentUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo)
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).
+		Return(&domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusPaidOff}, nil)
+
+	payment, err := paymentUsecase.CreatePayment(1, &domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusActive}, domain.PaymentInput{
+		PaymentAmount:  money.New(1000),
+		PaymentChannel: "cash",
+	})
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, usecase.ErrTransactionNotActive, "A concurrent pay off should be caught on the locked row")
+	mockInstallmentRepo.AssertNotCalled(t, "GetOutstandingInstallmentsWithTx", mock.Anything, mock.Anything)
+	mockPaymentRepo.AssertNotCalled(t, "CreatePaymentWithTx", mock.Anything, mock.Anything)
+}
+
+func TestGetPaymentsByTransactionID_DBError(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	paymentUsecase := usecase.NewPaymentUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo)
+
+	mockPaymentRepo.On("GetPaymentsByTransactionID", uint(1)).Return(nil, errors.New("database error"))
+
+	payments, err := paymentUsecase.GetPaymentsByTransactionID(1)
+
+	assert.Nil(t, payments)
+	assert.Equal(t, "database error", err.Error())
+}
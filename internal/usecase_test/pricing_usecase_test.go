@@ -0,0 +1,159 @@
+package usecase_test
+
+import (
+	"errors"
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func createDraftWithPricing(t *testing.T, product *domain.Product, rules []domain.PricingRule, customer *domain.Customer, input domain.TransactionInput) *domain.Transaction {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(rules...), negativeList(), config.AffordabilityConfig{})
+
+	customer.CustomerKYCStatus = domain.CustomerKYCVerified
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(&domain.Limit{LimitID: 1}, nil)
+
+	var created *domain.Transaction
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*domain.Transaction)
+		}).
+		Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, product, input)
+
+	assert.Nil(t, err)
+	return created
+}
+
+func pricedProduct() *domain.Product {
+	return &domain.Product{
+		ProductID:     1,
+		ProductCode:   "motorcycle",
+		ProductMaxOTR: money.New(60000000),
+		ProductActive: true,
+		ProductTenors: []domain.ProductTenor{
+			{TenorMonths: 3, TenorInterestRate: 2.5, TenorAdminFee: money.New(150000)},
+			{TenorMonths: 6, TenorInterestRate: 2.5, TenorAdminFee: money.New(150000)},
+		},
+	}
+}
+
+func draftInput() domain.TransactionInput {
+	return domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(10000000),
+		TransactionInstallment: 3,
+		TransactionAssetName:   "Motorcycle",
+		TransactionStatus:      domain.TransactionStatusDraft,
+	}
+}
+
+func TestCreateTransaction_AppliesMostSpecificPricingRule(t *testing.T) {
+	rules := []domain.PricingRule{
+		{PricingRuleID: 1, PricingProductID: 1, PricingAdminFee: money.New(100000), PricingInterestRate: 2.0, PricingActive: true},
+		{PricingRuleID: 2, PricingProductID: 1, PricingRiskGrade: "B", PricingAdminFee: money.New(80000), PricingInterestRate: 1.5, PricingActive: true},
+		{PricingRuleID: 3, PricingProductID: 1, PricingTenor: 3, PricingPriority: 10, PricingAdminFee: money.New(90000), PricingInterestRate: 1.8, PricingActive: true},
+		{PricingRuleID: 4, PricingProductID: 1, PricingTenor: 3, PricingRiskGrade: "B", PricingMinOTR: money.New(20000000), PricingAdminFee: money.New(50000), PricingInterestRate: 1.0, PricingActive: true},
+	}
+
+	created := createDraftWithPricing(t, pricedProduct(), rules, &domain.Customer{CustomerNIK: "1234567890123456", CustomerRiskGrade: "B"}, draftInput())
+
+	assert.Equal(t, money.New(80000), created.TransactionAdminFee)
+	assert.Equal(t, 1.5, created.TransactionInterest)
+	assert.Equal(t, uint(2), *created.TransactionPricingRuleID, "Grade rule should win over tenor rule; OTR band excludes rule 4")
+	assert.False(t, created.TransactionPricingOverride)
+}
+
+func TestCreateTransaction_FallsBackToProductTenorPricing(t *testing.T) {
+	rules := []domain.PricingRule{
+		{PricingRuleID: 1, PricingProductID: 1, PricingTenor: 6, PricingAdminFee: money.New(100000), PricingInterestRate: 2.0, PricingActive: true},
+		{PricingRuleID: 2, PricingProductID: 1, PricingRiskGrade: "A", PricingAdminFee: money.New(80000), PricingInterestRate: 1.5, PricingActive: true},
+	}
+
+	created := createDraftWithPricing(t, pricedProduct(), rules, &domain.Customer{CustomerNIK: "1234567890123456", CustomerRiskGrade: "C"}, draftInput())
+
+	assert.Equal(t, money.New(150000), created.TransactionAdminFee)
+	assert.Equal(t, 2.5, created.TransactionInterest)
+	assert.Nil(t, created.TransactionPricingRuleID)
+}
+
+func TestCreateTransaction_RecordsPricingOverride(t *testing.T) {
+	rules := []domain.PricingRule{
+		{PricingRuleID: 7, PricingProductID: 1, PricingAdminFee: money.New(100000), PricingInterestRate: 2.0, PricingActive: true},
+	}
+	input := draftInput()
+	input.TransactionAdminFee = money.New(25000)
+
+	created := createDraftWithPricing(t, pricedProduct(), rules, &domain.Customer{CustomerNIK: "1234567890123456"}, input)
+
+	assert.Equal(t, money.New(25000), created.TransactionAdminFee)
+	assert.Equal(t, 2.0, created.TransactionInterest, "Interest should still come from the rule")
+	assert.Equal(t, uint(7), *created.TransactionPricingRuleID)
+	assert.True(t, created.TransactionPricingOverride)
+}
+
+func TestCreatePricingRule_Success(t *testing.T) {
+	mockPricingRuleRepo := new(mocks.PricingRuleRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	pricingUsecase := usecase.NewPricingUsecase(mockPricingRuleRepo, mockProductRepo)
+
+	mockProductRepo.On("GetProductByID", uint(1)).Return(pricedProduct(), nil)
+	mockPricingRuleRepo.On("CreatePricingRule", mock.Anything).Return(nil)
+
+	rule, err := pricingUsecase.CreatePricingRule(1, domain.PricingRuleInput{
+		PricingProductID:    1,
+		PricingTenor:        6,
+		PricingMaxOTR:       money.New(30000000),
+		PricingRiskGrade:    "A",
+		PricingAdminFee:     money.New(75000),
+		PricingInterestRate: 1.25,
+	})
+
+	assert.Nil(t, err)
+	assert.True(t, rule.PricingActive)
+	assert.Equal(t, 6, rule.PricingTenor)
+	assert.Equal(t, money.New(75000), rule.PricingAdminFee)
+	mockPricingRuleRepo.AssertExpectations(t)
+}
+
+func TestCreatePricingRule_TenorNotOffered(t *testing.T) {
+	mockPricingRuleRepo := new(mocks.PricingRuleRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	pricingUsecase := usecase.NewPricingUsecase(mockPricingRuleRepo, mockProductRepo)
+
+	mockProductRepo.On("GetProductByID", uint(1)).Return(pricedProduct(), nil)
+
+	rule, err := pricingUsecase.CreatePricingRule(1, domain.PricingRuleInput{PricingProductID: 1, PricingTenor: 12})
+
+	assert.Nil(t, rule)
+	assert.ErrorIs(t, err, usecase.ErrTenorNotOffered)
+	mockPricingRuleRepo.AssertNotCalled(t, "CreatePricingRule", mock.Anything)
+}
+
+func TestCreatePricingRule_ProductNotFound(t *testing.T) {
+	mockPricingRuleRepo := new(mocks.PricingRuleRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	pricingUsecase := usecase.NewPricingUsecase(mockPricingRuleRepo, mockProductRepo)
+
+	mockProductRepo.On("GetProductByID", uint(9)).Return(nil, errors.New("record not found"))
+
+	rule, err := pricingUsecase.CreatePricingRule(1, domain.PricingRuleInput{PricingProductID: 9})
+
+	assert.Nil(t, rule)
+	assert.ErrorIs(t, err, usecase.ErrPricingProductNotFound)
+}
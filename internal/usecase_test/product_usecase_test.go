@@ -0,0 +1,103 @@
+package usecase_test
+
+import (
+	"errors"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func productInput() domain.ProductInput {
+	return domain.ProductInput{
+		ProductCode:   " Motorcycle ",
+		ProductName:   "Motorcycle Financing",
+		ProductMinOTR: money.New(10000000),
+		ProductMaxOTR: money.New(60000000),
+		ProductTenors: []domain.ProductTenorInput{
+			{TenorMonths: 24, TenorInterestRate: 1.8, TenorAdminFee: money.New(750000)},
+			{TenorMonths: 12, TenorInterestRate: 1.5, TenorAdminFee: money.New(500000)},
+		},
+	}
+}
+
+func TestCreateProduct_Success(t *testing.T) {
+	mockProductRepo := new(mocks.ProductRepository)
+	productUsecase := usecase.NewProductUsecase(mockProductRepo)
+
+	mockProductRepo.On("CreateProduct", mock.Anything).Return(nil)
+
+	product, err := productUsecase.CreateProduct(1, productInput())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "motorcycle", product.ProductCode)
+	assert.True(t, product.ProductActive)
+	assert.Equal(t, uint(1), product.ProductCreatedBy)
+	assert.Len(t, product.ProductTenors, 2)
+	assert.Equal(t, 12, product.ProductTenors[0].TenorMonths, "Tenors should be sorted by months")
+	assert.Equal(t, money.New(500000), product.ProductTenors[0].TenorAdminFee)
+	mockProductRepo.AssertExpectations(t)
+}
+
+func TestCreateProduct_InvalidOTRRange(t *testing.T) {
+	mockProductRepo := new(mocks.ProductRepository)
+	productUsecase := usecase.NewProductUsecase(mockProductRepo)
+
+	input := productInput()
+	input.ProductMaxOTR = money.New(5000000)
+
+	product, err := productUsecase.CreateProduct(1, input)
+
+	assert.Nil(t, product)
+	assert.ErrorIs(t, err, usecase.ErrInvalidOTRRange)
+	mockProductRepo.AssertNotCalled(t, "CreateProduct", mock.Anything)
+}
+
+func TestCreateProduct_DuplicateCode(t *testing.T) {
+	mockProductRepo := new(mocks.ProductRepository)
+	productUsecase := usecase.NewProductUsecase(mockProductRepo)
+
+	mockProductRepo.On("CreateProduct", mock.Anything).Return(&pgconn.PgError{Code: "23505"})
+
+	product, err := productUsecase.CreateProduct(1, productInput())
+
+	assert.Nil(t, product)
+	assert.ErrorIs(t, err, usecase.ErrProductAlreadyExists)
+}
+
+func TestUpdateProduct_Success(t *testing.T) {
+	mockProductRepo := new(mocks.ProductRepository)
+	productUsecase := usecase.NewProductUsecase(mockProductRepo)
+
+	product := &domain.Product{ProductID: 3, ProductCode: "motorcycle", ProductActive: true}
+	input := productInput()
+	inactive := false
+	input.ProductActive = &inactive
+
+	mockProductRepo.On("UpdateProduct", product).Return(nil)
+
+	err := productUsecase.UpdateProduct(2, product, input)
+
+	assert.Nil(t, err)
+	assert.False(t, product.ProductActive)
+	assert.Equal(t, uint(2), *product.ProductEditedBy)
+	assert.NotNil(t, product.ProductEditedAt)
+	assert.Equal(t, uint(3), product.ProductTenors[0].TenorProductID)
+}
+
+func TestUpdateProduct_DBError(t *testing.T) {
+	mockProductRepo := new(mocks.ProductRepository)
+	productUsecase := usecase.NewProductUsecase(mockProductRepo)
+
+	product := &domain.Product{ProductID: 3}
+	mockProductRepo.On("UpdateProduct", product).Return(errors.New("database error"))
+
+	err := productUsecase.UpdateProduct(2, product, productInput())
+
+	assert.EqualError(t, err, "database error")
+}
@@ -0,0 +1,295 @@
+package usecase_test
+
+import (
+	"errors"
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func negativeList(entries ...domain.NegativeListEntry) *mocks.NegativeListRepository {
+	negativeListRepo := new(mocks.NegativeListRepository)
+	negativeListRepo.On("FindActiveMatches", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(entries, nil)
+	return negativeListRepo
+}
+
+func negativeListEntry(id uint, listType, value, severity string) domain.NegativeListEntry {
+	return domain.NegativeListEntry{
+		NegativeListID:       id,
+		NegativeListType:     listType,
+		NegativeListValue:    value,
+		NegativeListReason:   "confirmed fraud",
+		NegativeListSource:   "fraud_team",
+		NegativeListSeverity: severity,
+		NegativeListActive:   true,
+	}
+}
+
+func TestCreateNegativeListEntry_NormalizesValue(t *testing.T) {
+	negativeListRepo := new(mocks.NegativeListRepository)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, new(mocks.CustomerRepository))
+
+	negativeListRepo.On("CreateNegativeListEntry", mock.MatchedBy(func(entry *domain.NegativeListEntry) bool {
+		return entry.NegativeListValue == "budi santoso" && entry.NegativeListActive && entry.NegativeListCreatedBy == 7
+	})).Return(nil)
+
+	entry, err := screeningUsecase.CreateNegativeListEntry(7, domain.NegativeListInput{
+		NegativeListType:     domain.NegativeListTypeName,
+		NegativeListValue:    "Santoso, Dr. Budi",
+		NegativeListReason:   "confirmed fraud",
+		NegativeListSource:   "fraud_team",
+		NegativeListSeverity: domain.NegativeListSeverityBlock,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "budi santoso", entry.NegativeListValue)
+	negativeListRepo.AssertExpectations(t)
+}
+
+func TestCreateNegativeListEntry_NormalizesPhone(t *testing.T) {
+	negativeListRepo := new(mocks.NegativeListRepository)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, new(mocks.CustomerRepository))
+
+	negativeListRepo.On("CreateNegativeListEntry", mock.Anything).Return(nil)
+
+	entry, err := screeningUsecase.CreateNegativeListEntry(1, domain.NegativeListInput{
+		NegativeListType:     domain.NegativeListTypePhone,
+		NegativeListValue:    "0812-3456-7890",
+		NegativeListReason:   "mule account",
+		NegativeListSource:   "ojk",
+		NegativeListSeverity: domain.NegativeListSeverityFlag,
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "6281234567890", entry.NegativeListValue)
+}
+
+func TestCreateNegativeListEntry_EmptyValue(t *testing.T) {
+	negativeListRepo := new(mocks.NegativeListRepository)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, new(mocks.CustomerRepository))
+
+	_, err := screeningUsecase.CreateNegativeListEntry(1, domain.NegativeListInput{
+		NegativeListType:     domain.NegativeListTypeName,
+		NegativeListValue:    "Dr. H.",
+		NegativeListReason:   "confirmed fraud",
+		NegativeListSource:   "fraud_team",
+		NegativeListSeverity: domain.NegativeListSeverityBlock,
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidNegativeListValue)
+	negativeListRepo.AssertNotCalled(t, "CreateNegativeListEntry", mock.Anything)
+}
+
+func TestUpdateNegativeListEntry_RejectsPastExpiry(t *testing.T) {
+	negativeListRepo := new(mocks.NegativeListRepository)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, new(mocks.CustomerRepository))
+
+	entry := negativeListEntry(1, domain.NegativeListTypeNIK, "3171014509900001", domain.NegativeListSeverityBlock)
+	expired := time.Now().Add(-time.Hour)
+
+	err := screeningUsecase.UpdateNegativeListEntry(1, &entry, domain.NegativeListInput{
+		NegativeListType:      domain.NegativeListTypeNIK,
+		NegativeListValue:     "3171014509900001",
+		NegativeListReason:    "confirmed fraud",
+		NegativeListSource:    "fraud_team",
+		NegativeListSeverity:  domain.NegativeListSeverityBlock,
+		NegativeListExpiresAt: &expired,
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrNegativeListExpired)
+	negativeListRepo.AssertNotCalled(t, "UpdateNegativeListEntry", mock.Anything)
+}
+
+func TestUpdateNegativeListEntry_Deactivates(t *testing.T) {
+	negativeListRepo := new(mocks.NegativeListRepository)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, new(mocks.CustomerRepository))
+
+	entry := negativeListEntry(1, domain.NegativeListTypeNIK, "3171014509900001", domain.NegativeListSeverityBlock)
+	inactive := false
+	negativeListRepo.On("UpdateNegativeListEntry", &entry).Return(nil)
+
+	err := screeningUsecase.UpdateNegativeListEntry(2, &entry, domain.NegativeListInput{
+		NegativeListType:     domain.NegativeListTypeNIK,
+		NegativeListValue:    "3171014509900001",
+		NegativeListReason:   "cleared after review",
+		NegativeListSource:   "fraud_team",
+		NegativeListSeverity: domain.NegativeListSeverityBlock,
+		NegativeListActive:   &inactive,
+	})
+
+	assert.Nil(t, err)
+	assert.False(t, entry.NegativeListActive)
+	assert.Equal(t, uint(2), *entry.NegativeListEditedBy)
+	negativeListRepo.AssertExpectations(t)
+}
+
+func TestScreenCustomer_Clear(t *testing.T) {
+	screeningUsecase := usecase.NewScreeningUsecase(negativeList(), new(mocks.CustomerRepository))
+	customer := onboardingCustomer()
+
+	result, err := screeningUsecase.ScreenCustomer(&customer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.ScreeningDecisionClear, result.Decision)
+	assert.Empty(t, result.Hits)
+}
+
+func TestScreenCustomer_MatchesNormalizedNameAndPhone(t *testing.T) {
+	negativeListRepo := negativeList(
+		negativeListEntry(1, domain.NegativeListTypeName, "rahayu siti", domain.NegativeListSeverityFlag),
+		negativeListEntry(2, domain.NegativeListTypePhone, "6281234567890", domain.NegativeListSeverityBlock),
+	)
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, new(mocks.CustomerRepository))
+	customer := onboardingCustomer()
+
+	result, err := screeningUsecase.ScreenCustomer(&customer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.ScreeningDecisionBlock, result.Decision, "Any blocking hit blocks")
+	assert.Len(t, result.Hits, 2)
+	assert.Equal(t, "customer_full_name", result.Hits[0].MatchedField)
+	assert.Equal(t, "customer_phone", result.Hits[1].MatchedField)
+	negativeListRepo.AssertCalled(t, "FindActiveMatches", "3171014509900001", []string{"rahayu siti"}, []string{"6281234567890"}, mock.Anything)
+}
+
+func TestScreenCustomer_IgnoresExpiredEntries(t *testing.T) {
+	expired := time.Now().Add(-time.Minute)
+	entry := negativeListEntry(1, domain.NegativeListTypeNIK, "3171014509900001", domain.NegativeListSeverityBlock)
+	entry.NegativeListExpiresAt = &expired
+	screeningUsecase := usecase.NewScreeningUsecase(negativeList(entry), new(mocks.CustomerRepository))
+	customer := onboardingCustomer()
+
+	result, err := screeningUsecase.ScreenCustomer(&customer)
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.ScreeningDecisionClear, result.Decision)
+}
+
+func TestScreenCustomer_LookupError(t *testing.T) {
+	negativeListRepo := new(mocks.NegativeListRepository)
+	negativeListRepo.On("FindActiveMatches", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New("db error"))
+	screeningUsecase := usecase.NewScreeningUsecase(negativeListRepo, new(mocks.CustomerRepository))
+	customer := onboardingCustomer()
+
+	result, err := screeningUsecase.ScreenCustomer(&customer)
+
+	assert.Nil(t, result)
+	assert.EqualError(t, err, "db error")
+}
+
+func TestCreateCustomer_NegativeListBlocked(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(
+		negativeListEntry(1, domain.NegativeListTypeNIK, "3171014509900001", domain.NegativeListSeverityBlock),
+	), duplicateConfig())
+
+	_, err := customerUsecase.CreateCustomer(onboardingCustomer())
+
+	var screeningErr *usecase.ScreeningError
+	assert.ErrorAs(t, err, &screeningErr)
+	assert.ErrorIs(t, err, usecase.ErrNegativeListBlocked)
+	assert.Equal(t, "customer_nik", screeningErr.Result.Hits[0].MatchedField)
+	customerRepo.AssertNotCalled(t, "CreateCustomer", mock.Anything)
+}
+
+func TestCreateCustomer_NegativeListFlagBlocksAutoApproval(t *testing.T) {
+	customerRepo := new(mocks.CustomerRepository)
+	customerUsecase := usecase.NewCustomerUsecase(customerRepo, negativeList(
+		negativeListEntry(1, domain.NegativeListTypePhone, "6281234567890", domain.NegativeListSeverityFlag),
+	), duplicateConfig())
+
+	customerRepo.On("FindDuplicateCandidates", mock.Anything, 10).Return([]domain.Customer{}, nil)
+	customerRepo.On("CreateCustomer", mock.MatchedBy(func(customer *domain.Customer) bool {
+		return customer.CustomerKYCStatus == domain.CustomerKYCPending &&
+			customer.CustomerKYCReason == "negative list flag: customer_phone: confirmed fraud"
+	})).Return(nil)
+
+	_, err := customerUsecase.CreateCustomer(onboardingCustomer())
+
+	assert.Nil(t, err)
+	customerRepo.AssertExpectations(t)
+}
+
+func TestCreateLimit_NegativeListBlocked(t *testing.T) {
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	limitUsecase := usecase.NewLimitUsecase(mockLimitRepo, mockCustomerRepo, mockTransactionRepo, mockProductRepo, negativeList(
+		negativeListEntry(1, domain.NegativeListTypeNIK, "1234567890123456", domain.NegativeListSeverityBlock),
+	))
+
+	mockProductRepo.On("GetOfferedTenors").Return([]int{1, 2, 3, 6, 12}, nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, nil)
+
+	err := limitUsecase.CreateLimit(domain.Limit{
+		LimitNIK:    "1234567890123456",
+		LimitTenor:  12,
+		LimitAmount: money.New(5000000),
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrNegativeListBlocked)
+	mockTransactionRepo.AssertNotCalled(t, "WithTransaction", mock.Anything)
+}
+
+func TestCreateTransaction_NegativeListBlocked(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(
+		negativeListEntry(1, domain.NegativeListTypeNIK, "1234567890123456", domain.NegativeListSeverityBlock),
+	), config.AffordabilityConfig{})
+
+	customer := &domain.Customer{
+		CustomerNIK:       "1234567890123456",
+		CustomerKYCStatus: domain.CustomerKYCVerified,
+	}
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, transactionProduct(), domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1000000),
+		TransactionAdminFee:    money.New(50000),
+		TransactionInstallment: 12,
+		TransactionInterest:    5.0,
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrNegativeListBlocked)
+	mockTransactionRepo.AssertNotCalled(t, "WithTransaction", mock.Anything)
+}
+
+func TestCreateTransaction_NegativeListFlagProceeds(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(
+		negativeListEntry(1, domain.NegativeListTypeNIK, "1234567890123456", domain.NegativeListSeverityFlag),
+	), config.AffordabilityConfig{})
+
+	customer := &domain.Customer{
+		CustomerNIK:       "1234567890123456",
+		CustomerKYCStatus: domain.CustomerKYCVerified,
+	}
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(nil)
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, transactionProduct(), domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1000000),
+		TransactionAdminFee:    money.New(50000),
+		TransactionInstallment: 12,
+		TransactionInterest:    5.0,
+	})
+
+	assert.Nil(t, err)
+	mockTransactionRepo.AssertExpectations(t)
+}
@@ -0,0 +1,265 @@
+package usecase_test
+
+import (
+	"kreditplus/config"
+	"kreditplus/internal/domain"
+	"kreditplus/internal/money"
+	"kreditplus/internal/repository/mocks"
+	"kreditplus/internal/usecase"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestGetPayoffQuote_AccruesCurrentPeriodAndWaivesFutureInterest(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	settlementUsecase := usecase.NewSettlementUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo, config.SettlementConfig{
+		TerminationFeeRate:       2,
+		MinimumTerminationFee:    money.New(5000),
+		FeeWaivedWithinRemaining: 1,
+	})
+
+	transaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX12345",
+		TransactionDate:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+
+	mockInstallmentRepo.On("GetInstallmentsByTransactionID", uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 1, InstallmentDueDate: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(10000), InstallmentFee: money.New(2000), InstallmentPaidPrincipal: money.New(100000), InstallmentPaidInterest: money.New(10000), InstallmentPaidFee: money.New(2000), InstallmentStatus: domain.InstallmentStatusPaid},
+		{InstallmentNumber: 2, InstallmentDueDate: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(10000), InstallmentFee: money.New(2000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentNumber: 3, InstallmentDueDate: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(10000), InstallmentFee: money.New(2000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+
+	quote, err := settlementUsecase.GetPayoffQuote(transaction, time.Date(2025, 2, 15, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, quote.PayoffRemainingInstallments)
+	assert.Equal(t, money.New(200000), quote.PayoffOutstandingPrincipal)
+	assert.Equal(t, money.New(5000), quote.PayoffAccruedInterest, "Half of the current period interest should accrue")
+	assert.Equal(t, money.New(15000), quote.PayoffInterestWaived)
+	assert.Equal(t, money.New(4000), quote.PayoffOutstandingFee)
+	assert.Equal(t, money.New(5000), quote.PayoffTerminationFee, "Minimum termination fee should apply")
+	assert.Equal(t, money.New(214000), quote.PayoffTotalAmount)
+}
+
+func TestGetPayoffQuote_PeriodStartsAtPreviousDueDate(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	settlementUsecase := usecase.NewSettlementUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo, config.SettlementConfig{
+		FeeWaivedWithinRemaining: 12,
+	})
+
+	transaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX12345",
+		TransactionDate:           time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC),
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+
+	mockInstallmentRepo.On("GetInstallmentsByTransactionID", uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 1, InstallmentDueDate: time.Date(2025, 2, 28, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(31000), InstallmentPaidPrincipal: money.New(100000), InstallmentPaidInterest: money.New(31000), InstallmentStatus: domain.InstallmentStatusPaid},
+		{InstallmentNumber: 2, InstallmentDueDate: time.Date(2025, 3, 31, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(31000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentNumber: 3, InstallmentDueDate: time.Date(2025, 4, 30, 0, 0, 0, 0, time.UTC), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(31000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+
+	quote, err := settlementUsecase.GetPayoffQuote(transaction, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(15000), quote.PayoffAccruedInterest, "15 of 31 days since the 28 Feb due date should accrue")
+	assert.Equal(t, money.New(47000), quote.PayoffInterestWaived)
+}
+
+func TestGetPayoffQuote_RejectsDateBeforeTransaction(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	settlementUsecase := usecase.NewSettlementUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo, config.SettlementConfig{})
+
+	quote, err := settlementUsecase.GetPayoffQuote(&domain.Transaction{
+		TransactionID:     1,
+		TransactionDate:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		TransactionStatus: domain.TransactionStatusActive,
+	}, time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC))
+
+	assert.Nil(t, quote)
+	assert.ErrorIs(t, err, usecase.ErrInvalidPayoffDate)
+	mockInstallmentRepo.AssertNotCalled(t, "GetInstallmentsByTransactionID", mock.Anything)
+}
+
+func TestSettleTransaction_SettlesInstallmentsAndReleasesLimit(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	settlementUsecase := usecase.NewSettlementUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo, config.SettlementConfig{
+		TerminationFeeRate:       2,
+		FeeWaivedWithinRemaining: 1,
+	})
+
+	today := time.Now().UTC()
+	transactionDate := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, -1, 0)
+	transaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "TX12345",
+		TransactionLimit:          1,
+		TransactionDate:           transactionDate,
+		TransactionOTR:            money.New(200000),
+		TransactionAdminFee:       money.New(4000),
+		TransactionInstallment:    2,
+		TransactionInterest:       5,
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitAmount:          money.New(1000000),
+		LimitUsedAmount:      money.New(224000),
+		LimitRemainingAmount: money.New(776000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(transaction))
+	outstanding := []domain.Installment{
+		{InstallmentID: 1, InstallmentNumber: 1, InstallmentDueDate: transactionDate.AddDate(0, 1, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(10000), InstallmentFee: money.New(2000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentID: 2, InstallmentNumber: 2, InstallmentDueDate: transactionDate.AddDate(0, 2, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(10000), InstallmentFee: money.New(2000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return(outstanding, nil)
+	mockInstallmentRepo.On("GetInstallmentsByTransactionIDWithTx", mock.Anything, uint(1)).Return(outstanding, nil)
+
+	var updated []domain.Installment
+	mockInstallmentRepo.On("UpdateInstallmentWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			updated = append(updated, *args.Get(1).(*domain.Installment))
+		}).
+		Return(nil)
+	mockPaymentRepo.On("CreatePaymentWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, transaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.MatchedBy(func(history *domain.TransactionStatusHistory) bool {
+		return history.HistoryToStatus == domain.TransactionStatusPaidOff && history.HistoryReason == "early settlement"
+	})).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(l *domain.Limit) bool { return l.LimitID == 1 }), mock.Anything).Return(releaseTo(limit))
+
+	payment, err := settlementUsecase.SettleTransaction(1, transaction, domain.SettlementInput{
+		PaymentAmount:  money.New(214000),
+		PaymentChannel: "bank_transfer",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.PaymentTypeSettlement, payment.PaymentType)
+	assert.Equal(t, money.New(200000), payment.PaymentPrincipalAmount)
+	assert.Equal(t, money.New(10000), payment.PaymentInterestAmount, "Interest of the next period should be waived")
+	assert.Equal(t, money.New(0), payment.PaymentTerminationFee, "Fee should be waived for the last installment")
+
+	assert.Len(t, updated, 2)
+	for _, installment := range updated {
+		assert.Equal(t, domain.InstallmentStatusSettled, installment.InstallmentStatus)
+		assert.NotNil(t, installment.InstallmentPaidAt)
+	}
+
+	assert.Equal(t, domain.TransactionStatusPaidOff, transaction.TransactionStatus)
+	assert.Equal(t, money.New(0), limit.LimitUsedAmount)
+	assert.Equal(t, money.New(1000000), limit.LimitRemainingAmount)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestSettleTransaction_AmountMismatch(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	settlementUsecase := usecase.NewSettlementUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo, config.SettlementConfig{})
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusActive}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(transaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentID: 1, InstallmentNumber: 1, InstallmentDueDate: time.Now().AddDate(0, -1, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(10000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+	mockInstallmentRepo.On("GetInstallmentsByTransactionIDWithTx", mock.Anything, uint(1)).Return([]domain.Installment{}, nil)
+
+	payment, err := settlementUsecase.SettleTransaction(1, transaction, domain.SettlementInput{
+		PaymentAmount:  money.New(100000),
+		PaymentChannel: "cash",
+	})
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, usecase.ErrSettlementAmountMismatch)
+	mockInstallmentRepo.AssertNotCalled(t, "UpdateInstallmentWithTx", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSettleTransaction_RejectsInactiveTransaction(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	settlementUsecase := usecase.NewSettlementUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo, config.SettlementConfig{})
+
+	transaction := &domain.Transaction{TransactionID: 1, TransactionStatus: domain.TransactionStatusPaidOff}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(transaction))
+
+	payment, err := settlementUsecase.SettleTransaction(1, transaction, domain.SettlementInput{
+		PaymentAmount:  money.New(1000),
+		PaymentChannel: "cash",
+	})
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, usecase.ErrTransactionNotActive)
+	mockInstallmentRepo.AssertNotCalled(t, "GetOutstandingInstallmentsWithTx", mock.Anything, mock.Anything)
+}
+
+func TestSettleTransaction_StaleStatusRechecksLockedRow(t *testing.T) {
+	mockPaymentRepo := new(mocks.PaymentRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockTransactionRepo := new(mocks.TransactionRepository)
+
+	settlementUsecase := usecase.NewSettlementUsecase(mockPaymentRepo, mockInstallmentRepo, mockLimitRepo, mockTransactionRepo, config.SettlementConfig{})
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).
+		Return(&domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusPaidOff}, nil)
+
+	payment, err := settlementUsecase.SettleTransaction(1, &domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusActive}, domain.SettlementInput{
+		PaymentAmount:  money.New(1000),
+		PaymentChannel: "cash",
+	})
+
+	assert.Nil(t, payment)
+	assert.ErrorIs(t, err, usecase.ErrTransactionNotActive, "A concurrent final payment should be caught on the locked row")
+	mockInstallmentRepo.AssertNotCalled(t, "GetOutstandingInstallmentsWithTx", mock.Anything, mock.Anything)
+	mockPaymentRepo.AssertNotCalled(t, "CreatePaymentWithTx", mock.Anything, mock.Anything)
+}
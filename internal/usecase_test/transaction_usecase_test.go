@@ -2,7 +2,10 @@ package usecase_test
 
 import (
 	"errors"
+	"kreditplus/config"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/financing"
+	"kreditplus/internal/money"
 	"kreditplus/internal/repository/mocks"
 	"kreditplus/internal/usecase"
 	"testing"
@@ -13,35 +16,61 @@ import (
 	"gorm.io/gorm"
 )
 
+func transactionProduct(tenors ...int) *domain.Product {
+	if len(tenors) == 0 {
+		tenors = []int{1, 2, 3, 6, 12}
+	}
+
+	product := &domain.Product{
+		ProductID:     1,
+		ProductCode:   "electronics",
+		ProductMaxOTR: money.New(100000000),
+		ProductActive: true,
+	}
+	for _, tenor := range tenors {
+		product.ProductTenors = append(product.ProductTenors, domain.ProductTenor{TenorProductID: 1, TenorMonths: tenor})
+	}
+	return product
+}
+
+func pricingRules(rules ...domain.PricingRule) *mocks.PricingRuleRepository {
+	pricingRuleRepo := new(mocks.PricingRuleRepository)
+	pricingRuleRepo.On("GetActivePricingRulesByProductID", mock.Anything).Return(rules, nil)
+	return pricingRuleRepo
+}
+
 func TestCreateTransaction_Success(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	input := domain.TransactionInput{
 		TransactionNIK:         "1234567890123456",
-		TransactionOTR:         1000000,
-		TransactionAdminFee:    50000,
+		TransactionOTR:         money.New(1000000),
+		TransactionAdminFee:    money.New(50000),
 		TransactionInstallment: 12,
 		TransactionInterest:    5.0,
 	}
 
 	customer := &domain.Customer{
-		CustomerNIK: "1234567890123456",
+		CustomerNIK:       "1234567890123456",
+		CustomerKYCStatus: domain.CustomerKYCVerified,
 	}
 
 	limit := &domain.Limit{
 		LimitID:              1,
 		LimitNIK:             "1234567890123456",
-		LimitRemainingAmount: 5000000, // ✅
+		LimitRemainingAmount: money.New(5000000), // ✅
 	}
 
-	mockLimitRepo.On("GetLimitByNIKandTenorWithTx", mock.Anything, input.TransactionNIK, input.TransactionInstallment).Return(limit, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(limit, nil)
 	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(nil)
 
-	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, input)
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, transactionProduct(), input)
 
 	assert.Nil(t, err, "Transaction creation should be successful")
 	mockTransactionRepo.AssertExpectations(t)
@@ -51,25 +80,28 @@ func TestCreateTransaction_InsufficientLimit(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	input := domain.TransactionInput{
 		TransactionNIK:         "1234567890123456",
-		TransactionOTR:         5000000,
-		TransactionAdminFee:    50000,
+		TransactionOTR:         money.New(5000000),
+		TransactionAdminFee:    money.New(50000),
 		TransactionInstallment: 12,
 		TransactionInterest:    5.0,
 	}
 
 	customer := &domain.Customer{
-		CustomerNIK: "1234567890123456",
+		CustomerNIK:       "1234567890123456",
+		CustomerKYCStatus: domain.CustomerKYCVerified,
 	}
 
 	limit := &domain.Limit{
 		LimitID:              1,
 		LimitNIK:             "1234567890123456",
-		LimitRemainingAmount: 100000,
+		LimitRemainingAmount: money.New(100000),
 	}
 
 	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
@@ -77,10 +109,10 @@ func TestCreateTransaction_InsufficientLimit(t *testing.T) {
 			return fn(nil)
 		})
 
-	mockLimitRepo.On("GetLimitByNIKandTenorWithTx", mock.Anything, input.TransactionNIK, input.TransactionInstallment).
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).
 		Return(limit, nil)
 
-	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, input)
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, transactionProduct(), input)
 
 	assert.Error(t, err, "Transaction should fail due to insufficient limit")
 	assert.Equal(t, "insufficient limit", err.Error())
@@ -90,42 +122,72 @@ func TestCreateTransaction_DBError(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	input := domain.TransactionInput{
 		TransactionNIK:         "1234567890123456",
-		TransactionOTR:         1000000,
-		TransactionAdminFee:    50000,
+		TransactionOTR:         money.New(1000000),
+		TransactionAdminFee:    money.New(50000),
 		TransactionInstallment: 12,
 		TransactionInterest:    5.0,
 	}
 
 	customer := &domain.Customer{
-		CustomerNIK: "1234567890123456",
+		CustomerNIK:       "1234567890123456",
+		CustomerKYCStatus: domain.CustomerKYCVerified,
 	}
 
 	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(errors.New("database error"))
 
-	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, input)
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, transactionProduct(), input)
 
 	assert.Error(t, err, "Error should not be nil")
 	assert.Equal(t, "database error", err.Error(), "Expected database error")
 }
 
+func TestCreateTransaction_CustomerNotVerified(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1000000),
+		TransactionInstallment: 12,
+	}
+
+	customer := &domain.Customer{
+		CustomerNIK:       "1234567890123456",
+		CustomerKYCStatus: domain.CustomerKYCNeedsResubmission,
+	}
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, transactionProduct(), input)
+
+	assert.ErrorIs(t, err, usecase.ErrCustomerNotVerified)
+	mockTransactionRepo.AssertNotCalled(t, "WithTransaction", mock.Anything)
+}
+
 func TestGetAllTransactions_Success(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	mockTransactions := []domain.Transaction{
 		{
 			TransactionID:             1,
 			TransactionContractNumber: "TX12345",
 			TransactionNIK:            "1234567890123456",
-			TransactionOTR:            10000000,
-			TransactionAdminFee:       500000,
+			TransactionOTR:            money.New(10000000),
+			TransactionAdminFee:       money.New(500000),
 			TransactionInstallment:    12,
 			TransactionInterest:       5.0,
 			TransactionAssetName:      "Motorcycle",
@@ -134,8 +196,8 @@ func TestGetAllTransactions_Success(t *testing.T) {
 			TransactionID:             2,
 			TransactionContractNumber: "TX67890",
 			TransactionNIK:            "9876543210987654",
-			TransactionOTR:            15000000,
-			TransactionAdminFee:       700000,
+			TransactionOTR:            money.New(15000000),
+			TransactionAdminFee:       money.New(700000),
 			TransactionInstallment:    24,
 			TransactionInterest:       4.5,
 			TransactionAssetName:      "Car",
@@ -157,7 +219,9 @@ func TestGetAllTransactions_DBError(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	mockTransactionRepo.On("GetAllTransactions", 10, 0).Return(nil, errors.New("database error"))
 
@@ -172,15 +236,17 @@ func TestGetTransactionByID_Success(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	mockTransaction := &domain.Transaction{
 		TransactionID:             1,
 		TransactionContractNumber: "TX12345",
 		TransactionNIK:            "1234567890123456",
-		TransactionOTR:            15000000,
-		TransactionAdminFee:       500000,
+		TransactionOTR:            money.New(15000000),
+		TransactionAdminFee:       money.New(500000),
 		TransactionInstallment:    12,
 		TransactionInterest:       5.0,
 		TransactionAssetName:      "Car",
@@ -201,8 +267,10 @@ func TestGetTransactionByID_NotFound(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	mockTransactionRepo.On("GetTransactionByID", uint(999)).Return(nil, errors.New("transaction not found"))
 
@@ -215,9 +283,11 @@ func TestGetTransactionByID_NotFound(t *testing.T) {
 
 func TestGetCustomerByNIKForTransaction_Success(t *testing.T) {
 	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockTransactionRepo := new(mocks.TransactionRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	expectedCustomer := &domain.Customer{
 		CustomerNIK:      "1234567890123456",
@@ -236,9 +306,11 @@ func TestGetCustomerByNIKForTransaction_Success(t *testing.T) {
 
 func TestGetCustomerByNIKForTransaction_NotFound(t *testing.T) {
 	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockTransactionRepo := new(mocks.TransactionRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	mockCustomerRepo.On("GetCustomerByNIK", "0000000000000000").Return(nil, gorm.ErrRecordNotFound)
 
@@ -251,9 +323,11 @@ func TestGetCustomerByNIKForTransaction_NotFound(t *testing.T) {
 
 func TestGetCustomerByNIKForTransaction_DBError(t *testing.T) {
 	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockTransactionRepo := new(mocks.TransactionRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(nil, errors.New("database error"))
 
@@ -268,16 +342,19 @@ func TestUpdateTransaction_Success(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	userID := uint(1)
 
 	mockTransaction := &domain.Transaction{
 		TransactionContractNumber: "TX12345",
 		TransactionNIK:            "1234567890123456",
-		TransactionOTR:            10000000,
+		TransactionOTR:            money.New(10000000),
 		TransactionInstallment:    12,
 		TransactionLimit:          1,
+		TransactionStatus:         domain.TransactionStatusActive,
 	}
 
 	mockCustomer := &domain.Customer{
@@ -288,21 +365,21 @@ func TestUpdateTransaction_Success(t *testing.T) {
 		LimitID:              1,
 		LimitNIK:             "1234567890123456",
 		LimitTenor:           12,
-		LimitAmount:          20000000,
-		LimitRemainingAmount: 10000000,
+		LimitAmount:          money.New(20000000),
+		LimitRemainingAmount: money.New(10000000),
 	}
 
 	input := domain.TransactionInput{
 		TransactionNIK:         "1234567890123456",
-		TransactionOTR:         8000000,
+		TransactionOTR:         money.New(8000000),
 		TransactionInstallment: 12,
 	}
 
 	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(nil)
-	mockLimitRepo.On("GetLimitByNIKandTenorWithTx", mock.Anything, input.TransactionNIK, input.TransactionInstallment).Return(mockLimit, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(mockLimit, nil)
 	mockTransactionRepo.On("UpdateTransactionWithTx", mock.Anything, mockTransaction).Return(nil)
 
-	err := transactionUsecase.UpdateTransactionWithLimitUpdate(userID, mockCustomer, mockTransaction, input)
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(userID, mockCustomer, transactionProduct(), mockTransaction, input)
 
 	assert.Nil(t, err, "Transaction should be updated successfully")
 }
@@ -311,16 +388,20 @@ func TestUpdateTransaction_InsufficientLimit(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	userID := uint(1)
 
 	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
 		TransactionContractNumber: "TX12345",
 		TransactionNIK:            "1234567890123456",
-		TransactionOTR:            10000000,
+		TransactionOTR:            money.New(10000000),
 		TransactionInstallment:    12,
 		TransactionLimit:          1,
+		TransactionStatus:         domain.TransactionStatusActive,
 	}
 
 	mockCustomer := &domain.Customer{
@@ -331,13 +412,13 @@ func TestUpdateTransaction_InsufficientLimit(t *testing.T) {
 		LimitID:              1,
 		LimitNIK:             "1234567890123456",
 		LimitTenor:           12,
-		LimitAmount:          20000000,
-		LimitRemainingAmount: 5000000,
+		LimitAmount:          money.New(20000000),
+		LimitRemainingAmount: money.New(5000000),
 	}
 
 	input := domain.TransactionInput{
 		TransactionNIK:         "1234567890123456",
-		TransactionOTR:         15000000,
+		TransactionOTR:         money.New(15000000),
 		TransactionInstallment: 12,
 	}
 
@@ -345,14 +426,24 @@ func TestUpdateTransaction_InsufficientLimit(t *testing.T) {
 		fn := args.Get(0).(func(tx *gorm.DB) error)
 		_ = fn(nil)
 	}).Return(errors.New("insufficient limit"))
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, mockTransaction.TransactionID).Return(lockedAs(mockTransaction))
+
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(mockLimit, nil)
 
-	mockLimitRepo.On("GetLimitByNIKandTenorWithTx", mock.Anything, input.TransactionNIK, input.TransactionInstallment).Return(mockLimit, nil)
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, mockTransaction.TransactionID).Return(false, nil)
 
-	mockLimitRepo.On("UpdateLimitWithTx", mock.Anything, mock.Anything).Return(nil).Maybe()
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything).Return(releaseTo(mockLimit)).Maybe()
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, mock.Anything, mock.Anything).Return(consumeFrom(mockLimit)).Maybe()
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil).Maybe()
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil).Maybe()
 
 	mockTransactionRepo.On("UpdateTransactionWithTx", mock.Anything, mock.Anything).Return(nil).Maybe()
 
-	err := transactionUsecase.UpdateTransactionWithLimitUpdate(userID, mockCustomer, mockTransaction, input)
+	mockInstallmentRepo.On("VoidInstallmentsByTransactionIDWithTx", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(userID, mockCustomer, transactionProduct(), mockTransaction, input)
 
 	assert.Error(t, err)
 	assert.Equal(t, "insufficient limit", err.Error(), "Should return insufficient limit error")
@@ -365,16 +456,20 @@ func TestUpdateTransaction_DBError(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	userID := uint(1)
 
 	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
 		TransactionContractNumber: "TX12345",
 		TransactionNIK:            "1234567890123456",
-		TransactionOTR:            10000000,
+		TransactionOTR:            money.New(10000000),
 		TransactionInstallment:    12,
 		TransactionLimit:          1,
+		TransactionStatus:         domain.TransactionStatusActive,
 	}
 
 	mockCustomer := &domain.Customer{
@@ -385,13 +480,13 @@ func TestUpdateTransaction_DBError(t *testing.T) {
 		LimitID:              1,
 		LimitNIK:             "1234567890123456",
 		LimitTenor:           12,
-		LimitAmount:          20000000,
-		LimitRemainingAmount: 15000000,
+		LimitAmount:          money.New(20000000),
+		LimitRemainingAmount: money.New(15000000),
 	}
 
 	input := domain.TransactionInput{
 		TransactionNIK:         "1234567890123456",
-		TransactionOTR:         12000000,
+		TransactionOTR:         money.New(12000000),
 		TransactionInstallment: 12,
 	}
 
@@ -399,14 +494,23 @@ func TestUpdateTransaction_DBError(t *testing.T) {
 		Return(func(fn func(*gorm.DB) error) error {
 			return fn(nil)
 		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, mockTransaction.TransactionID).Return(lockedAs(mockTransaction))
 
-	mockLimitRepo.On("GetLimitByNIKandTenorWithTx", mock.Anything, input.TransactionNIK, input.TransactionInstallment).
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).
 		Return(mockLimit, nil)
 
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, mockTransaction.TransactionID).
+		Return(false, nil)
+
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything).Return(releaseTo(mockLimit))
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, mock.Anything, mock.Anything).Return(consumeFrom(mockLimit))
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+
 	mockTransactionRepo.On("UpdateTransactionWithTx", mock.Anything, mockTransaction).
 		Return(errors.New("database error"))
 
-	err := transactionUsecase.UpdateTransactionWithLimitUpdate(userID, mockCustomer, mockTransaction, input)
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(userID, mockCustomer, transactionProduct(), mockTransaction, input)
 
 	assert.Error(t, err)
 	assert.Equal(t, "database error", err.Error(), "Should return database error")
@@ -415,75 +519,1534 @@ func TestUpdateTransaction_DBError(t *testing.T) {
 	mockLimitRepo.AssertExpectations(t)
 }
 
-func TestDeleteTransaction_Success(t *testing.T) {
+func TestCreateTransaction_GeneratesInstallmentSchedule(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1000000),
+		TransactionAdminFee:    money.New(50000),
+		TransactionInstallment: 3,
+		TransactionInterest:    2.0,
+		TransactionAssetName:   "Laptop",
+	}
+
+	customer := &domain.Customer{
+		CustomerNIK:       "1234567890123456",
+		CustomerKYCStatus: domain.CustomerKYCVerified,
+	}
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitNIK:             "1234567890123456",
+		LimitTenor:           3,
+		LimitRemainingAmount: money.New(5000000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(limit, nil)
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.MatchedBy(func(movement *domain.LimitMovement) bool {
+		return movement.MovementType == domain.LimitMovementConsume &&
+			movement.MovementLimitID == 1 &&
+			movement.MovementUsedDelta == money.New(1110000) &&
+			movement.MovementRemainingBalance == money.New(3890000)
+	})).Return(nil)
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, limit, money.New(1110000)).Return(consumeFrom(limit))
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+
+	var schedule []domain.Installment
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			schedule = args.Get(1).([]domain.Installment)
+		}).
+		Return(nil)
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, transactionProduct(), input)
+
+	assert.Nil(t, err, "Transaction creation should be successful")
+	assert.Len(t, schedule, 3, "Schedule should have one installment per tenor month")
+
+	var total, principal money.Money
+	for i, installment := range schedule {
+		assert.Equal(t, i+1, installment.InstallmentNumber)
+		assert.Equal(t, domain.InstallmentStatusUnpaid, installment.InstallmentStatus)
+		total = total.Add(installment.InstallmentAmount)
+		principal = principal.Add(installment.InstallmentPrincipal)
+	}
+
+	assert.Equal(t, money.New(1110000), total, "Schedule should sum to the amount consumed from the limit")
+	assert.Equal(t, money.New(1000000), principal, "Schedule principal should sum to OTR")
+	assert.Equal(t, money.New(3890000), limit.LimitRemainingAmount)
+	mockInstallmentRepo.AssertExpectations(t)
+	mockLimitRepo.AssertExpectations(t)
+}
+
+func TestCreateTransaction_CustomerLimitExceeded(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1000000),
+		TransactionAdminFee:    money.New(50000),
+		TransactionInstallment: 3,
+		TransactionInterest:    2.0,
+		TransactionAssetName:   "Laptop",
+	}
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitNIK:             "1234567890123456",
+		LimitTenor:           3,
+		LimitRemainingAmount: money.New(5000000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(limit, nil)
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, limit, money.New(1110000)).Return(consumeFrom(limit))
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, "1234567890123456").
+		Return(&domain.CustomerLimit{CustomerLimitID: 1, CustomerLimitNIK: "1234567890123456", CustomerLimitAmount: money.New(2000000)}, nil)
+	mockLimitRepo.On("GetUsedAmountByNIKWithTx", mock.Anything, "1234567890123456").Return(money.New(2110000), nil)
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, transactionProduct(), input)
+
+	assert.ErrorIs(t, err, usecase.ErrCustomerLimitExceeded, "Aggregate usage across tenors should be capped by the customer limit")
+}
+
+func TestGetTransactionSchedule_Success(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockInstallments := []domain.Installment{
+		{InstallmentID: 1, InstallmentTransactionID: 1, InstallmentNumber: 1, InstallmentAmount: money.New(370000)},
+		{InstallmentID: 2, InstallmentTransactionID: 1, InstallmentNumber: 2, InstallmentAmount: money.New(370000)},
+	}
+
+	mockInstallmentRepo.On("GetInstallmentsByTransactionID", uint(1)).Return(mockInstallments, nil)
+
+	installments, err := transactionUsecase.GetTransactionSchedule(1)
+
+	assert.Nil(t, err, "Error should be nil")
+	assert.Len(t, installments, 2)
+	assert.Equal(t, 2, installments[1].InstallmentNumber)
+}
+
+func TestUpdateTransaction_RejectsTransactionWithPayments(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	mockTransaction := &domain.Transaction{
-		TransactionID:             1,
-		TransactionContractNumber: "TX12345",
-		TransactionLimit:          1,
-		TransactionOTR:            1000000,
-		TransactionAdminFee:       50000,
-		TransactionInstallment:    12,
-		TransactionInterest:       5.0,
+		TransactionID:          1,
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1000000),
+		TransactionInstallment: 3,
+		TransactionStatus:      domain.TransactionStatusActive,
+	}
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(2000000),
+		TransactionInstallment: 3,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, mockTransaction.TransactionID).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, uint(1)).Return(true, nil)
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(), mockTransaction, input)
+
+	assert.ErrorIs(t, err, usecase.ErrTransactionHasPayments)
+	mockLimitRepo.AssertNotCalled(t, "GetLimitByNIKandTenor", mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaction_DraftDoesNotConsumeLimit(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(10000000),
+		TransactionAdminFee:    money.New(50000),
+		TransactionInstallment: 3,
+		TransactionInterest:    2.0,
+		TransactionAssetName:   "Laptop",
+		TransactionStatus:      domain.TransactionStatusDraft,
+	}
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitRemainingAmount: money.New(1000000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(limit, nil)
+
+	var created *domain.Transaction
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*domain.Transaction)
+		}).
+		Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.MatchedBy(func(history *domain.TransactionStatusHistory) bool {
+		return history.HistoryFromStatus == "" && history.HistoryToStatus == domain.TransactionStatusDraft
+	})).Return(nil)
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, transactionProduct(), input)
+
+	assert.Nil(t, err, "Draft should be created even when the limit is not sufficient yet")
+	assert.Equal(t, domain.TransactionStatusDraft, created.TransactionStatus)
+	assert.Equal(t, money.New(1000000), limit.LimitRemainingAmount)
+	mockLimitRepo.AssertNotCalled(t, "ConsumeLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+	mockInstallmentRepo.AssertNotCalled(t, "CreateInstallmentsWithTx", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransaction_RejectsClosedTransaction(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:          1,
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1000000),
+		TransactionInstallment: 3,
+		TransactionStatus:      domain.TransactionStatusCancelled,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, mockTransaction.TransactionID).Return(lockedAs(mockTransaction))
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(), mockTransaction, domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(2000000),
+		TransactionInstallment: 3,
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrTransactionNotEditable)
+	mockTransactionRepo.AssertNotCalled(t, "UpdateTransactionWithTx", mock.Anything, mock.Anything)
+}
+
+func TestCancelTransaction_ReleasesLimitAndVoidsSchedule(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:          1,
+		TransactionLimit:       1,
+		TransactionOTR:         money.New(1000000),
+		TransactionInstallment: 3,
+		TransactionStatus:      domain.TransactionStatusActive,
 	}
 
 	mockLimit := &domain.Limit{
 		LimitID:              1,
-		LimitNIK:             "1234567890123456",
-		LimitTenor:           12,
-		LimitAmount:          20000000,
-		LimitUsedAmount:      1000000,
-		LimitRemainingAmount: 19000000,
+		LimitUsedAmount:      money.New(1000000),
+		LimitRemainingAmount: money.New(4000000),
 	}
 
-	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(nil)
-	mockLimitRepo.On("GetLimitByIDWithTx", mock.Anything, mockTransaction.TransactionLimit).Return(mockLimit, nil)
-	mockTransactionRepo.On("DeleteTransactionWithTx", mock.Anything, mockTransaction).Return(nil)
-	mockLimitRepo.On("UpdateLimitWithTx", mock.Anything, mockLimit).Return(nil)
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, uint(1)).Return(false, nil)
+	mockInstallmentRepo.On("VoidInstallmentsByTransactionIDWithTx", mock.Anything, uint(1)).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), mock.Anything).Return(releaseTo(mockLimit))
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.MatchedBy(func(history *domain.TransactionStatusHistory) bool {
+		return history.HistoryFromStatus == domain.TransactionStatusActive &&
+			history.HistoryToStatus == domain.TransactionStatusCancelled &&
+			history.HistoryReason == "customer request"
+	})).Return(nil)
+
+	err := transactionUsecase.TransitionTransactionStatus(1, mockTransaction, domain.TransactionStatusCancelled, "customer request")
+
+	assert.Nil(t, err, "Transaction should be cancelled successfully")
+	assert.Equal(t, domain.TransactionStatusCancelled, mockTransaction.TransactionStatus)
+	assert.Equal(t, money.New(5000000), mockLimit.LimitRemainingAmount)
+	assert.Equal(t, money.New(0), mockLimit.LimitUsedAmount)
+	mockInstallmentRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestCancelTransaction_DraftDoesNotTouchLimit(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:     1,
+		TransactionLimit:  1,
+		TransactionOTR:    money.New(1000000),
+		TransactionStatus: domain.TransactionStatusDraft,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
 
-	err := transactionUsecase.DeleteTransactionWithLimitUpdate(1, mockTransaction)
+	err := transactionUsecase.TransitionTransactionStatus(1, mockTransaction, domain.TransactionStatusCancelled, "")
 
-	assert.Nil(t, err, "Transaction should be deleted successfully")
+	assert.Nil(t, err)
+	assert.Equal(t, domain.TransactionStatusCancelled, mockTransaction.TransactionStatus)
+	mockLimitRepo.AssertNotCalled(t, "ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestDeleteTransaction_NotFound(t *testing.T) {
+func TestCancelTransaction_StaleStatusRechecksLockedRow(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
 
-	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(errors.New("transaction not found"))
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
-	err := transactionUsecase.DeleteTransactionWithLimitUpdate(1, &domain.Transaction{TransactionID: 999})
+	mockTransaction := &domain.Transaction{
+		TransactionID:     1,
+		TransactionLimit:  1,
+		TransactionOTR:    money.New(1000000),
+		TransactionStatus: domain.TransactionStatusActive,
+	}
 
-	assert.Error(t, err)
-	assert.Equal(t, "transaction not found", err.Error(), "Should return transaction not found error")
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).
+		Return(&domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionOTR: money.New(1000000), TransactionStatus: domain.TransactionStatusCancelled}, nil)
+
+	err := transactionUsecase.TransitionTransactionStatus(1, mockTransaction, domain.TransactionStatusCancelled, "")
+
+	assert.ErrorIs(t, err, usecase.ErrInvalidStatusTransition, "A concurrent cancel should be rejected once the row is locked")
+	mockInstallmentRepo.AssertNotCalled(t, "VoidInstallmentsByTransactionIDWithTx", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestDeleteTransaction_DBError(t *testing.T) {
+func TestCancelTransaction_DBError(t *testing.T) {
 	mockTransactionRepo := new(mocks.TransactionRepository)
 	mockLimitRepo := new(mocks.LimitRepository)
 	mockCustomerRepo := new(mocks.CustomerRepository)
-	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
 
 	mockTransaction := &domain.Transaction{
-		TransactionID:             1,
-		TransactionContractNumber: "TX12345",
-		TransactionLimit:          1,
-		TransactionOTR:            1000000,
-		TransactionAdminFee:       50000,
-		TransactionInstallment:    12,
-		TransactionInterest:       5.0,
+		TransactionID:     1,
+		TransactionStatus: domain.TransactionStatusActive,
 	}
 
 	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(errors.New("database error"))
 
-	err := transactionUsecase.DeleteTransactionWithLimitUpdate(1, mockTransaction)
+	err := transactionUsecase.TransitionTransactionStatus(1, mockTransaction, domain.TransactionStatusCancelled, "")
 
 	assert.Error(t, err)
 	assert.Equal(t, "database error", err.Error(), "Should return database error")
 }
+
+func TestTransitionTransaction_InvalidTransition(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+
+	cases := []struct {
+		from string
+		to   string
+	}{
+		{domain.TransactionStatusPaidOff, domain.TransactionStatusActive},
+		{domain.TransactionStatusCancelled, domain.TransactionStatusActive},
+		{domain.TransactionStatusDraft, domain.TransactionStatusPaidOff},
+		{domain.TransactionStatusWrittenOff, domain.TransactionStatusCancelled},
+		{domain.TransactionStatusActive, domain.TransactionStatusActive},
+		{domain.TransactionStatusActive, domain.TransactionStatusRestructured},
+	}
+
+	var transaction *domain.Transaction
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(func(*gorm.DB, uint) (*domain.Transaction, error) {
+		return lockedAs(transaction)(nil, 0)
+	})
+
+	for _, tc := range cases {
+		transaction = &domain.Transaction{TransactionID: 1, TransactionStatus: tc.from}
+
+		err := transactionUsecase.TransitionTransactionStatus(1, transaction, tc.to, "")
+
+		assert.ErrorIs(t, err, usecase.ErrInvalidStatusTransition, "%s -> %s should be rejected", tc.from, tc.to)
+		assert.Equal(t, tc.from, transaction.TransactionStatus)
+	}
+	mockTransactionRepo.AssertNotCalled(t, "UpdateTransactionStatusWithTx", mock.Anything, mock.Anything)
+}
+
+func TestActivateTransaction_ConsumesLimitAndGeneratesSchedule(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:          1,
+		TransactionLimit:       1,
+		TransactionOTR:         money.New(1000000),
+		TransactionAdminFee:    money.New(50000),
+		TransactionInstallment: 3,
+		TransactionInterest:    2.0,
+		TransactionStatus:      domain.TransactionStatusDraft,
+	}
+
+	mockLimit := &domain.Limit{
+		LimitID:              1,
+		LimitRemainingAmount: money.New(5000000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), money.New(1110000)).Return(consumeFrom(mockLimit))
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.MatchedBy(func(installments []domain.Installment) bool {
+		return len(installments) == 3
+	})).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	err := transactionUsecase.TransitionTransactionStatus(1, mockTransaction, domain.TransactionStatusActive, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.TransactionStatusActive, mockTransaction.TransactionStatus)
+	assert.Equal(t, money.New(3890000), mockLimit.LimitRemainingAmount)
+	assert.False(t, mockTransaction.TransactionDate.IsZero(), "Activation should start the contract date")
+	mockInstallmentRepo.AssertExpectations(t)
+}
+
+func TestPayOffTransaction_RejectsOutstandingInstallments(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:     1,
+		TransactionLimit:  1,
+		TransactionStatus: domain.TransactionStatusActive,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentID: 3, InstallmentNumber: 3, InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+
+	err := transactionUsecase.TransitionTransactionStatus(1, mockTransaction, domain.TransactionStatusPaidOff, "")
+
+	assert.ErrorIs(t, err, usecase.ErrTransactionHasOutstandingBalance)
+	assert.Equal(t, domain.TransactionStatusActive, mockTransaction.TransactionStatus)
+	mockLimitRepo.AssertNotCalled(t, "UpdateLimitWithTx", mock.Anything, mock.Anything)
+}
+
+func TestPayOffTransaction_ReleasesInterestAndFee(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionLimit:          1,
+		TransactionOTR:            money.New(1000000),
+		TransactionAdminFee:       money.New(50000),
+		TransactionInstallment:    3,
+		TransactionInterest:       2.0,
+		TransactionDaysPastDue:    4,
+		TransactionCollectibility: domain.Collectibility1To30,
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+
+	mockLimit := &domain.Limit{
+		LimitID:              1,
+		LimitUsedAmount:      money.New(110000),
+		LimitRemainingAmount: money.New(4890000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{}, nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), mock.Anything).Return(releaseTo(mockLimit))
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	err := transactionUsecase.TransitionTransactionStatus(1, mockTransaction, domain.TransactionStatusPaidOff, "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, domain.TransactionStatusPaidOff, mockTransaction.TransactionStatus)
+	assert.Equal(t, domain.CollectibilityCurrent, mockTransaction.TransactionCollectibility)
+	assert.Equal(t, money.New(0), mockLimit.LimitUsedAmount)
+	assert.Equal(t, money.New(5000000), mockLimit.LimitRemainingAmount)
+}
+
+func TestCreateTransaction_UsesSelectedFinancingScheme(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1200000),
+		TransactionAdminFee:    money.New(60000),
+		TransactionInstallment: 12,
+		TransactionInterest:    1.0,
+		TransactionAssetName:   "Laptop",
+		TransactionScheme:      "declining_balance",
+	}
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitRemainingAmount: money.New(2000000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(limit, nil)
+
+	var created *domain.Transaction
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			created = args.Get(1).(*domain.Transaction)
+		}).
+		Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, limit, mock.Anything).Return(consumeFrom(limit))
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+
+	var schedule []domain.Installment
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			schedule = args.Get(1).([]domain.Installment)
+		}).
+		Return(nil)
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified}, transactionProduct(), input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "declining_balance", created.TransactionScheme)
+	assert.Equal(t, money.New(12000), schedule[0].InstallmentInterest)
+	assert.Equal(t, money.New(1000), schedule[11].InstallmentInterest)
+	assert.Equal(t, money.New(662000), limit.LimitRemainingAmount, "Limit consumption should follow the scheme total")
+}
+
+func TestSimulateTransaction_ComparesAvailableTenors(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1200000),
+		TransactionAdminFee:    money.New(60000),
+		TransactionInstallment: 3,
+		TransactionInterest:    1.0,
+		TransactionAssetName:   "Laptop",
+	}
+
+	mockLimitRepo.On("GetCustomerLimitByNIK", input.TransactionNIK).Return(&domain.CustomerLimit{}, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, float64(1)).Return(&domain.Limit{}, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, float64(2)).Return(&domain.Limit{LimitID: 2, LimitRemainingAmount: money.New(1000000)}, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, float64(3)).Return(&domain.Limit{LimitID: 3, LimitRemainingAmount: money.New(2000000)}, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, float64(6)).Return(&domain.Limit{LimitID: 6, LimitRemainingAmount: money.New(5000000)}, nil)
+
+	simulations, err := transactionUsecase.SimulateTransaction(&domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(1, 2, 3, 6), input)
+
+	assert.Nil(t, err)
+	assert.Len(t, simulations, 4)
+
+	assert.False(t, simulations[0].SimulationEligible)
+	assert.Equal(t, "no limit for this tenor", simulations[0].SimulationReason)
+
+	assert.False(t, simulations[1].SimulationEligible)
+	assert.Equal(t, "insufficient limit", simulations[1].SimulationReason)
+
+	assert.True(t, simulations[2].SimulationRequested)
+	assert.True(t, simulations[2].SimulationEligible)
+	assert.Equal(t, money.New(1296000), simulations[2].SimulationTotalPayable)
+	assert.Equal(t, money.New(432000), simulations[2].SimulationMonthlyInstallment)
+	assert.Equal(t, money.New(704000), simulations[2].SimulationRemainingAfter)
+
+	assert.Equal(t, money.New(1332000), simulations[3].SimulationTotalPayable)
+	mockTransactionRepo.AssertNotCalled(t, "WithTransaction", mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "UpdateLimitWithTx", mock.Anything, mock.Anything)
+}
+
+func TestSimulateTransaction_IncludesRequestedTenorOutsideCatalog(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockLimitRepo.On("GetCustomerLimitByNIK", "1234567890123456").Return(&domain.CustomerLimit{}, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", "1234567890123456", mock.Anything).Return(&domain.Limit{LimitID: 1, LimitRemainingAmount: money.New(5000000)}, nil)
+
+	simulations, err := transactionUsecase.SimulateTransaction(&domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(1, 2, 3, 6), domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1200000),
+		TransactionAdminFee:    money.New(60000),
+		TransactionInstallment: 12,
+		TransactionInterest:    1.0,
+		TransactionScheme:      "annuity",
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, simulations, 5)
+	assert.Equal(t, 12, simulations[4].SimulationTenor)
+	assert.True(t, simulations[4].SimulationRequested)
+	assert.Equal(t, "annuity", simulations[4].SimulationScheme)
+	assert.False(t, simulations[4].SimulationEligible)
+	assert.Equal(t, "tenor is not offered", simulations[4].SimulationReason)
+	mockLimitRepo.AssertNotCalled(t, "GetLimitByNIKandTenor", "1234567890123456", float64(12))
+}
+
+func TestSimulateTransaction_CappedByCustomerLimit(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockLimitRepo.On("GetCustomerLimitByNIK", "1234567890123456").Return(&domain.CustomerLimit{CustomerLimitID: 1, CustomerLimitAmount: money.New(3000000)}, nil)
+	mockLimitRepo.On("GetLimitsByNIK", "1234567890123456").Return([]domain.Limit{
+		{LimitID: 1, LimitUsedAmount: money.New(1500000)},
+		{LimitID: 3, LimitUsedAmount: money.New(500000)},
+	}, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", "1234567890123456", mock.Anything).Return(&domain.Limit{LimitID: 3, LimitRemainingAmount: money.New(5000000)}, nil)
+
+	simulations, err := transactionUsecase.SimulateTransaction(&domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(3), domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1200000),
+		TransactionAdminFee:    money.New(60000),
+		TransactionInstallment: 3,
+		TransactionInterest:    1.0,
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, simulations, 1)
+	assert.False(t, simulations[0].SimulationEligible, "The umbrella limit should cap the tenor sub-limit")
+	assert.Equal(t, "exceeds customer limit", simulations[0].SimulationReason)
+	assert.Equal(t, money.New(1000000), simulations[0].SimulationLimitRemainingAmount)
+	assert.Equal(t, money.New(-296000), simulations[0].SimulationRemainingAfter)
+}
+
+func TestRestructureTransaction_ExtendTenorMovesLimitToNewTenor(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	productID := uint(7)
+	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "CTR-1",
+		TransactionNIK:            "1234567890123456",
+		TransactionLimit:          1,
+		TransactionOTR:            money.New(300000),
+		TransactionInstallment:    3,
+		TransactionInterest:       1.0,
+		TransactionScheme:         "flat",
+		TransactionStatus:         domain.TransactionStatusActive,
+		TransactionVersion:        1,
+		TransactionOriginalNumber: "CTR-1",
+		TransactionProductID:      &productID,
+	}
+
+	oldLimit := &domain.Limit{LimitID: 1, LimitTenor: 3, LimitAmount: money.New(1000000), LimitUsedAmount: money.New(209000), LimitRemainingAmount: money.New(791000)}
+	newLimit := &domain.Limit{LimitID: 2, LimitTenor: 6, LimitAmount: money.New(500000), LimitUsedAmount: money.New(0), LimitRemainingAmount: money.New(500000)}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 2, InstallmentDueDate: time.Now().AddDate(0, 1, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentNumber: 3, InstallmentDueDate: time.Now().AddDate(0, 2, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), mock.Anything).Return(releaseTo(oldLimit))
+	mockLimitRepo.On("GetLimitByNIKandTenor", "1234567890123456", 6.0).Return(newLimit, nil)
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, newLimit, money.New(212000)).Return(consumeFrom(newLimit))
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.MatchedBy(func(transaction *domain.Transaction) bool {
+		return transaction.TransactionContractNumber == "CTR-1-V2" && *transaction.TransactionParentID == 1 &&
+			transaction.TransactionVersion == 2 && transaction.TransactionOTR == money.New(200000) && transaction.TransactionLimit == 2 &&
+			*transaction.TransactionProductID == 7 && !transaction.TransactionPricingOverride
+	})).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.MatchedBy(func(installments []domain.Installment) bool {
+		return len(installments) == 6
+	})).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+	mockInstallmentRepo.On("CloseOutstandingInstallmentsWithTx", mock.Anything, uint(1), domain.InstallmentStatusRestructured).Return(nil)
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionRestructureWithTx", mock.Anything, mock.MatchedBy(func(restructure *domain.TransactionRestructure) bool {
+		return restructure.RestructureFromTransactionID == 1 && restructure.RestructureType == domain.RestructureTypeExtendTenor &&
+			restructure.RestructureOldTenor == 3 && restructure.RestructureNewTenor == 6
+	})).Return(nil)
+
+	restructured, err := transactionUsecase.RestructureTransaction(1, mockTransaction, domain.TransactionRestructureInput{
+		RestructureType:   domain.RestructureTypeExtendTenor,
+		RestructureTenor:  6,
+		RestructureReason: "income reduced",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, "CTR-1", restructured.TransactionOriginalNumber)
+	assert.Equal(t, domain.TransactionStatusRestructured, mockTransaction.TransactionStatus)
+	assert.Equal(t, money.New(0), oldLimit.LimitUsedAmount)
+	assert.Equal(t, money.New(1000000), oldLimit.LimitRemainingAmount)
+	assert.Equal(t, money.New(212000), newLimit.LimitUsedAmount)
+	assert.Equal(t, money.New(288000), newLimit.LimitRemainingAmount)
+	mockLimitRepo.AssertNotCalled(t, "UpdateLimitWithTx", mock.Anything, mock.Anything)
+	mockTransactionRepo.AssertExpectations(t)
+	mockInstallmentRepo.AssertExpectations(t)
+}
+
+func TestRestructureTransaction_CapitalisesArrearsOnSameTenor(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "CTR-1",
+		TransactionNIK:            "1234567890123456",
+		TransactionLimit:          1,
+		TransactionOTR:            money.New(300000),
+		TransactionInstallment:    3,
+		TransactionInterest:       1.0,
+		TransactionScheme:         "flat",
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+
+	limit := &domain.Limit{LimitID: 1, LimitTenor: 3, LimitAmount: money.New(1000000), LimitUsedAmount: money.New(209000), LimitRemainingAmount: money.New(791000)}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 2, InstallmentDueDate: time.Now().AddDate(0, 0, -10), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentPenalty: money.New(500), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentNumber: 3, InstallmentDueDate: time.Now().AddDate(0, 1, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), mock.Anything).Return(releaseTo(limit))
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, mock.MatchedBy(func(consumed *domain.Limit) bool {
+		return consumed.LimitID == 1
+	}), mock.Anything).Return(consumeFrom(limit))
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.MatchedBy(func(transaction *domain.Transaction) bool {
+		return transaction.TransactionContractNumber == "CTR-1-V2" && transaction.TransactionOTR == money.New(203500)
+	})).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+	mockInstallmentRepo.On("CloseOutstandingInstallmentsWithTx", mock.Anything, uint(1), domain.InstallmentStatusRestructured).Return(nil)
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionRestructureWithTx", mock.Anything, mock.MatchedBy(func(restructure *domain.TransactionRestructure) bool {
+		return restructure.RestructureCapitalised == money.New(3500)
+	})).Return(nil)
+
+	_, err := transactionUsecase.RestructureTransaction(1, mockTransaction, domain.TransactionRestructureInput{
+		RestructureType:   domain.RestructureTypeCapitaliseArrears,
+		RestructureTenor:  3,
+		RestructureReason: "arrears capitalised",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, money.New(209605), limit.LimitUsedAmount)
+	assert.Equal(t, money.New(790395), limit.LimitRemainingAmount)
+	mockLimitRepo.AssertNotCalled(t, "UpdateLimitWithTx", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "GetLimitByNIKandTenor", mock.Anything, mock.Anything)
+}
+
+func TestRestructureTransaction_PaymentHolidayShiftsSchedule(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "CTR-1",
+		TransactionLimit:          1,
+		TransactionOTR:            money.New(300000),
+		TransactionInstallment:    3,
+		TransactionInterest:       1.0,
+		TransactionScheme:         "flat",
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 3, InstallmentDueDate: time.Now().AddDate(0, 1, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+	limit := &domain.Limit{LimitID: 1, LimitTenor: 3, LimitUsedAmount: money.New(109000), LimitRemainingAmount: money.New(891000)}
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), mock.Anything).Return(releaseTo(limit))
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, mock.Anything, mock.Anything).Return(consumeFrom(limit))
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	var schedule []domain.Installment
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			schedule = args.Get(1).([]domain.Installment)
+		}).
+		Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+	mockInstallmentRepo.On("CloseOutstandingInstallmentsWithTx", mock.Anything, uint(1), domain.InstallmentStatusRestructured).Return(nil)
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionRestructureWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	restructured, err := transactionUsecase.RestructureTransaction(1, mockTransaction, domain.TransactionRestructureInput{
+		RestructureType:          domain.RestructureTypePaymentHoliday,
+		RestructureTenor:         3,
+		RestructureHolidayMonths: 2,
+		RestructureReason:        "hospitalised",
+	})
+
+	assert.Nil(t, err)
+	assert.Len(t, schedule, 3)
+	assert.Equal(t, financing.AddMonths(restructured.TransactionDate, 3).Format("2006-01-02"), schedule[0].InstallmentDueDate.Format("2006-01-02"),
+		"First installment should fall due after the holiday")
+}
+
+func TestRestructureTransaction_RejectsArrearsWithoutCapitalising(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusActive}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 2, InstallmentDueDate: time.Now().AddDate(0, 0, -10), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+
+	restructured, err := transactionUsecase.RestructureTransaction(1, mockTransaction, domain.TransactionRestructureInput{
+		RestructureType:   domain.RestructureTypeExtendTenor,
+		RestructureTenor:  6,
+		RestructureReason: "income reduced",
+	})
+
+	assert.Nil(t, restructured)
+	assert.ErrorIs(t, err, usecase.ErrRestructureHasArrears)
+	mockLimitRepo.AssertNotCalled(t, "ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+	mockTransactionRepo.AssertNotCalled(t, "CreateTransactionWithTx", mock.Anything, mock.Anything)
+}
+
+func TestRestructureTransaction_CustomerLimitExceeded(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "CTR-1",
+		TransactionNIK:            "1234567890123456",
+		TransactionLimit:          1,
+		TransactionOTR:            money.New(300000),
+		TransactionInstallment:    3,
+		TransactionInterest:       1.0,
+		TransactionScheme:         "flat",
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+	oldLimit := &domain.Limit{LimitID: 1, LimitNIK: "1234567890123456", LimitTenor: 3, LimitUsedAmount: money.New(109000), LimitRemainingAmount: money.New(891000)}
+	newLimit := &domain.Limit{LimitID: 2, LimitNIK: "1234567890123456", LimitTenor: 6, LimitRemainingAmount: money.New(2000000)}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 2, InstallmentDueDate: time.Now().AddDate(0, 0, 10), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+		{InstallmentNumber: 3, InstallmentDueDate: time.Now().AddDate(0, 1, 10), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), mock.Anything).Return(releaseTo(oldLimit))
+	mockLimitRepo.On("GetLimitByNIKandTenor", "1234567890123456", 6.0).Return(newLimit, nil)
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, newLimit, mock.Anything).Return(consumeFrom(newLimit))
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, "1234567890123456").
+		Return(&domain.CustomerLimit{CustomerLimitID: 1, CustomerLimitNIK: "1234567890123456", CustomerLimitAmount: money.New(200000)}, nil)
+	mockLimitRepo.On("GetUsedAmountByNIKWithTx", mock.Anything, "1234567890123456").Return(money.New(212000), nil)
+
+	restructured, err := transactionUsecase.RestructureTransaction(1, mockTransaction, domain.TransactionRestructureInput{
+		RestructureType:   domain.RestructureTypeExtendTenor,
+		RestructureTenor:  6,
+		RestructureReason: "income reduced",
+	})
+
+	assert.Nil(t, restructured)
+	assert.ErrorIs(t, err, usecase.ErrCustomerLimitExceeded, "Restructure should be capped by the customer limit")
+	mockTransactionRepo.AssertNotCalled(t, "CreateTransactionRestructureWithTx", mock.Anything, mock.Anything)
+}
+
+func TestRestructureTransaction_RejectedByAffordabilityCheck(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{MaxInstallmentRatio: 30})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "CTR-1",
+		TransactionNIK:            "1234567890123456",
+		TransactionLimit:          1,
+		TransactionOTR:            money.New(300000),
+		TransactionInstallment:    3,
+		TransactionInterest:       1.0,
+		TransactionScheme:         "flat",
+		TransactionStatus:         domain.TransactionStatusActive,
+	}
+	limit := &domain.Limit{LimitID: 1, LimitNIK: "1234567890123456", LimitTenor: 3, LimitUsedAmount: money.New(109000), LimitRemainingAmount: money.New(891000)}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 3, InstallmentDueDate: time.Now().AddDate(0, 1, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), mock.Anything).Return(releaseTo(limit))
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockCustomerRepo.On("GetCustomerByNIK", "1234567890123456").Return(&domain.Customer{CustomerNIK: "1234567890123456", CustomerSalary: money.New(200000)}, nil)
+	mockInstallmentRepo.On("GetMonthlyObligationByNIKWithTx", mock.Anything, "1234567890123456", uint(1)).Return(money.New(50000), nil)
+
+	restructured, err := transactionUsecase.RestructureTransaction(1, mockTransaction, domain.TransactionRestructureInput{
+		RestructureType:   domain.RestructureTypeExtendTenor,
+		RestructureTenor:  3,
+		RestructureReason: "income reduced",
+	})
+
+	var affordabilityErr *usecase.AffordabilityError
+	assert.Nil(t, restructured)
+	assert.True(t, errors.As(err, &affordabilityErr))
+	assert.Equal(t, money.New(50000), affordabilityErr.Rejection.ExistingInstallments, "Obligations should exclude the contract being restructured")
+	mockTransactionRepo.AssertNotCalled(t, "CreateTransactionWithTx", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "ConsumeLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRestructureTransaction_InterestOverrideMarksPricing(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	ruleID := uint(3)
+	mockTransaction := &domain.Transaction{
+		TransactionID:             1,
+		TransactionContractNumber: "CTR-1",
+		TransactionLimit:          1,
+		TransactionOTR:            money.New(300000),
+		TransactionInstallment:    3,
+		TransactionInterest:       1.0,
+		TransactionScheme:         "flat",
+		TransactionStatus:         domain.TransactionStatusActive,
+		TransactionPricingRuleID:  &ruleID,
+	}
+	limit := &domain.Limit{LimitID: 1, LimitTenor: 3, LimitUsedAmount: money.New(109000), LimitRemainingAmount: money.New(891000)}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("GetOutstandingInstallmentsWithTx", mock.Anything, uint(1)).Return([]domain.Installment{
+		{InstallmentNumber: 3, InstallmentDueDate: time.Now().AddDate(0, 1, 0), InstallmentPrincipal: money.New(100000), InstallmentInterest: money.New(3000), InstallmentStatus: domain.InstallmentStatusUnpaid},
+	}, nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything).Return(releaseTo(limit))
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, mock.Anything, mock.Anything).Return(consumeFrom(limit))
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.MatchedBy(func(transaction *domain.Transaction) bool {
+		return transaction.TransactionInterest == 0.5 && transaction.TransactionPricingRuleID == nil && transaction.TransactionPricingOverride
+	})).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+	mockInstallmentRepo.On("CloseOutstandingInstallmentsWithTx", mock.Anything, uint(1), domain.InstallmentStatusRestructured).Return(nil)
+	mockTransactionRepo.On("UpdateTransactionStatusWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockTransactionRepo.On("CreateTransactionRestructureWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := transactionUsecase.RestructureTransaction(1, mockTransaction, domain.TransactionRestructureInput{
+		RestructureType:     domain.RestructureTypeExtendTenor,
+		RestructureTenor:    3,
+		RestructureInterest: 0.5,
+		RestructureReason:   "rate relief",
+	})
+
+	assert.Nil(t, err)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestRestructureTransaction_StaleStatusRechecksLockedRow(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusActive}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(1)).
+		Return(&domain.Transaction{TransactionID: 1, TransactionLimit: 1, TransactionStatus: domain.TransactionStatusRestructured}, nil)
+
+	restructured, err := transactionUsecase.RestructureTransaction(1, mockTransaction, domain.TransactionRestructureInput{
+		RestructureType:   domain.RestructureTypeExtendTenor,
+		RestructureTenor:  6,
+		RestructureReason: "income reduced",
+	})
+
+	assert.Nil(t, restructured)
+	assert.ErrorIs(t, err, usecase.ErrTransactionNotActive, "A concurrent restructure should be caught on the locked row")
+	mockInstallmentRepo.AssertNotCalled(t, "GetOutstandingInstallmentsWithTx", mock.Anything, mock.Anything)
+	mockTransactionRepo.AssertNotCalled(t, "CreateTransactionWithTx", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransaction_CrossTenorMovesLimit(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:          1,
+		TransactionNIK:         "1234567890123456",
+		TransactionLimit:       1,
+		TransactionOTR:         money.New(1000000),
+		TransactionInstallment: 3,
+		TransactionStatus:      domain.TransactionStatusActive,
+	}
+
+	oldLimit := &domain.Limit{LimitID: 1, LimitTenor: 3, LimitAmount: money.New(1000000), LimitUsedAmount: money.New(1000000), LimitRemainingAmount: money.New(0)}
+	newLimit := &domain.Limit{LimitID: 2, LimitTenor: 6, LimitAmount: money.New(2000000), LimitUsedAmount: money.New(0), LimitRemainingAmount: money.New(2000000)}
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1500000),
+		TransactionInstallment: 6,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, mockTransaction.TransactionID).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, uint(1)).Return(false, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", "1234567890123456", 6.0).Return(newLimit, nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.MatchedBy(func(limit *domain.Limit) bool {
+		return limit.LimitID == 1
+	}), money.New(1000000)).Return(releaseTo(oldLimit))
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, newLimit, money.New(1500000)).Return(consumeFrom(newLimit))
+	mockLimitRepo.On("GetCustomerLimitByNIKWithTx", mock.Anything, mock.Anything).Return(&domain.CustomerLimit{}, nil)
+	mockTransactionRepo.On("UpdateTransactionWithTx", mock.Anything, mockTransaction).Return(nil)
+	mockInstallmentRepo.On("VoidInstallmentsByTransactionIDWithTx", mock.Anything, uint(1)).Return(nil)
+	mockInstallmentRepo.On("CreateInstallmentsWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(), mockTransaction, input)
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(2), mockTransaction.TransactionLimit)
+	assert.Equal(t, money.New(0), oldLimit.LimitUsedAmount, "Old tenor limit should be released")
+	assert.Equal(t, money.New(1000000), oldLimit.LimitRemainingAmount)
+	assert.Equal(t, money.New(1500000), newLimit.LimitUsedAmount, "New tenor limit should be consumed")
+	assert.Equal(t, money.New(500000), newLimit.LimitRemainingAmount)
+	mockLimitRepo.AssertNotCalled(t, "UpdateLimitWithTx", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransaction_RejectsDifferentNIK(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:          1,
+		TransactionNIK:         "1234567890123456",
+		TransactionLimit:       1,
+		TransactionOTR:         money.New(1000000),
+		TransactionInstallment: 3,
+		TransactionStatus:      domain.TransactionStatusActive,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, mockTransaction.TransactionID).Return(lockedAs(mockTransaction))
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "6543210987654321"}, transactionProduct(), mockTransaction, domain.TransactionInput{
+		TransactionNIK:         "6543210987654321",
+		TransactionOTR:         money.New(1500000),
+		TransactionInstallment: 6,
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrTransactionNIKMismatch)
+	assert.Equal(t, uint(1), mockTransaction.TransactionLimit, "Contract should stay on its own limit")
+	mockLimitRepo.AssertNotCalled(t, "GetLimitByNIKandTenor", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "ConsumeLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransaction_CrossTenorInsufficientLimit(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	mockTransaction := &domain.Transaction{
+		TransactionID:          1,
+		TransactionNIK:         "1234567890123456",
+		TransactionLimit:       1,
+		TransactionOTR:         money.New(1000000),
+		TransactionInstallment: 3,
+		TransactionStatus:      domain.TransactionStatusActive,
+	}
+
+	oldLimit := &domain.Limit{LimitID: 1, LimitTenor: 3, LimitAmount: money.New(5000000), LimitUsedAmount: money.New(1000000), LimitRemainingAmount: money.New(4000000)}
+	newLimit := &domain.Limit{LimitID: 2, LimitTenor: 6, LimitAmount: money.New(1000000), LimitRemainingAmount: money.New(1000000)}
+
+	mockTransactionRepo.On("WithTransaction", mock.AnythingOfType("func(*gorm.DB) error")).
+		Return(func(fn func(*gorm.DB) error) error {
+			return fn(nil)
+		})
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, mockTransaction.TransactionID).Return(lockedAs(mockTransaction))
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, uint(1)).Return(false, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", "1234567890123456", 6.0).
+		Return(newLimit, nil)
+	mockLimitRepo.On("ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything).Return(releaseTo(oldLimit))
+	mockLimitRepo.On("CreateLimitMovementWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockLimitRepo.On("ConsumeLimitWithTx", mock.Anything, newLimit, money.New(1500000)).Return(consumeFrom(newLimit))
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(), mockTransaction, domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(1500000),
+		TransactionInstallment: 6,
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrInsufficientLimit)
+	assert.Equal(t, uint(1), mockTransaction.TransactionLimit)
+	assert.Equal(t, 3.0, mockTransaction.TransactionInstallment, "Transaction should be left untouched")
+	assert.Equal(t, money.New(1000000), newLimit.LimitRemainingAmount, "Conditional update should not apply")
+	mockTransactionRepo.AssertNotCalled(t, "UpdateTransactionWithTx", mock.Anything, mock.Anything)
+}
+
+func lockedAs(transaction *domain.Transaction) func(*gorm.DB, uint) (*domain.Transaction, error) {
+	return func(*gorm.DB, uint) (*domain.Transaction, error) {
+		locked := *transaction
+		return &locked, nil
+	}
+}
+
+func consumeFrom(balance *domain.Limit) func(*gorm.DB, *domain.Limit, money.Money) (bool, error) {
+	return func(_ *gorm.DB, limit *domain.Limit, amount money.Money) (bool, error) {
+		if balance.LimitRemainingAmount.LessThan(amount) {
+			return false, nil
+		}
+		balance.LimitUsedAmount = balance.LimitUsedAmount.Add(amount)
+		balance.LimitRemainingAmount = balance.LimitRemainingAmount.Sub(amount)
+		*limit = *balance
+		return true, nil
+	}
+}
+
+func releaseTo(balance *domain.Limit) func(*gorm.DB, *domain.Limit, money.Money) error {
+	return func(_ *gorm.DB, limit *domain.Limit, amount money.Money) error {
+		balance.LimitUsedAmount = balance.LimitUsedAmount.Sub(amount)
+		balance.LimitRemainingAmount = balance.LimitRemainingAmount.Add(amount)
+		*limit = *balance
+		return nil
+	}
+}
+
+func TestCreateTransaction_RejectsUnaffordableInstallment(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{MaxInstallmentRatio: 30})
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(6000000),
+		TransactionInstallment: 6,
+		TransactionAssetName:   "Motorcycle",
+	}
+
+	customer := &domain.Customer{
+		CustomerNIK:       "1234567890123456",
+		CustomerSalary:    money.New(5000000),
+		CustomerKYCStatus: domain.CustomerKYCVerified,
+	}
+
+	limit := &domain.Limit{
+		LimitID:              1,
+		LimitNIK:             "1234567890123456",
+		LimitTenor:           6,
+		LimitRemainingAmount: money.New(20000000),
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(limit, nil)
+	mockInstallmentRepo.On("GetMonthlyObligationByNIKWithTx", mock.Anything, "1234567890123456", uint(0)).Return(money.New(1200000), nil)
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, customer, transactionProduct(), input)
+
+	assert.ErrorIs(t, err, usecase.ErrAffordabilityExceeded)
+	var affordabilityErr *usecase.AffordabilityError
+	assert.True(t, errors.As(err, &affordabilityErr))
+	assert.Equal(t, domain.AffordabilityReasonRatioExceeded, affordabilityErr.Rejection.Reason)
+	assert.Equal(t, money.New(1000000), affordabilityErr.Rejection.NewInstallment)
+	assert.Equal(t, money.New(2200000), affordabilityErr.Rejection.TotalInstallments)
+	assert.Equal(t, money.New(1500000), affordabilityErr.Rejection.MaxAffordableInstallment)
+	assert.Equal(t, 44.0, affordabilityErr.Rejection.InstallmentRatio)
+	mockTransactionRepo.AssertNotCalled(t, "CreateTransactionWithTx", mock.Anything, mock.Anything)
+	mockLimitRepo.AssertNotCalled(t, "ConsumeLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreateTransaction_DraftSkipsAffordabilityCheck(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{MaxInstallmentRatio: 30})
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(60000000),
+		TransactionInstallment: 6,
+		TransactionAssetName:   "Car",
+		TransactionStatus:      domain.TransactionStatusDraft,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(&domain.Limit{LimitID: 1}, nil)
+	mockTransactionRepo.On("CreateTransactionWithTx", mock.Anything, mock.Anything).Return(nil)
+	mockTransactionRepo.On("CreateTransactionStatusHistoryWithTx", mock.Anything, mock.Anything).Return(nil)
+
+	err := transactionUsecase.CreateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456", CustomerKYCStatus: domain.CustomerKYCVerified, CustomerSalary: money.New(1000000)}, transactionProduct(), input)
+
+	assert.Nil(t, err)
+	mockInstallmentRepo.AssertNotCalled(t, "GetMonthlyObligationByNIKWithTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransaction_RechecksLockedRow(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	stale := &domain.Transaction{
+		TransactionID:          7,
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(3000000),
+		TransactionInstallment: 6,
+		TransactionLimit:       1,
+		TransactionStatus:      domain.TransactionStatusActive,
+	}
+	locked := *stale
+	locked.TransactionStatus = domain.TransactionStatusCancelled
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(7)).Return(&locked, nil)
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(6), stale, domain.TransactionInput{
+		TransactionNIK: "1234567890123456",
+		TransactionOTR: money.New(4000000),
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrTransactionNotEditable, "A concurrent cancel should be seen once the row is locked")
+	mockLimitRepo.AssertNotCalled(t, "ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransaction_DraftKeepsTenorWhenOmitted(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	transaction := &domain.Transaction{
+		TransactionID:          7,
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(3000000),
+		TransactionInstallment: 6,
+		TransactionLimit:       2,
+		TransactionStatus:      domain.TransactionStatusDraft,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(7)).Return(lockedAs(transaction))
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, uint(7)).Return(false, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", "1234567890123456", 6.0).Return(&domain.Limit{LimitID: 2, LimitTenor: 6}, nil)
+	mockTransactionRepo.On("UpdateTransactionWithTx", mock.Anything, mock.MatchedBy(func(updated *domain.Transaction) bool {
+		return updated.TransactionLimit == 2 && updated.TransactionInstallment == 6 && updated.TransactionOTR == money.New(4000000)
+	})).Return(nil)
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(6), transaction, domain.TransactionInput{
+		TransactionNIK: "1234567890123456",
+		TransactionOTR: money.New(4000000),
+	})
+
+	assert.Nil(t, err)
+	mockLimitRepo.AssertExpectations(t)
+	mockTransactionRepo.AssertExpectations(t)
+}
+
+func TestUpdateTransaction_DraftWithoutLimit(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{})
+
+	transaction := &domain.Transaction{
+		TransactionID:          7,
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(3000000),
+		TransactionInstallment: 6,
+		TransactionLimit:       2,
+		TransactionStatus:      domain.TransactionStatusDraft,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, uint(7)).Return(lockedAs(transaction))
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, uint(7)).Return(false, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", "1234567890123456", 12.0).Return(&domain.Limit{}, nil)
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456"}, transactionProduct(6, 12), transaction, domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionInstallment: 12,
+	})
+
+	assert.ErrorIs(t, err, usecase.ErrInsufficientLimit)
+	assert.Equal(t, uint(2), transaction.TransactionLimit)
+	mockTransactionRepo.AssertNotCalled(t, "UpdateTransactionWithTx", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTransaction_AffordabilityExcludesAmendedContract(t *testing.T) {
+	mockTransactionRepo := new(mocks.TransactionRepository)
+	mockLimitRepo := new(mocks.LimitRepository)
+	mockCustomerRepo := new(mocks.CustomerRepository)
+	mockInstallmentRepo := new(mocks.InstallmentRepository)
+	mockProductRepo := new(mocks.ProductRepository)
+	transactionUsecase := usecase.NewTransactionUsecase(mockCustomerRepo, mockLimitRepo, mockTransactionRepo, mockInstallmentRepo, mockProductRepo, pricingRules(), negativeList(), config.AffordabilityConfig{MaxInstallmentRatio: 30})
+
+	transaction := &domain.Transaction{
+		TransactionID:          7,
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(3000000),
+		TransactionInstallment: 6,
+		TransactionLimit:       1,
+		TransactionStatus:      domain.TransactionStatusActive,
+		TransactionScheme:      "flat",
+	}
+
+	input := domain.TransactionInput{
+		TransactionNIK:         "1234567890123456",
+		TransactionOTR:         money.New(12000000),
+		TransactionInstallment: 6,
+	}
+
+	mockTransactionRepo.On("WithTransaction", mock.Anything).Return(func(fn func(*gorm.DB) error) error { return fn(nil) })
+	mockTransactionRepo.On("GetTransactionByIDWithTx", mock.Anything, transaction.TransactionID).Return(lockedAs(transaction))
+	mockInstallmentRepo.On("HasPaidInstallmentsWithTx", mock.Anything, uint(7)).Return(false, nil)
+	mockLimitRepo.On("GetLimitByNIKandTenor", input.TransactionNIK, input.TransactionInstallment).Return(&domain.Limit{LimitID: 1, LimitTenor: 6, LimitRemainingAmount: money.New(50000000)}, nil)
+	mockInstallmentRepo.On("GetMonthlyObligationByNIKWithTx", mock.Anything, "1234567890123456", uint(7)).Return(money.Money{}, nil)
+
+	err := transactionUsecase.UpdateTransactionWithLimitUpdate(1, &domain.Customer{CustomerNIK: "1234567890123456", CustomerSalary: money.New(5000000)}, transactionProduct(6), transaction, input)
+
+	var affordabilityErr *usecase.AffordabilityError
+	assert.True(t, errors.As(err, &affordabilityErr))
+	assert.Equal(t, money.New(2000000), affordabilityErr.Rejection.TotalInstallments)
+	assert.Equal(t, money.New(3000000), transaction.TransactionOTR, "Rejected amendment should leave the transaction untouched")
+	mockLimitRepo.AssertNotCalled(t, "ReleaseLimitWithTx", mock.Anything, mock.Anything, mock.Anything)
+	mockInstallmentRepo.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package utils
 
 import (
 	"html"
+	"kreditplus/internal/money"
 	"math"
 	"regexp"
 	"strings"
@@ -49,6 +50,13 @@ func SanitizeNumberFloat64(value float64) float64 {
 	return value
 }
 
+func SanitizeMoney(value money.Money) money.Money {
+	if value.IsNegative() {
+		return money.Money{}
+	}
+	return value
+}
+
 func SanitizeDate(date interface{}) time.Time {
 	switch v := date.(type) {
 	case string:
@@ -63,3 +71,19 @@ func SanitizeDate(date interface{}) time.Time {
 		return time.Time{}
 	}
 }
+
+var nameHonorifics = map[string]bool{"h": true, "hj": true, "dr": true, "drs": true, "dra": true, "ir": true, "prof": true}
+
+func NameTokens(name string) []string {
+	tokens := strings.FieldsFunc(strings.ToLower(html.UnescapeString(name)), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+
+	normalized := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		if !nameHonorifics[token] {
+			normalized = append(normalized, token)
+		}
+	}
+	return normalized
+}
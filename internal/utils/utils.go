@@ -3,9 +3,11 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"kreditplus/internal/money"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"reflect"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,7 +17,18 @@ import (
 
 var Logger = logrus.New()
 
-var Validate = validator.New()
+var Validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if amount, ok := field.Interface().(money.Money); ok {
+			return amount.Float64()
+		}
+		return nil
+	}, money.Money{})
+	return v
+}
 
 func SaveUploadedFile(c *gin.Context, formFieldName string, uploadDir string) (string, error) {
 	file, err := c.FormFile(formFieldName)
@@ -3,13 +3,26 @@ package main
 import (
 	"kreditplus/config"
 	"kreditplus/internal/domain"
+	"kreditplus/internal/job"
 	"kreditplus/internal/route"
 	"log"
+	"os"
 )
 
 func main() {
 	config.ConnectDB()
-	config.DB.AutoMigrate(&domain.User{}, &domain.Customer{}, &domain.Limit{}, &domain.Transaction{})
+	if err := config.DB.AutoMigrate(&domain.User{}, &domain.Customer{}, &domain.Limit{}, &domain.Transaction{}, &domain.Installment{}, &domain.Payment{}, &domain.TransactionStatusHistory{}, &domain.TransactionRestructure{}, &domain.LimitMovement{}, &domain.CustomerLimit{}, &domain.Product{}, &domain.ProductTenor{}, &domain.PricingRule{}, &domain.NegativeListEntry{}, &domain.CustomerAddress{}, &domain.CustomerPhoneNumber{}, &domain.CustomerEmailAddress{}, &domain.CustomerEmployment{}, &domain.CustomerEmergencyContact{}); err != nil {
+		log.Fatal("Database migration failed: ", err)
+	}
+	if err := job.OpenLimitLedgers(); err != nil {
+		log.Fatal("Limit ledger migration failed: ", err)
+	}
+	if err := job.MergeDuplicateLimits(); err != nil {
+		log.Fatal("Duplicate limit merge failed: ", err)
+	}
+	if err := config.DB.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_limits_nik_tenor ON limits (limit_nik, limit_tenor)`).Error; err != nil {
+		log.Fatal("Limit uniqueness migration failed: ", err)
+	}
 
 	var count int64
 	config.DB.Model(&domain.User{}).Where("user_username = ?", "admin").Count(&count)
@@ -26,6 +39,12 @@ func main() {
 		log.Println("Admin user already exists")
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "reconcile-limits" {
+		os.Exit(job.RunLimitReconciliation(os.Args[2:]))
+	}
+
+	job.StartOverdueJob()
+
 	r := route.SetupRouter()
 	r.SetTrustedProxies([]string{"127.0.0.1"})
 	log.Println("Server running on port 8080")